@@ -12,14 +12,19 @@ import (
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
 	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/cache"
 	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
 	"github.com/Shofyan/url-shortener/internal/infrastructure/config"
 	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
-	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
 	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/metrics"
 	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
 	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
 	"github.com/Shofyan/url-shortener/internal/interfaces/http/router"
@@ -37,8 +42,8 @@ func main() {
 	defer closeDependencies(db, redisClient)
 
 	// Initialize and start services
-	srv, cleanupService := initializeServices(cfg, db, redisClient)
-	startServer(srv, cleanupService)
+	srv, cleanupService, clickRecorder := initializeServices(cfg, db, redisClient)
+	startServer(srv, cleanupService, clickRecorder)
 }
 
 // initializeDependencies sets up database and Redis connections.
@@ -56,14 +61,23 @@ func initializeDependencies(cfg *config.Config) (*sql.DB, *redis.Client) {
 
 	log.Println("✓ Connected to PostgreSQL")
 
-	// Initialize Redis
-	redisClient, err := redisCache.NewRedisClient(
-		cfg.Redis.GetRedisAddr(),
-		cfg.Redis.Password,
-		cfg.Redis.DB,
-		cfg.Redis.PoolSize,
-		cfg.Redis.MinIdleConns,
-	)
+	// Redis is only needed by the redis cache backend and the shared rate limiter
+	if !cache.NeedsRedis(cfg.Cache.Backend) && cfg.App.RateLimitBackend != "redis" {
+		log.Printf("✓ Using %s cache backend; skipping Redis", cfg.Cache.Backend)
+
+		return db, nil
+	}
+
+	redisClient, err := redisCache.NewRedisClient(redisCache.ClientConfig{
+		Addr:         cfg.Redis.GetRedisAddr(),
+		Password:     cfg.Redis.Password,
+		DB:           cfg.Redis.DB,
+		PoolSize:     cfg.Redis.PoolSize,
+		MinIdleConns: cfg.Redis.MinIdleConns,
+		DialTimeout:  cfg.Redis.DialTimeout,
+		ReadTimeout:  cfg.Redis.ReadTimeout,
+		WriteTimeout: cfg.Redis.WriteTimeout,
+	})
 	if err != nil {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
@@ -79,25 +93,67 @@ func closeDependencies(db *sql.DB, redisClient *redis.Client) {
 		log.Printf("Failed to close database connection: %v", err)
 	}
 
+	if redisClient == nil {
+		return
+	}
+
 	if err := redisClient.Close(); err != nil {
 		log.Printf("Failed to close Redis connection: %v", err)
 	}
 }
 
 // initializeServices sets up all services and HTTP server.
-func initializeServices(cfg *config.Config, db *sql.DB, redisClient *redis.Client) (*http.Server, *service.BackgroundURLCleanupService) {
+func initializeServices(
+	cfg *config.Config,
+	db *sql.DB,
+	redisClient *redis.Client,
+) (*http.Server, *service.BackgroundURLCleanupService, *usecase.ClickRecorder) {
 	// Initialize repositories
 	urlRepo := postgres.NewURLRepository(db)
-	cacheRepo := redisCache.NewCacheRepository(redisClient)
+	urlRepo.SetDeleteChunkSize(cfg.App.CleanupDeleteChunkSize)
+	clickRepo := postgres.NewClickRepository(db)
+	historyRepo := postgres.NewDestinationHistoryRepository(db)
+
+	cacheRepo, err := cache.NewRepository(cache.Config{
+		Backend:   cfg.Cache.Backend,
+		KeyPrefix: cfg.Redis.KeyPrefix,
+	}, redisClient)
+	if err != nil {
+		log.Fatalf("Invalid cache configuration: %v", err)
+	}
 
 	// Initialize generators
-	snowflakeGen, err := snowflake.NewGenerator(cfg.App.SnowflakeNodeID)
+	snowflakeEpoch, err := cfg.App.GetSnowflakeEpoch()
+	if err != nil {
+		log.Fatalf("Invalid Snowflake configuration: %v", err)
+	}
+
+	snowflakeGen, err := snowflake.NewGeneratorWithEpoch(cfg.App.SnowflakeNodeID, snowflakeEpoch)
 	if err != nil {
 		log.Fatalf("Failed to create Snowflake generator: %v", err)
 	}
 
-	base62Gen := base62.NewGenerator()
-	generatorService := service.NewGeneratorService(snowflakeGen, base62Gen)
+	shortenConfig := cfg.App.GetShortenConfig()
+	shortenConfig.HashAnalyticsIdentifiers = cfg.Analytics.HashIdentifiers
+
+	randomCfg := random.DefaultConfig()
+	randomCfg.MinLength = cfg.App.ShortKeyMinLength
+	randomCfg.MaxLength = cfg.App.ShortKeyMaxLength
+	randomCfg.CollisionThreshold = cfg.App.ShortKeyCollisionThreshold
+	randomCfg.MaxAttempts = shortenConfig.MaxKeyAttempts
+	randomCfg.CollisionBackoff = shortenConfig.CollisionBackoff
+
+	generatorService, err := generator.NewGeneratorServiceFromConfig(generator.Config{
+		Strategy:     cfg.App.ShortKeyStrategy,
+		KeyProfile:   cfg.App.KeyProfile,
+		KeyAlphabet:  cfg.App.KeyAlphabet,
+		Random:       randomCfg,
+		Blocklist:    cfg.App.KeyBlocklist,
+		BlockedWords: cfg.App.KeyBlocklistWords,
+	}, snowflakeGen, urlRepo)
+	if err != nil {
+		log.Fatalf("Invalid short key generator configuration: %v", err)
+	}
 
 	// Initialize cleanup service
 	cleanupService := service.NewBackgroundURLCleanupService(
@@ -107,21 +163,75 @@ func initializeServices(cfg *config.Config, db *sql.DB, redisClient *redis.Clien
 	)
 
 	// Initialize use cases
-	shortenUseCase := usecase.NewShortenURLUseCase(
+	shortenUseCase := usecase.NewShortenURLUseCaseWithConfig(
 		urlRepo,
 		cacheRepo,
 		generatorService,
 		cfg.App.BaseURL,
 		cfg.App.CacheTTL,
+		shortenConfig,
 	)
+	shortenUseCase.SetDestinationHistory(historyRepo)
+
+	if estimator, ok := cacheRepo.(repository.VisitorEstimator); ok && cfg.App.UniqueVisitorEstimation {
+		shortenUseCase.SetVisitorEstimator(estimator, cfg.App.UniqueVisitorTTL)
+	}
+
+	analyticsUseCase := usecase.NewAnalyticsUseCase(urlRepo, clickRepo)
+
+	// Clicks are buffered and written in batches off the redirect path; nil when disabled
+	var clickRecorder *usecase.ClickRecorder
+	if cfg.Analytics.RecordClicks {
+		clickRecorder = usecase.NewClickRecorder(clickRepo, usecase.ClickRecorderConfig{
+			QueueSize:     cfg.Analytics.ClickQueueSize,
+			BatchSize:     cfg.Analytics.ClickBatchSize,
+			FlushInterval: cfg.Analytics.ClickFlushInterval,
+		})
+		shortenUseCase.SetClickRecorder(clickRecorder)
+	}
 
 	// Initialize handlers and middleware
 	urlHandler := handler.NewURLHandler(shortenUseCase, cleanupService)
+	urlHandler.SetCacheStatusHeader(cfg.App.CacheStatusHeader)
+	urlHandler.SetStatsCacheMaxAge(cfg.App.StatsCacheMaxAge)
+	urlHandler.SetRuntimeInfo(cfg.App.GetRuntimeInfo())
+	urlHandler.SetBatchLimits(handler.BatchLimits{Default: cfg.App.BatchMaxItems, Endpoints: cfg.App.BatchLimits})
+
+	redirectMetrics, err := metrics.NewRedirectMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatalf("Failed to register redirect metrics: %v", err)
+	}
+
+	urlHandler.SetRedirectObserver(redirectMetrics)
+
+	useCaseMetrics, err := metrics.NewUseCaseMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatalf("Failed to register use case metrics: %v", err)
+	}
+
+	shortenUseCase.SetMetricsObserver(useCaseMetrics)
+
+	if err := metrics.RegisterCleanupMetrics(prometheus.DefaultRegisterer, cleanupService.GetCleanupStats); err != nil {
+		log.Fatalf("Failed to register cleanup metrics: %v", err)
+	}
+
+	httpMetrics, err := metrics.NewHTTPMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatalf("Failed to register HTTP metrics: %v", err)
+	}
+
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsUseCase)
 	webHandler := handler.NewWebHandler()
-	rateLimiter := middleware.NewRateLimiter(cfg.App.RateLimitRequests, cfg.App.RateLimitRequests)
+
+	rateLimitMetrics, err := metrics.NewRateLimitMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		log.Fatalf("Failed to register rate limit metrics: %v", err)
+	}
+
+	rateLimiter := newRateLimiter(cfg, redisClient, rateLimitMetrics)
 
 	// Setup router and server
-	r := router.SetupRouter(cfg, urlHandler, webHandler, rateLimiter)
+	r := router.SetupRouter(cfg, urlHandler, analyticsHandler, webHandler, rateLimiter, httpMetrics)
 	srv := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
 		Handler:      r,
@@ -130,11 +240,39 @@ func initializeServices(cfg *config.Config, db *sql.DB, redisClient *redis.Clien
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 
-	return srv, cleanupService
+	return srv, cleanupService, clickRecorder
 }
 
-// startServer starts the HTTP server and cleanup service with graceful shutdown.
-func startServer(srv *http.Server, cleanupService *service.BackgroundURLCleanupService) {
+// newRateLimiter builds the rate limiter selected by app.ratelimitbackend, with its
+// route group limits, bucket key and API key tier. Denied requests go to observer.
+func newRateLimiter(cfg *config.Config, redisClient *redis.Client, observer middleware.ThrottleObserver) middleware.Limiter {
+	key := middleware.ClientIPKey
+	if cfg.App.RateLimitAPIKeyHeader != "" && len(cfg.App.RateLimitAPIKeys) > 0 {
+		key = middleware.APIKeyOrIPKey(cfg.App.RateLimitAPIKeyHeader, cfg.App.RateLimitAPIKeys)
+	}
+
+	if cfg.App.RateLimitBackend == "redis" {
+		rateLimiter := middleware.NewRedisRateLimiter(redisClient, cfg.Redis.KeyPrefix, cfg.App.RateLimitRequests, cfg.App.RateLimitWindow)
+		rateLimiter.SetGroupLimits(cfg.App.RateLimitGroups)
+		rateLimiter.SetKeyFunc(key)
+		rateLimiter.SetAPIKeyMultiplier(cfg.App.RateLimitAPIKeyMultiplier)
+		rateLimiter.SetThrottleObserver(observer)
+
+		return rateLimiter
+	}
+
+	rateLimiter := middleware.NewRateLimiter(cfg.App.RateLimitRequests, cfg.App.RateLimitRequests)
+	rateLimiter.SetGroupLimits(cfg.App.RateLimitGroups)
+	rateLimiter.SetKeyFunc(key)
+	rateLimiter.SetAPIKeyMultiplier(cfg.App.RateLimitAPIKeyMultiplier)
+	rateLimiter.SetThrottleObserver(observer)
+
+	return rateLimiter
+}
+
+// startServer starts the HTTP server, cleanup service and click recorder with graceful
+// shutdown. clickRecorder is nil when click recording is disabled.
+func startServer(srv *http.Server, cleanupService *service.BackgroundURLCleanupService, clickRecorder *usecase.ClickRecorder) {
 	// Start server in a goroutine
 	go func() {
 		log.Printf("🚀 Server starting on port %s", strings.TrimPrefix(srv.Addr, ":"))
@@ -152,6 +290,11 @@ func startServer(srv *http.Server, cleanupService *service.BackgroundURLCleanupS
 		log.Println("✓ URL cleanup service started")
 	}
 
+	if clickRecorder != nil {
+		clickRecorder.Start()
+		log.Println("✓ Click recorder started")
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -175,5 +318,11 @@ func startServer(srv *http.Server, cleanupService *service.BackgroundURLCleanupS
 
 	cancel()
 
+	// Write the clicks of requests that finished during shutdown
+	if clickRecorder != nil {
+		clickRecorder.Stop()
+		log.Println("✓ Click recorder stopped")
+	}
+
 	log.Println("Server exited")
 }
@@ -0,0 +1,44 @@
+// Package apperror defines application errors that carry their API representation.
+//
+// Use cases return *Error values so that interface adapters can translate any
+// failure into a response without knowing every sentinel individually. The
+// HTTP status lives here rather than in each handler, keeping the mapping in
+// one place as new errors are introduced.
+package apperror
+
+import "net/http"
+
+// Error is an application error with a stable machine-readable code and the
+// HTTP status it should be reported with.
+type Error struct {
+	// Code is the machine-readable error identifier returned to clients
+	Code string
+	// HTTPStatus is the status code used when the error is returned over HTTP
+	HTTPStatus int
+	// Message is the human-readable description
+	Message string
+}
+
+// New creates an application error.
+func New(code string, httpStatus int, message string) *Error {
+	return &Error{
+		Code:       code,
+		HTTPStatus: httpStatus,
+		Message:    message,
+	}
+}
+
+// NotFound creates an application error reported as 404 Not Found.
+func NotFound(code, message string) *Error {
+	return New(code, http.StatusNotFound, message)
+}
+
+// BadRequest creates an application error reported as 400 Bad Request.
+func BadRequest(code, message string) *Error {
+	return New(code, http.StatusBadRequest, message)
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Message
+}
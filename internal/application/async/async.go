@@ -0,0 +1,55 @@
+// Package async runs fire-and-forget work without letting it take the process down.
+//
+// Hooks such as webhooks, analytics and audit logging run after the response
+// has been decided. A panic in one of them must neither crash the server nor
+// affect the request that triggered it, so every such dispatch goes through Go,
+// which recovers the panic, logs it with the originating request ID and counts it.
+package async
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"sync/atomic"
+)
+
+// requestIDKey is the context key under which the request ID is stored.
+type requestIDKey struct{}
+
+// panics counts recovered panics since the process started.
+var panics atomic.Int64
+
+// WithRequestID returns a copy of ctx carrying the request ID used in panic logs.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestID returns the request ID stored in ctx, or "" when there is none.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// Go runs fn on its own goroutine. fn receives a context that keeps ctx's values
+// but not its cancellation, so work outlives the request that started it.
+// A panic in fn is recovered, logged under name and counted in PanicCount.
+func Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				panics.Add(1)
+				log.Printf("[async] Recovered panic in %s (request_id=%s): %v\n%s",
+					name, RequestID(ctx), recovered, debug.Stack())
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+// PanicCount returns how many panics Go has recovered since the process started.
+func PanicCount() int64 {
+	return panics.Load()
+}
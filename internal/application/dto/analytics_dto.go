@@ -0,0 +1,39 @@
+package dto
+
+// FullStatsResponse combines URL stats with its click timeline and top referrers.
+type FullStatsResponse struct {
+	Stats        *URLStatsResponse `json:"stats"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Timeline     []DailyClicks     `json:"timeline"`
+	TopReferrers []ReferrerClicks  `json:"top_referrers"`
+}
+
+// TimeseriesResponse is the daily click timeline of a short URL.
+type TimeseriesResponse struct {
+	ShortKey    string        `json:"short_key"`
+	From        string        `json:"from"`
+	To          string        `json:"to"`
+	TotalClicks int64         `json:"total_clicks"`
+	Timeline    []DailyClicks `json:"timeline"`
+}
+
+// DailyClicks represents the clicks received on one UTC day.
+type DailyClicks struct {
+	Date   string `json:"date"` // YYYY-MM-DD
+	Clicks int64  `json:"clicks"`
+}
+
+// ReferrerClicks represents the clicks attributed to one referrer.
+type ReferrerClicks struct {
+	Referrer string `json:"referrer"`
+	Clicks   int64  `json:"clicks"`
+}
+
+// TopURLsResponse lists the most-visited URLs, most visited first.
+type TopURLsResponse struct {
+	Items []URLStatsResponse `json:"items"`
+	Limit int                `json:"limit"`
+	// Creation cutoff in RFC 3339; omitted when every URL is ranked
+	Since string `json:"since,omitempty"`
+}
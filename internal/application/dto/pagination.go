@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/apperror"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded.
+var ErrInvalidCursor = apperror.BadRequest("invalid_cursor", "invalid pagination cursor")
+
+// PageResponse represents one page of a cursor-paginated list.
+type PageResponse[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// OffsetPagination describes where an offset-paginated page sits in the full list.
+type OffsetPagination struct {
+	Limit   int   `json:"limit"`
+	Offset  int   `json:"offset"`
+	Total   int64 `json:"total"`
+	HasMore bool  `json:"has_more"`
+}
+
+// PageCursor identifies the last item of a page for keyset pagination.
+// Lists are ordered by (created_at, id) so the pair is always unique.
+type PageCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int64     `json:"id"`
+}
+
+// EncodeCursor serializes a cursor into an opaque URL-safe token.
+func EncodeCursor(cursor PageCursor) string {
+	data, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by EncodeCursor.
+// An empty token decodes to nil, meaning the first page.
+func DecodeCursor(token string) (*PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &cursor, nil
+}
+
+// NewPageResponse builds a page from items fetched with limit+1 rows.
+// The extra row only signals that another page exists and is dropped from the result.
+func NewPageResponse[T any](items []T, limit int, cursorOf func(T) PageCursor) *PageResponse[T] {
+	page := &PageResponse[T]{Items: items}
+
+	if limit > 0 && len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		page.NextCursor = EncodeCursor(cursorOf(page.Items[limit-1]))
+	}
+
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+
+	return page
+}
@@ -5,6 +5,14 @@ type ShortenURLRequest struct {
 	LongURL    string `json:"long_url" binding:"required"`
 	CustomKey  string `json:"custom_key,omitempty"`
 	TTLSeconds int64  `json:"ttl_seconds,omitempty"` // Time-to-live in seconds
+	// Optional absolute expiry as an RFC 3339 timestamp; takes precedence over ttl_seconds
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// Optional cache TTL in seconds, capped at the URL's remaining lifetime
+	CacheTTLSeconds int64 `json:"cache_ttl_seconds,omitempty"`
+	// Legacy numeric ID to derive the short key from; honoured for admin callers only
+	ID int64 `json:"id,omitempty"`
+	// Namespace the URL belongs to; scopes deduplication when owner-scoped dedup is enabled
+	Owner string `json:"owner,omitempty"`
 }
 
 // ShortenURLResponse represents the response after shortening a URL.
@@ -14,6 +22,38 @@ type ShortenURLResponse struct {
 	LongURL   string `json:"long_url"`
 	CreatedAt string `json:"created_at"`
 	ExpiresAt string `json:"expires_at,omitempty"`
+	// True when the destination was already shortened and its existing key is returned
+	Reused bool `json:"reused"`
+}
+
+// BatchShortenResult is the outcome of one item of a batch shorten request.
+// Exactly one of Result and Error is set.
+type BatchShortenResult struct {
+	Index  int                 `json:"index"`
+	Result *ShortenURLResponse `json:"result,omitempty"`
+	Error  *ErrorResponse      `json:"error,omitempty"`
+}
+
+// BatchShortenResponse lists batch shorten results in request order.
+type BatchShortenResponse struct {
+	Results []BatchShortenResult `json:"results"`
+}
+
+// ResolveURLResponse is returned instead of a redirect when a client asks for JSON.
+type ResolveURLResponse struct {
+	LongURL   string `json:"long_url"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+// URLPreviewResponse describes where a short URL leads, for checking it before following.
+type URLPreviewResponse struct {
+	ShortKey string `json:"short_key"`
+	LongURL  string `json:"long_url"`
+	Host     string `json:"host"`
+	// Whether the destination is blocklisted; omitted when no blocklist is configured
+	Blocked *bool `json:"blocked,omitempty"`
+	// Page title of the destination; omitted without a fetcher or when it cannot be read
+	Title string `json:"title,omitempty"`
 }
 
 // URLStatsResponse represents URL statistics.
@@ -24,10 +64,122 @@ type URLStatsResponse struct {
 	CreatedAt      string `json:"created_at"`
 	ExpiresAt      string `json:"expires_at,omitempty"`
 	LastAccessedAt string `json:"last_accessed_at,omitempty"`
+	// Approximate distinct visitors; present only when unique visitor estimation is enabled
+	EstimatedUniqueVisitors *int64 `json:"estimated_unique_visitors,omitempty"`
+	// Why the URL was taken down; present only for disabled URLs with a recorded reason
+	DisabledReason string `json:"disabled_reason,omitempty"`
+}
+
+// URLListResponse represents one offset-paginated page of URLs.
+type URLListResponse struct {
+	Items      []URLStatsResponse `json:"items"`
+	Pagination OffsetPagination   `json:"pagination"`
+}
+
+// UpdateDestinationRequest represents the request to repoint a short URL.
+type UpdateDestinationRequest struct {
+	LongURL string `json:"long_url" binding:"required"`
+}
+
+// SetEnabledRequest represents the request to pause or resume a short URL.
+type SetEnabledRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// DisableURLRequest represents the request to take a short URL down.
+type DisableURLRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// RenewURLsRequest extends the expiry of every URL matching the criteria.
+// At least one criterion is required, timestamps use RFC 3339, and exactly one of
+// ttl_seconds or expires_at sets the new expiry. Confirm must be true.
+type RenewURLsRequest struct {
+	Host          string `json:"host,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+	KeyPrefix     string `json:"key_prefix,omitempty"`
+	CreatedAfter  string `json:"created_after,omitempty"`
+	CreatedBefore string `json:"created_before,omitempty"`
+	TTLSeconds    int64  `json:"ttl_seconds,omitempty"`
+	ExpiresAt     string `json:"expires_at,omitempty"`
+	Confirm       bool   `json:"confirm"`
+}
+
+// RenewURLsResponse reports how many URLs a bulk renewal extended.
+type RenewURLsResponse struct {
+	Renewed   int    `json:"renewed"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// DestinationChangeResponse is one repointing of a short URL.
+type DestinationChangeResponse struct {
+	PreviousURL string `json:"previous_url"`
+	NewURL      string `json:"new_url"`
+	ChangedAt   string `json:"changed_at"`
+}
+
+// DestinationHistoryResponse lists a short URL's destination changes, oldest first.
+type DestinationHistoryResponse struct {
+	ShortKey   string                      `json:"short_key"`
+	CurrentURL string                      `json:"current_url"`
+	Changes    []DestinationChangeResponse `json:"changes"`
 }
 
 // ErrorResponse represents an error response.
 type ErrorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
+	// Unused alternatives offered when a custom key is taken
+	Suggestions []string `json:"suggestions,omitempty"`
+	// Why the URL was disabled, when a disabled URL is requested
+	Reason string `json:"reason,omitempty"`
+}
+
+// UpdateCleanupConfigRequest changes cleanup tuning on the running service.
+// Durations use Go syntax such as "5m"; omitted fields keep their current value.
+type UpdateCleanupConfigRequest struct {
+	CleanupInterval string `json:"cleanup_interval,omitempty"`
+	BatchSize       *int   `json:"batch_size,omitempty"`
+	BufferTime      string `json:"buffer_time,omitempty"`
+}
+
+// CleanupConfigResponse describes the cleanup configuration in effect.
+type CleanupConfigResponse struct {
+	Enabled            bool   `json:"enabled"`
+	CleanupInterval    string `json:"cleanup_interval"`
+	BatchSize          int    `json:"batch_size"`
+	BufferTime         string `json:"buffer_time"`
+	MaxCleanupDuration string `json:"max_cleanup_duration"`
+}
+
+// RuntimeInfoResponse describes how an instance generates keys, for diagnosing fleet misconfiguration.
+type RuntimeInfoResponse struct {
+	Version    string `json:"version"`
+	BaseURL    string `json:"base_url"`
+	IDStrategy string `json:"id_strategy"`
+	KeyProfile string `json:"key_profile"`
+	// Alphabet with its middle masked, plus its length
+	KeyAlphabet       string                 `json:"key_alphabet"`
+	KeyAlphabetLength int                    `json:"key_alphabet_length"`
+	Snowflake         SnowflakeInfo          `json:"snowflake"`
+	Cleanup           *CleanupConfigResponse `json:"cleanup,omitempty"`
+}
+
+// SnowflakeInfo describes the Snowflake ID generator; node IDs must be unique across instances.
+type SnowflakeInfo struct {
+	NodeID int64  `json:"node_id"`
+	Epoch  string `json:"epoch"`
+}
+
+// SelfTestCheck is the outcome of one self-test check: pass, fail or skip.
+type SelfTestCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// SelfTestResponse reports a post-deploy self-test; Status is fail if any check failed.
+type SelfTestResponse struct {
+	Status string          `json:"status"`
+	Checks []SelfTestCheck `json:"checks"`
 }
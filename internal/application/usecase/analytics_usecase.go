@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/apperror"
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+const (
+	// DefaultTimelineDays is the timeline range used when none is requested.
+	DefaultTimelineDays = 30
+	// MaxTimelineDays bounds the timeline range to keep responses small.
+	MaxTimelineDays = 366
+	// DefaultTopReferrers is the number of referrers returned when none is requested.
+	DefaultTopReferrers = 10
+	// MaxTopReferrers bounds the number of referrers returned.
+	MaxTopReferrers = 100
+	// DefaultTopURLs is the leaderboard size used when none is requested.
+	DefaultTopURLs = 10
+	// MaxTopURLs bounds the leaderboard size.
+	MaxTopURLs = 100
+
+	dateLayout = "2006-01-02"
+)
+
+// ErrInvalidTimeRange is returned when a requested analytics range is empty or too long.
+var ErrInvalidTimeRange = apperror.BadRequest("invalid_request", "invalid time range")
+
+// FullStatsQuery selects the analytics range for GetFullStats.
+// From and To are UTC days; both are inclusive.
+type FullStatsQuery struct {
+	From         time.Time
+	To           time.Time
+	TopReferrers int
+}
+
+// AnalyticsUseCase composes URL stats with per-click analytics.
+type AnalyticsUseCase struct {
+	urlRepo   repository.URLRepository
+	clickRepo repository.ClickRepository
+}
+
+// NewAnalyticsUseCase creates a new AnalyticsUseCase.
+func NewAnalyticsUseCase(urlRepo repository.URLRepository, clickRepo repository.ClickRepository) *AnalyticsUseCase {
+	return &AnalyticsUseCase{
+		urlRepo:   urlRepo,
+		clickRepo: clickRepo,
+	}
+}
+
+// GetFullStats returns base stats plus the daily click timeline and top referrers in one response.
+// The timeline contains one entry per day in the range, with zero for days without clicks.
+func (uc *AnalyticsUseCase) GetFullStats(ctx context.Context, shortKeyStr string, query FullStatsQuery) (*dto.FullStatsResponse, error) {
+	shortKey, err := valueobject.NewShortKey(shortKeyStr)
+	if err != nil {
+		// A malformed key cannot name any stored URL
+		return nil, ErrURLNotFound
+	}
+
+	from, to, err := dayRange(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	topReferrers := query.TopReferrers
+	if topReferrers <= 0 || topReferrers > MaxTopReferrers {
+		topReferrers = DefaultTopReferrers
+	}
+
+	url, err := uc.urlRepo.FindByShortKey(ctx, shortKey)
+	if err != nil {
+		return nil, ErrURLNotFound
+	}
+
+	if url.IsExpired() {
+		return nil, ErrURLExpired
+	}
+
+	// Repository ranges are half-open, so query up to the start of the day after "to"
+	end := to.AddDate(0, 0, 1)
+
+	days, err := uc.clickRepo.GetClicksByDay(ctx, shortKey.Value(), from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	referrers, err := uc.clickRepo.GetTopReferrers(ctx, shortKey.Value(), from, end, topReferrers)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.FullStatsResponse{
+		Stats:        buildStatsResponse(url),
+		From:         from.Format(dateLayout),
+		To:           to.Format(dateLayout),
+		Timeline:     buildTimeline(from, end, days),
+		TopReferrers: make([]dto.ReferrerClicks, 0, len(referrers)),
+	}
+
+	for _, referrer := range referrers {
+		resp.TopReferrers = append(resp.TopReferrers, dto.ReferrerClicks{
+			Referrer: referrer.Referrer,
+			Clicks:   referrer.Clicks,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetTimeseries returns the daily click counts of a short URL from one UTC day to
+// another, both inclusive, with zero for days without clicks.
+func (uc *AnalyticsUseCase) GetTimeseries(ctx context.Context, shortKeyStr string, from, to time.Time) (*dto.TimeseriesResponse, error) {
+	shortKey, err := valueobject.NewShortKey(shortKeyStr)
+	if err != nil {
+		return nil, ErrURLNotFound
+	}
+
+	from, to, err = dayRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	url, err := uc.urlRepo.FindByShortKey(ctx, shortKey)
+	if err != nil {
+		return nil, ErrURLNotFound
+	}
+
+	if url.IsExpired() {
+		return nil, ErrURLExpired
+	}
+
+	end := to.AddDate(0, 0, 1)
+
+	days, err := uc.clickRepo.GetClicksByDay(ctx, shortKey.Value(), from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.TimeseriesResponse{
+		ShortKey: shortKey.Value(),
+		From:     from.Format(dateLayout),
+		To:       to.Format(dateLayout),
+		Timeline: buildTimeline(from, end, days),
+	}
+
+	for _, day := range resp.Timeline {
+		resp.TotalClicks += day.Clicks
+	}
+
+	return resp, nil
+}
+
+// TopURLs returns the most-visited URLs, most visited first. A non-nil since limits the
+// leaderboard to URLs created at or after it; limit defaults to DefaultTopURLs and is
+// capped at MaxTopURLs.
+func (uc *AnalyticsUseCase) TopURLs(ctx context.Context, limit int, since *time.Time) (*dto.TopURLsResponse, error) {
+	if limit <= 0 {
+		limit = DefaultTopURLs
+	} else if limit > MaxTopURLs {
+		limit = MaxTopURLs
+	}
+
+	urls, err := uc.urlRepo.FindTopByVisits(ctx, limit, since)
+	if err != nil {
+		log.Printf("[TopURLs] Error listing top URLs (limit %d): %v", limit, err)
+		return nil, ErrInternalError
+	}
+
+	resp := &dto.TopURLsResponse{
+		Items: make([]dto.URLStatsResponse, 0, len(urls)),
+		Limit: limit,
+	}
+
+	if since != nil {
+		resp.Since = since.UTC().Format(time.RFC3339)
+	}
+
+	for _, url := range urls {
+		resp.Items = append(resp.Items, *buildStatsResponse(url))
+	}
+
+	return resp, nil
+}
+
+// buildTimeline expands sparse daily counts into one entry per day in [from, end).
+func buildTimeline(from, end time.Time, days []repository.DailyClicks) []dto.DailyClicks {
+	counts := make(map[string]int64, len(days))
+	for _, day := range days {
+		counts[day.Day.UTC().Format(dateLayout)] += day.Clicks
+	}
+
+	var timeline []dto.DailyClicks
+
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format(dateLayout)
+		timeline = append(timeline, dto.DailyClicks{Date: date, Clicks: counts[date]})
+	}
+
+	return timeline
+}
+
+// dayRange truncates from and to to UTC days, rejecting ranges that are reversed or
+// longer than MaxTimelineDays.
+func dayRange(from, to time.Time) (time.Time, time.Time, error) {
+	from, to = truncateDay(from), truncateDay(to)
+
+	if to.Before(from) || to.Sub(from) >= MaxTimelineDays*24*time.Hour {
+		return from, to, ErrInvalidTimeRange
+	}
+
+	return from, to, nil
+}
+
+// truncateDay returns midnight UTC of t's day.
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+)
+
+// hashedKeyLength is the number of hex characters kept from a short key's SHA-256.
+// 64 bits keeps collisions between keys negligible while not storing the full digest.
+const hashedKeyLength = 16
+
+// HashShortKey returns the truncated, hex-encoded SHA-256 of shortKey. The same key
+// always hashes to the same value, so analytics can still be grouped per key.
+func HashShortKey(shortKey string) string {
+	sum := sha256.Sum256([]byte(shortKey))
+
+	return hex.EncodeToString(sum[:])[:hashedKeyLength]
+}
+
+// AnonymizeIP truncates ip so it no longer identifies a single client: IPv4
+// addresses lose their last octet and IPv6 addresses keep only their /48 prefix.
+// Values that are not IP addresses are hashed like short keys instead.
+func AnonymizeIP(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return HashShortKey(ip)
+	}
+
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String()
+	}
+
+	return parsed.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// analyticsKey returns the identifier analytics are recorded under for shortKey.
+func (uc *ShortenURLUseCase) analyticsKey(shortKey string) string {
+	if uc.config.HashAnalyticsIdentifiers {
+		return HashShortKey(shortKey)
+	}
+
+	return shortKey
+}
+
+// analyticsVisitor returns the form of a client IP that analytics may record.
+func (uc *ShortenURLUseCase) analyticsVisitor(ip string) string {
+	if uc.config.HashAnalyticsIdentifiers {
+		return AnonymizeIP(ip)
+	}
+
+	return ip
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// CacheBreakerState is the state of the circuit breaker around cache reads and fills.
+type CacheBreakerState string
+
+// Cache breaker states.
+const (
+	// CacheBreakerClosed sends every cache call through.
+	CacheBreakerClosed CacheBreakerState = "closed"
+	// CacheBreakerOpen skips the cache and goes straight to the database until the cooldown ends.
+	CacheBreakerOpen CacheBreakerState = "open"
+	// CacheBreakerHalfOpen lets one call through to probe whether the cache has recovered.
+	CacheBreakerHalfOpen CacheBreakerState = "half_open"
+)
+
+// errCacheBreakerOpen is returned in place of a cache call the open breaker skipped.
+var errCacheBreakerOpen = errors.New("cache circuit breaker open")
+
+// CacheBreakerStats is a snapshot of the cache circuit breaker.
+type CacheBreakerStats struct {
+	State CacheBreakerState `json:"state"`
+	// Cache errors in a row; reset by any successful call
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// When an open breaker lets a probe through; zero unless open
+	RetryAt *time.Time `json:"retry_at,omitempty"`
+	// Times the breaker has opened, and cache calls it skipped meanwhile
+	Trips   int64 `json:"trips"`
+	Skipped int64 `json:"skipped"`
+}
+
+// cacheBreaker stops cache reads and fills after ShortenURLConfig.CacheBreakerThreshold
+// consecutive errors, so a Redis outage costs one failed call per cooldown instead of a
+// timeout on every request. Misses are not errors.
+type cacheBreaker struct {
+	mu       sync.Mutex
+	state    CacheBreakerState
+	failures int
+	retryAt  time.Time
+	probing  bool
+	trips    int64
+	skipped  int64
+}
+
+// CacheBreakerStats returns the current state of the cache circuit breaker.
+func (uc *ShortenURLUseCase) CacheBreakerStats() CacheBreakerStats {
+	b := &uc.cacheBreaker
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stats := CacheBreakerStats{
+		State:               b.stateLocked(),
+		ConsecutiveFailures: b.failures,
+		Trips:               b.trips,
+		Skipped:             b.skipped,
+	}
+
+	if stats.State == CacheBreakerOpen {
+		retryAt := b.retryAt
+		stats.RetryAt = &retryAt
+	}
+
+	return stats
+}
+
+// withCacheBreaker runs a cache read or fill through the breaker: it returns
+// errCacheBreakerOpen without calling when the breaker is open, and otherwise records
+// call's outcome. Invalidations bypass the breaker, since skipping one could leave a
+// stale entry behind once the cache recovers.
+func (uc *ShortenURLUseCase) withCacheBreaker(call func() error) error {
+	if uc.config.CacheBreakerThreshold <= 0 {
+		return call()
+	}
+
+	b := &uc.cacheBreaker
+	if !b.allow() {
+		return errCacheBreakerOpen
+	}
+
+	err := call()
+	b.record(err, uc.config.CacheBreakerThreshold, uc.config.CacheBreakerCooldown)
+
+	return err
+}
+
+// stateLocked returns the state, treating an open breaker past its cooldown as half-open.
+// Callers hold b.mu.
+func (b *cacheBreaker) stateLocked() CacheBreakerState {
+	switch {
+	case b.state == "":
+		return CacheBreakerClosed
+	case b.state == CacheBreakerOpen && !time.Now().Before(b.retryAt):
+		return CacheBreakerHalfOpen
+	default:
+		return b.state
+	}
+}
+
+// allow reports whether a cache call may go through. Once the cooldown has passed a
+// single probe is let through; other calls keep skipping the cache until it reports.
+func (b *cacheBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.stateLocked() {
+	case CacheBreakerClosed:
+		return true
+	case CacheBreakerHalfOpen:
+		if !b.probing {
+			b.state = CacheBreakerHalfOpen
+			b.probing = true
+
+			return true
+		}
+	}
+
+	b.skipped++
+
+	return false
+}
+
+// record updates the breaker with the outcome of a call it allowed. A failed probe,
+// or threshold failures in a row, open it for cooldown.
+func (b *cacheBreaker) record(err error, threshold int, cooldown time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	probe := b.probing
+	b.probing = false
+
+	if err == nil || errors.Is(err, repository.ErrCacheMiss) {
+		if b.state != CacheBreakerClosed && b.state != "" {
+			log.Printf("[CacheBreaker] event=closed after_trips=%d", b.trips)
+		}
+
+		b.state = CacheBreakerClosed
+		b.failures = 0
+
+		return
+	}
+
+	b.failures++
+
+	if probe || (b.stateLocked() == CacheBreakerClosed && b.failures >= threshold) {
+		b.state = CacheBreakerOpen
+		b.retryAt = time.Now().Add(cooldown)
+		b.trips++
+
+		log.Printf("[CacheBreaker] event=opened consecutive_failures=%d cooldown=%v error=%q", b.failures, cooldown, err.Error())
+	}
+}
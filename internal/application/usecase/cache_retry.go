@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+)
+
+// cacheRetryMaxAttempts bounds how often one failed cache write is retried before it is dropped.
+const cacheRetryMaxAttempts = 3
+
+// pendingCacheWrite is a cache write waiting for a retry.
+type pendingCacheWrite struct {
+	url      *entity.URL
+	attempts int
+}
+
+// cacheRetryQueue holds cache writes that failed on the shorten path. It is bounded
+// by ShortenURLConfig.CacheRetryQueueSize and drained by a single delayed retry pass,
+// so a Redis outage costs at most one timer and a fixed amount of memory.
+type cacheRetryQueue struct {
+	mu        sync.Mutex
+	pending   map[string]*pendingCacheWrite
+	scheduled bool
+	dropped   int64
+}
+
+// PendingCacheRetries returns the number of failed cache writes waiting for a retry.
+func (uc *ShortenURLUseCase) PendingCacheRetries() int {
+	uc.cacheRetries.mu.Lock()
+	defer uc.cacheRetries.mu.Unlock()
+
+	return len(uc.cacheRetries.pending)
+}
+
+// DroppedCacheRetries returns how many failed cache writes were given up on, because
+// the queue was full or the retries were exhausted.
+func (uc *ShortenURLUseCase) DroppedCacheRetries() int64 {
+	uc.cacheRetries.mu.Lock()
+	defer uc.cacheRetries.mu.Unlock()
+
+	return uc.cacheRetries.dropped
+}
+
+// enqueueCacheRetry queues url for another cache write and schedules a retry pass
+// if none is pending. A newer write for the same key replaces the queued one.
+func (uc *ShortenURLUseCase) enqueueCacheRetry(url *entity.URL, attempts int) {
+	limit := uc.config.CacheRetryQueueSize
+	if limit <= 0 {
+		return
+	}
+
+	q := &uc.cacheRetries
+	key := url.ShortKey.Value()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending == nil {
+		q.pending = make(map[string]*pendingCacheWrite)
+	}
+
+	if _, queued := q.pending[key]; !queued && len(q.pending) >= limit {
+		q.dropped++
+		log.Printf("[CacheRetry] Queue full (%d), dropping cache write for %s", limit, key)
+
+		return
+	}
+
+	q.pending[key] = &pendingCacheWrite{url: url, attempts: attempts}
+
+	if !q.scheduled {
+		q.scheduled = true
+
+		time.AfterFunc(uc.config.CacheRetryDelay, func() {
+			async.Go(context.Background(), "cache-retry", uc.retryCacheWrites)
+		})
+	}
+}
+
+// dropCacheRetry discards a queued cache write for key. Callers that change or remove
+// a URL call it so a retry cannot overwrite their cache entry with the old snapshot.
+func (uc *ShortenURLUseCase) dropCacheRetry(key string) {
+	q := &uc.cacheRetries
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	delete(q.pending, key)
+}
+
+// retryCacheWrites attempts every queued cache write once. Writes that fail again are
+// requeued until cacheRetryMaxAttempts; URLs that expired meanwhile are skipped.
+func (uc *ShortenURLUseCase) retryCacheWrites(ctx context.Context) {
+	q := &uc.cacheRetries
+
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.scheduled = false
+	q.mu.Unlock()
+
+	for key, write := range pending {
+		if write.url.IsExpired() {
+			continue
+		}
+
+		if uc.writeCache(ctx, write.url) {
+			log.Printf("[CacheRetry] Cached %s on retry %d", key, write.attempts+1)
+			continue
+		}
+
+		if write.attempts+1 >= cacheRetryMaxAttempts {
+			q.mu.Lock()
+			q.dropped++
+			q.mu.Unlock()
+
+			log.Printf("[CacheRetry] Giving up on %s after %d retries", key, cacheRetryMaxAttempts)
+
+			continue
+		}
+
+		uc.enqueueCacheRetry(write.url, write.attempts+1)
+	}
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// clickFlushTimeout bounds each batch write, so a slow database cannot stall the flusher.
+const clickFlushTimeout = 5 * time.Second
+
+// ClickEvent is the request context of a redirect, as captured by the HTTP layer.
+type ClickEvent struct {
+	ShortKey  string
+	Referrer  string
+	UserAgent string
+	ClientIP  string
+}
+
+// ClickRecorderConfig tunes the click buffer.
+type ClickRecorderConfig struct {
+	// Clicks held while waiting to be written; further clicks are dropped
+	QueueSize int
+	// Clicks written per INSERT
+	BatchSize int
+	// Longest a click waits in a partial batch before it is written
+	FlushInterval time.Duration
+}
+
+// DefaultClickRecorderConfig returns the default click buffer settings.
+func DefaultClickRecorderConfig() ClickRecorderConfig {
+	return ClickRecorderConfig{
+		QueueSize:     10000,
+		BatchSize:     100,
+		FlushInterval: time.Second,
+	}
+}
+
+// ClickRecorder buffers clicks in a bounded queue and writes them in batches from a
+// background goroutine, keeping the database off the redirect path. When the queue is
+// full, clicks are dropped and counted rather than blocking the redirect.
+type ClickRecorder struct {
+	repo   repository.ClickRepository
+	config ClickRecorderConfig
+	queue  chan repository.Click
+
+	dropped atomic.Int64
+	failed  atomic.Int64
+
+	done     chan struct{}
+	wg       sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewClickRecorder creates a ClickRecorder writing to repo. Call Start to begin flushing.
+func NewClickRecorder(repo repository.ClickRepository, config ClickRecorderConfig) *ClickRecorder {
+	defaults := DefaultClickRecorderConfig()
+
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = defaults.FlushInterval
+	}
+
+	return &ClickRecorder{
+		repo:   repo,
+		config: config,
+		queue:  make(chan repository.Click, config.QueueSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start begins writing queued clicks in the background.
+func (r *ClickRecorder) Start() {
+	r.wg.Add(1)
+
+	go func() {
+		defer r.wg.Done()
+		r.flushLoop()
+	}()
+}
+
+// Stop writes the clicks still queued and waits for the flusher to exit.
+func (r *ClickRecorder) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.done)
+	})
+
+	r.wg.Wait()
+
+	if dropped := r.dropped.Load(); dropped > 0 {
+		log.Printf("[ClickRecorder] Stopped; %d clicks dropped while the queue was full", dropped)
+	}
+}
+
+// Enqueue queues click without blocking, reporting false when the queue was full and
+// the click was dropped.
+func (r *ClickRecorder) Enqueue(click repository.Click) bool {
+	select {
+	case r.queue <- click:
+		return true
+	default:
+		r.dropped.Add(1)
+		return false
+	}
+}
+
+// Dropped returns how many clicks were dropped because the queue was full.
+func (r *ClickRecorder) Dropped() int64 {
+	return r.dropped.Load()
+}
+
+// Failed returns how many clicks were lost because writing their batch failed.
+func (r *ClickRecorder) Failed() int64 {
+	return r.failed.Load()
+}
+
+// flushLoop collects queued clicks into batches, writing each when it is full or has
+// waited FlushInterval. On Stop it drains the queue before returning.
+func (r *ClickRecorder) flushLoop() {
+	ticker := time.NewTicker(r.config.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]repository.Click, 0, r.config.BatchSize)
+
+	add := func(click repository.Click) {
+		batch = append(batch, click)
+
+		if len(batch) >= r.config.BatchSize {
+			r.flush(batch)
+			batch = make([]repository.Click, 0, r.config.BatchSize)
+		}
+	}
+
+	for {
+		select {
+		case click := <-r.queue:
+			add(click)
+		case <-ticker.C:
+			if len(batch) > 0 {
+				r.flush(batch)
+				batch = make([]repository.Click, 0, r.config.BatchSize)
+			}
+		case <-r.done:
+			for {
+				select {
+				case click := <-r.queue:
+					add(click)
+				default:
+					if len(batch) > 0 {
+						r.flush(batch)
+					}
+
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes batch, counting its clicks as failed when the write errors.
+func (r *ClickRecorder) flush(batch []repository.Click) {
+	ctx, cancel := context.WithTimeout(context.Background(), clickFlushTimeout)
+	defer cancel()
+
+	if err := r.repo.RecordClicks(ctx, batch); err != nil {
+		r.failed.Add(int64(len(batch)))
+		log.Printf("[ClickRecorder] Warning: Failed to record %d clicks: %v", len(batch), err)
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// SetDestinationHistory enables recording of destination changes made through
+// UpdateDestination, and serving them from GetDestinationHistory.
+func (uc *ShortenURLUseCase) SetDestinationHistory(history repository.DestinationHistoryRepository) {
+	uc.destinationHistory = history
+}
+
+// recordDestinationChange appends a repointing of shortKey to the history. The update
+// has already been applied, so a failed write is logged rather than returned.
+func (uc *ShortenURLUseCase) recordDestinationChange(ctx context.Context, shortKey, previousURL, newURL string) {
+	if uc.destinationHistory == nil || previousURL == newURL {
+		return
+	}
+
+	change := repository.DestinationChange{
+		PreviousURL: previousURL,
+		NewURL:      newURL,
+		ChangedAt:   time.Now().UTC(),
+	}
+
+	if err := uc.destinationHistory.RecordDestinationChange(ctx, shortKey, change); err != nil {
+		log.Printf("[UpdateDestination] Warning: Failed to record history for %s: %v", shortKey, err)
+	}
+}
+
+// GetDestinationHistory returns the current destination of a short URL and every
+// recorded change to it, oldest first.
+func (uc *ShortenURLUseCase) GetDestinationHistory(ctx context.Context, shortKeyStr string) (*dto.DestinationHistoryResponse, error) {
+	if uc.destinationHistory == nil {
+		return nil, ErrHistoryUnavailable
+	}
+
+	shortKey, err := valueobject.NewShortKey(shortKeyStr)
+	if err != nil {
+		return nil, ErrURLNotFound
+	}
+
+	url, err := uc.urlRepo.FindByShortKey(ctx, shortKey)
+	if err != nil {
+		return nil, ErrURLNotFound
+	}
+
+	changes, err := uc.destinationHistory.GetDestinationHistory(ctx, shortKey.Value())
+	if err != nil {
+		log.Printf("[GetDestinationHistory] Error loading history for %s: %v", shortKey.Value(), err)
+		return nil, ErrInternalError
+	}
+
+	resp := &dto.DestinationHistoryResponse{
+		ShortKey:   shortKey.Value(),
+		CurrentURL: url.LongURL.Value(),
+		Changes:    make([]dto.DestinationChangeResponse, len(changes)),
+	}
+
+	for i, change := range changes {
+		resp.Changes[i] = dto.DestinationChangeResponse{
+			PreviousURL: change.PreviousURL,
+			NewURL:      change.NewURL,
+			ChangedAt:   change.ChangedAt.Format(time.RFC3339),
+		}
+	}
+
+	return resp, nil
+}
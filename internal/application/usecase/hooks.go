@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// RedirectEvent describes a short key that was resolved for a redirect.
+type RedirectEvent struct {
+	ShortKey    string
+	LongURL     string
+	CacheStatus CacheStatus
+	ResolvedAt  time.Time
+}
+
+// RedirectHook is notified after a short key resolves. Hooks run asynchronously,
+// so they never delay or fail the redirect; a panicking hook is recovered and counted.
+type RedirectHook func(ctx context.Context, event RedirectEvent)
+
+// AddRedirectHook registers hook to run after every successful resolve.
+func (uc *ShortenURLUseCase) AddRedirectHook(hook RedirectHook) {
+	uc.hooksMu.Lock()
+	defer uc.hooksMu.Unlock()
+
+	uc.redirectHooks = append(uc.redirectHooks, hook)
+}
+
+// SetVisitorEstimator enables distinct-visitor estimation. Each visitor's entry is
+// kept for ttl after the short key was last visited.
+func (uc *ShortenURLUseCase) SetVisitorEstimator(estimator repository.VisitorEstimator, ttl time.Duration) {
+	uc.visitorEstimator = estimator
+	uc.visitorTTL = ttl
+}
+
+// RecordVisitor adds visitor (typically the client IP) to the short key's distinct
+// visitor estimate in the background. It does nothing unless an estimator is set.
+// With HashAnalyticsIdentifiers both are recorded in their privacy-preserving form.
+func (uc *ShortenURLUseCase) RecordVisitor(ctx context.Context, shortKey, visitor string) {
+	if uc.visitorEstimator == nil || visitor == "" {
+		return
+	}
+
+	key, visitor := uc.analyticsKey(shortKey), uc.analyticsVisitor(visitor)
+
+	async.Go(ctx, "visitor estimate", func(ctx context.Context) {
+		if err := uc.visitorEstimator.AddVisitor(ctx, key, visitor, uc.visitorTTL); err != nil {
+			log.Printf("[RecordVisitor] Warning: Failed to record visitor for %s: %v", shortKey, err)
+		}
+	})
+}
+
+// SetClickRecorder enables per-click recording through recorder.
+func (uc *ShortenURLUseCase) SetClickRecorder(recorder *ClickRecorder) {
+	uc.clickRecorder = recorder
+}
+
+// RecordClick queues a redirect's click for recording without waiting on the database.
+// It does nothing unless a click recorder is set, and drops the click when the
+// recorder's queue is full. With HashAnalyticsIdentifiers the client IP is anonymized.
+func (uc *ShortenURLUseCase) RecordClick(event ClickEvent) {
+	if uc.clickRecorder == nil {
+		return
+	}
+
+	click := repository.Click{
+		ShortKey:  event.ShortKey,
+		ClickedAt: time.Now(),
+		Referrer:  event.Referrer,
+		UserAgent: event.UserAgent,
+	}
+
+	if event.ClientIP != "" {
+		click.ClientIP = uc.analyticsVisitor(event.ClientIP)
+	}
+
+	uc.clickRecorder.Enqueue(click)
+}
+
+// dispatchRedirect hands event to every registered hook on its own goroutine.
+func (uc *ShortenURLUseCase) dispatchRedirect(ctx context.Context, event RedirectEvent) {
+	uc.hooksMu.RLock()
+	hooks := uc.redirectHooks
+	uc.hooksMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook := hook
+
+		async.Go(ctx, "redirect hook", func(ctx context.Context) {
+			hook(ctx, event)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package usecase
+
+// Shorten outcomes reported to a MetricsObserver.
+const (
+	ShortenCreated = "created"
+	ShortenReused  = "reused"
+	ShortenFailed  = "failed"
+)
+
+// MetricsObserver is told the outcome of every shorten request (ShortenCreated,
+// ShortenReused or ShortenFailed) and the cache status of every lookup, so they can be
+// exported as metrics. Calls are made inline and must not block.
+type MetricsObserver interface {
+	ObserveShorten(outcome string)
+	ObserveCacheLookup(status string)
+}
+
+// SetMetricsObserver sets where shorten outcomes and cache lookups are reported; nil
+// disables reporting.
+func (uc *ShortenURLUseCase) SetMetricsObserver(observer MetricsObserver) {
+	uc.metricsObserver = observer
+}
+
+// observeShorten reports a shorten outcome to the metrics observer, if any.
+func (uc *ShortenURLUseCase) observeShorten(outcome string) {
+	if uc.metricsObserver != nil {
+		uc.metricsObserver.ObserveShorten(outcome)
+	}
+}
+
+// observeCacheLookup reports the cache status of a lookup to the metrics observer, if any.
+func (uc *ShortenURLUseCase) observeCacheLookup(status CacheStatus) {
+	if uc.metricsObserver != nil {
+		uc.metricsObserver.ObserveCacheLookup(string(status))
+	}
+}
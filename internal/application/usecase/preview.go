@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+// DestinationBlocklist reports whether a destination is known to be unsafe.
+type DestinationBlocklist interface {
+	// Blocks reports whether longURL must not be visited
+	Blocks(longURL string) bool
+}
+
+// PreviewFetcher reads the page title of a destination for previews. Implementations
+// bound the request themselves; Preview does not add a timeout.
+type PreviewFetcher interface {
+	FetchTitle(ctx context.Context, longURL string) (string, error)
+}
+
+// SetDestinationBlocklist sets the blocklist Preview checks destinations against;
+// nil leaves the blocked field out of previews.
+func (uc *ShortenURLUseCase) SetDestinationBlocklist(blocklist DestinationBlocklist) {
+	uc.destinationBlocklist = blocklist
+}
+
+// SetPreviewFetcher sets how Preview reads destination titles; nil leaves them out.
+func (uc *ShortenURLUseCase) SetPreviewFetcher(fetcher PreviewFetcher) {
+	uc.previewFetcher = fetcher
+}
+
+// Preview resolves a short key without redirecting, counting a visit or notifying
+// redirect hooks, and describes the destination so it can be checked before it is
+// followed. Blocklisted destinations are not fetched.
+func (uc *ShortenURLUseCase) Preview(ctx context.Context, shortKeyStr string) (*dto.URLPreviewResponse, error) {
+	longURL, _, err := uc.ResolveWithoutVisit(ctx, shortKeyStr)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.URLPreviewResponse{
+		ShortKey: uc.TrimKeySuffixes(shortKeyStr),
+		LongURL:  longURL,
+	}
+
+	if parsed, err := url.Parse(longURL); err == nil {
+		resp.Host = strings.ToLower(parsed.Hostname())
+	}
+
+	blocked := false
+	if uc.destinationBlocklist != nil {
+		blocked = uc.destinationBlocklist.Blocks(longURL)
+		resp.Blocked = &blocked
+	}
+
+	if uc.previewFetcher != nil && !blocked {
+		title, err := uc.previewFetcher.FetchTitle(ctx, longURL)
+		if err != nil {
+			log.Printf("[Preview] Could not fetch title of %s: %v", longURL, err)
+		}
+
+		resp.Title = strings.TrimSpace(title)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+// Self-test check outcomes.
+const (
+	SelfTestPass = "pass"
+	SelfTestFail = "fail"
+	SelfTestSkip = "skip"
+)
+
+// Names of the checks SelfTest runs.
+const (
+	SelfTestKeyRoundTrip   = "key_round_trip"
+	SelfTestCacheRoundTrip = "cache_round_trip"
+	SelfTestDatabasePing   = "database_ping"
+)
+
+// selfTestCacheTTL bounds how long a self-test cache entry lives if its delete fails.
+const selfTestCacheTTL = time.Minute
+
+// selfTestURL is the destination written to the cache by the round-trip check.
+const selfTestURL = "https://selftest.invalid/round-trip"
+
+// SelfTest exercises the dependencies a deploy can break: it encodes a fresh ID to a
+// short key and decodes it back, writes, reads and deletes a cache entry under a
+// temporary key, and pings the database. Every check runs even if an earlier one fails.
+func (uc *ShortenURLUseCase) SelfTest(ctx context.Context) *dto.SelfTestResponse {
+	checks := []dto.SelfTestCheck{
+		selfTestCheck(SelfTestKeyRoundTrip, uc.checkKeyRoundTrip),
+		selfTestCheck(SelfTestCacheRoundTrip, func() error { return uc.checkCacheRoundTrip(ctx) }),
+		selfTestCheck(SelfTestDatabasePing, func() error { return uc.checkDatabase(ctx) }),
+	}
+
+	resp := &dto.SelfTestResponse{Status: SelfTestPass, Checks: checks}
+
+	for _, check := range checks {
+		if check.Status == SelfTestFail {
+			resp.Status = SelfTestFail
+			log.Printf("[SelfTest] Check %s failed: %s", check.Name, check.Message)
+		}
+	}
+
+	return resp
+}
+
+// errSelfTestSkipped marks a check that does not apply to this deployment.
+type errSelfTestSkipped string
+
+func (e errSelfTestSkipped) Error() string { return string(e) }
+
+// selfTestCheck runs check and reports its outcome under name.
+func selfTestCheck(name string, check func() error) dto.SelfTestCheck {
+	err := check()
+
+	var skipped errSelfTestSkipped
+
+	switch {
+	case err == nil:
+		return dto.SelfTestCheck{Name: name, Status: SelfTestPass}
+	case errors.As(err, &skipped):
+		return dto.SelfTestCheck{Name: name, Status: SelfTestSkip, Message: err.Error()}
+	default:
+		return dto.SelfTestCheck{Name: name, Status: SelfTestFail, Message: err.Error()}
+	}
+}
+
+// checkKeyRoundTrip generates an ID, encodes it and decodes the key back to the same ID,
+// which fails on a misconfigured alphabet or padding.
+func (uc *ShortenURLUseCase) checkKeyRoundTrip() error {
+	if uc.genService == nil {
+		return errors.New("no generator service configured")
+	}
+
+	id, err := uc.genService.GenerateID()
+	if err != nil {
+		return fmt.Errorf("generate ID: %w", err)
+	}
+
+	ok, err := uc.genService.VerifyRoundTrip(id)
+	if errors.Is(err, service.ErrNotDecodable) {
+		return errSelfTestSkipped(fmt.Sprintf("encoded ID %d; keys carry no ID to decode", id))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("ID %d does not decode back to itself", id)
+	}
+
+	return nil
+}
+
+// checkCacheRoundTrip writes a cache entry under a temporary key, reads it back and
+// compares it, then deletes it; this catches broken serialization of cache entries.
+func (uc *ShortenURLUseCase) checkCacheRoundTrip(ctx context.Context) error {
+	key := fmt.Sprintf("selftest:%d", time.Now().UnixNano())
+	expiresAt := time.Now().Add(selfTestCacheTTL).UTC().Truncate(time.Second)
+
+	written := &repository.CacheEntry{
+		LongURL:   selfTestURL,
+		ExpiresAt: &expiresAt,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := uc.cacheRepo.SetCacheEntry(ctx, key, written, selfTestCacheTTL); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	read, err := uc.cacheRepo.GetCacheEntry(ctx, key)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	if read == nil || read.LongURL != written.LongURL || read.ExpiresAt == nil ||
+		!read.ExpiresAt.Equal(expiresAt) || !read.CreatedAt.Equal(written.CreatedAt) {
+		return errors.New("entry read back differs from the one written")
+	}
+
+	if err := uc.cacheRepo.Delete(ctx, key); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// checkDatabase pings the database through the URL repository, if it supports it.
+func (uc *ShortenURLUseCase) checkDatabase(ctx context.Context) error {
+	pinger, ok := uc.urlRepo.(repository.Pinger)
+	if !ok {
+		return errSelfTestSkipped("URL repository cannot be pinged")
+	}
+
+	return pinger.Ping(ctx)
+}
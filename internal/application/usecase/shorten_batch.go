@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+)
+
+// ShortenBatch shortens many URLs in one call. Results are parallel to reqs: each
+// index holds either a response or the error that item failed with, so one bad item
+// does not fail the rest. Keys are generated for every item first and the new URLs
+// are then persisted together.
+//
+// Items are checked against each other as well as the store: a custom key used twice
+// conflicts on its second use, and a destination repeated without a custom key or ID
+// shares the key created for its first occurrence.
+func (uc *ShortenURLUseCase) ShortenBatch(ctx context.Context, reqs []*dto.ShortenURLRequest) ([]*dto.ShortenURLResponse, []error) {
+	ctx, span := startSpan(ctx, "ShortenBatch")
+	defer span.End()
+
+	log.Printf("[ShortenBatch] Shortening %d URLs", len(reqs))
+
+	responses := make([]*dto.ShortenURLResponse, len(reqs))
+	errs := make([]error, len(reqs))
+
+	// New URLs to save, the indexes they answer, and keys claimed within the batch
+	var pending []*entity.URL
+
+	pendingIndexes := make(map[*entity.URL][]int)
+	claimedKeys := make(map[string]bool)
+	pendingByDestination := make(map[string]*entity.URL)
+	reused := make(map[int]bool)
+
+	for i, req := range reqs {
+		if req == nil {
+			errs[i] = ErrEmptyBatchItem
+			continue
+		}
+
+		if req.CustomKey != "" {
+			if claimedKeys[req.CustomKey] {
+				errs[i] = &CustomKeyConflictError{}
+				continue
+			}
+
+			claimedKeys[req.CustomKey] = true
+		}
+
+		// Repeats of a destination already being created share its key
+		destination := uc.batchDestination(req)
+		if url, ok := pendingByDestination[destination]; ok && destination != "" {
+			pendingIndexes[url] = append(pendingIndexes[url], i)
+			continue
+		}
+
+		url, existing, reclaim, err := uc.prepareURL(ctx, req)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		if existing != nil {
+			responses[i] = uc.buildReusedResponse(existing)
+			reused[i] = true
+
+			continue
+		}
+
+		claimedKeys[url.ShortKey.Value()] = true
+
+		// Reclaiming an expired custom key overwrites its row, which a bulk insert cannot do
+		if reclaim {
+			responses[i], errs[i] = uc.persistOne(ctx, url, reclaim)
+			continue
+		}
+
+		pending = append(pending, url)
+		pendingIndexes[url] = []int{i}
+
+		if destination != "" {
+			pendingByDestination[destination] = url
+		}
+	}
+
+	saveErrs := uc.saveBatch(ctx, pending)
+
+	for i, url := range pending {
+		var resp *dto.ShortenURLResponse
+
+		err := saveErrs[i]
+		if err == nil {
+			uc.cacheURL(ctx, url)
+			resp = uc.buildResponse(url)
+		}
+
+		for _, index := range pendingIndexes[url] {
+			responses[index], errs[index] = resp, err
+		}
+	}
+
+	for i := range reqs {
+		switch {
+		case errs[i] != nil:
+			uc.observeShorten(ShortenFailed)
+		case reused[i]:
+			uc.observeShorten(ShortenReused)
+		default:
+			uc.observeShorten(ShortenCreated)
+		}
+	}
+
+	return responses, errs
+}
+
+// batchDestination identifies the owner and normalized destination of a request that
+// deduplicates, or returns "" for requests with a custom key or ID and invalid URLs.
+func (uc *ShortenURLUseCase) batchDestination(req *dto.ShortenURLRequest) string {
+	if req.CustomKey != "" || req.ID != 0 {
+		return ""
+	}
+
+	longURL, err := uc.validateAndNormalizeLongURL(req.LongURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(req.Owner) + "\x00" + longURL.Value()
+}
+
+// persistOne saves a single prepared URL and caches it.
+func (uc *ShortenURLUseCase) persistOne(ctx context.Context, url *entity.URL, reclaim bool) (*dto.ShortenURLResponse, error) {
+	if err := uc.saveURL(ctx, url, reclaim); err != nil {
+		log.Printf("[ShortenBatch] Error saving %s: %v", url.ShortKey.Value(), err)
+		return nil, fmt.Errorf("failed to save URL: %w", err)
+	}
+
+	uc.cacheURL(ctx, url)
+
+	return uc.buildResponse(url), nil
+}
+
+// saveBatch persists new URLs and returns the error for each, parallel to urls. They
+// are inserted together; if that fails, nothing was saved and each URL is retried on
+// its own so only the items that actually fail report an error.
+func (uc *ShortenURLUseCase) saveBatch(ctx context.Context, urls []*entity.URL) []error {
+	errs := make([]error, len(urls))
+	if len(urls) == 0 {
+		return errs
+	}
+
+	err := uc.urlRepo.BatchSave(ctx, urls)
+	if err == nil {
+		return errs
+	}
+
+	log.Printf("[ShortenBatch] Batch insert of %d URLs failed, saving individually: %v", len(urls), err)
+
+	for i, url := range urls {
+		if err := uc.urlRepo.Save(ctx, url); err != nil {
+			log.Printf("[ShortenBatch] Error saving %s: %v", url.ShortKey.Value(), err)
+			errs[i] = fmt.Errorf("failed to save URL: %w", err)
+		}
+	}
+
+	return errs
+}
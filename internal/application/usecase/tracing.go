@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies spans started by the use cases.
+const TracerName = "github.com/Shofyan/url-shortener/usecase"
+
+// shortKeyAttribute is the span attribute carrying the short key being served.
+const shortKeyAttribute = "short_key"
+
+// startSpan starts a child span of whatever span ctx carries. The tracer is looked
+// up on every call so a provider registered after startup is picked up.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
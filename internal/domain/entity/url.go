@@ -15,6 +15,14 @@ type URL struct {
 	ExpiresAt      *time.Time
 	VisitCount     int64
 	LastAccessedAt *time.Time
+	// Disabled pauses redirects without deleting the URL; the zero value keeps URLs enabled
+	Disabled bool
+	// DisabledReason records why a disabled URL was taken down; empty when it was only paused
+	DisabledReason string
+	// CacheTTL overrides how long the URL stays cached; zero uses the service default
+	CacheTTL time.Duration
+	// Owner is the namespace that created the URL; empty when unowned
+	Owner string
 }
 
 // NewURL creates a new URL entity.
@@ -42,6 +50,11 @@ func (u *URL) IsExpired() bool {
 	return time.Now().After(*u.ExpiresAt)
 }
 
+// IsEnabled reports whether the URL currently redirects.
+func (u *URL) IsEnabled() bool {
+	return !u.Disabled
+}
+
 // IncrementVisit increments the visit count and updates last accessed time.
 func (u *URL) IncrementVisit() {
 	u.VisitCount++
@@ -2,9 +2,14 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
+// ErrCacheMiss is returned by CacheRepository reads when the key is not cached. Any
+// other error means the cache itself failed.
+var ErrCacheMiss = errors.New("cache miss")
+
 // CacheRepository defines the interface for caching operations.
 type CacheRepository interface {
 	// Set stores a key-value pair with TTL
@@ -27,15 +32,29 @@ type CacheRepository interface {
 
 	// SetTombstone stores a tombstone marker for an expired/deleted URL
 	SetTombstone(ctx context.Context, key string, reason string, ttl time.Duration) error
+
+	// FlushNamespace deletes every cached entry owned by this service
+	FlushNamespace(ctx context.Context) error
 }
 
+// Tombstone reasons recorded in CacheEntry.Reason.
+const (
+	TombstoneExpired  = "expired"
+	TombstoneDeleted  = "deleted"
+	TombstoneDisabled = "disabled"
+	// TombstoneNotFound caches a lookup for a key that was never stored
+	TombstoneNotFound = "not_found"
+)
+
 // CacheEntry represents a structured cache entry with metadata.
 type CacheEntry struct {
 	LongURL     string     `json:"long_url"`
 	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	IsTombstone bool       `json:"is_tombstone"`
-	Reason      string     `json:"reason,omitempty"` // For tombstones: "expired", "deleted", etc.
+	Reason      string     `json:"reason,omitempty"`       // For tombstones: one of the Tombstone* reasons
+	CachedUntil *time.Time `json:"cached_until,omitempty"` // When the cache entry itself expires
+	Detail      string     `json:"detail,omitempty"`       // For disabled tombstones: why the URL was disabled
 }
 
 // IsExpired checks if the cache entry is logically expired.
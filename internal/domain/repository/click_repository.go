@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// DailyClicks is the number of clicks a short URL received on one UTC day.
+type DailyClicks struct {
+	Day    time.Time
+	Clicks int64
+}
+
+// ReferrerCount is the number of clicks attributed to one referrer.
+// Clicks without a Referer header are reported as "direct".
+type ReferrerCount struct {
+	Referrer string
+	Clicks   int64
+}
+
+// Click is a single redirect of a short URL.
+type Click struct {
+	ShortKey  string
+	ClickedAt time.Time
+	// Referer header of the request; empty for direct visits
+	Referrer  string
+	UserAgent string
+	// Client IP, anonymized when analytics identifiers are hashed
+	ClientIP string
+}
+
+// ClickRepository defines the interface for recording and querying per-click analytics.
+type ClickRepository interface {
+	// RecordClicks stores a batch of clicks in a single statement
+	RecordClicks(ctx context.Context, clicks []Click) error
+
+	// GetClicksByDay returns click counts grouped by UTC day for clicks in [from, to).
+	// Days without clicks are omitted.
+	GetClicksByDay(ctx context.Context, shortKey string, from, to time.Time) ([]DailyClicks, error)
+
+	// GetTopReferrers returns the referrers with the most clicks in [from, to), highest first
+	GetTopReferrers(ctx context.Context, shortKey string, from, to time.Time, limit int) ([]ReferrerCount, error)
+}
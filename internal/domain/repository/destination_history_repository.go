@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// DestinationChange records one repointing of a short key.
+type DestinationChange struct {
+	PreviousURL string
+	NewURL      string
+	ChangedAt   time.Time
+}
+
+// DestinationHistoryRepository stores the audit trail of destination changes.
+type DestinationHistoryRepository interface {
+	// RecordDestinationChange appends change to the history of shortKey
+	RecordDestinationChange(ctx context.Context, shortKey string, change DestinationChange) error
+
+	// GetDestinationHistory returns every recorded change of shortKey, oldest first
+	GetDestinationHistory(ctx context.Context, shortKey string) ([]DestinationChange, error)
+}
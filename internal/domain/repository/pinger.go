@@ -0,0 +1,9 @@
+package repository
+
+import "context"
+
+// Pinger is implemented by repositories that can verify their backing store is reachable.
+type Pinger interface {
+	// Ping checks the connection to the backing store
+	Ping(ctx context.Context) error
+}
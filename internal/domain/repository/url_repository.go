@@ -2,29 +2,137 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Shofyan/url-shortener/internal/domain/entity"
 	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
 )
 
+// ErrNotFound is returned by URLRepository lookups when no URL matches. Any other
+// error means the lookup itself failed and says nothing about whether the URL exists.
+var ErrNotFound = errors.New("URL not found")
+
+// ErrDuplicateKey is matched by DuplicateKeyError through errors.Is.
+var ErrDuplicateKey = errors.New("short key already exists")
+
+// DuplicateKeyError is returned by BatchSave when a short key in the batch is already
+// stored or appears earlier in the same batch. Nothing from the batch is saved.
+type DuplicateKeyError struct {
+	ShortKey string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return ErrDuplicateKey.Error() + ": " + e.ShortKey
+}
+
+func (e *DuplicateKeyError) Unwrap() error {
+	return ErrDuplicateKey
+}
+
+// UpsertResult describes the outcome of an Upsert operation.
+type UpsertResult string
+
+const (
+	// UpsertCreated indicates a new URL mapping was inserted.
+	UpsertCreated UpsertResult = "created"
+	// UpsertUpdated indicates an existing mapping was repointed to a new destination.
+	UpsertUpdated UpsertResult = "updated"
+	// UpsertUnchanged indicates the mapping already pointed to the requested destination.
+	UpsertUnchanged UpsertResult = "unchanged"
+)
+
+// Orders accepted by URLRepository.List. Each sorts descending, newest, most visited
+// or latest expiring first, with URLs without an expiry last.
+const (
+	ListOrderCreatedAt  = "created_at"
+	ListOrderVisitCount = "visit_count"
+	ListOrderExpiresAt  = "expires_at"
+)
+
+// ErrInvalidListOrder is returned by List for an order other than the ListOrder constants.
+var ErrInvalidListOrder = errors.New("invalid list order")
+
+// KeysetCursor marks the last row of a page in (created_at, id) order.
+// Listing methods return rows strictly after it, newest first.
+type KeysetCursor struct {
+	CreatedAt time.Time
+	ID        int64
+}
+
+// RenewCriteria selects URLs for a bulk renewal. Empty fields match everything;
+// set fields are combined with AND.
+type RenewCriteria struct {
+	// Host matches the destination host exactly
+	Host string
+	// Owner matches the owner namespace that created the URL exactly
+	Owner string
+	// KeyPrefix matches short keys starting with this prefix
+	KeyPrefix string
+	// CreatedAfter and CreatedBefore bound created_at (inclusive, exclusive)
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// IsEmpty reports whether no criteria are set, which would match every URL.
+func (c RenewCriteria) IsEmpty() bool {
+	return c.Host == "" && c.Owner == "" && c.KeyPrefix == "" && c.CreatedAfter == nil && c.CreatedBefore == nil
+}
+
 // URLRepository defines the interface for URL persistence.
 type URLRepository interface {
 	// Save saves a new URL mapping
 	Save(ctx context.Context, url *entity.URL) error
 
+	// BatchSave saves many new URL mappings in one transaction; either all are saved or,
+	// on error, none are. A taken short key fails with a *DuplicateKeyError
+	BatchSave(ctx context.Context, urls []*entity.URL) error
+
 	// FindByShortKey retrieves a URL by its short key
 	FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error)
 
 	// FindByLongURL retrieves a URL by its long URL
 	FindByLongURL(ctx context.Context, longURL *valueobject.LongURL) (*entity.URL, error)
 
+	// FindByLongURLAndOwner retrieves the newest URL for longURL created by owner
+	FindByLongURLAndOwner(ctx context.Context, longURL *valueobject.LongURL, owner string) (*entity.URL, error)
+
+	// FindByHost lists URLs whose destination host equals host, newest first.
+	// A nil cursor starts at the newest URL; at most limit URLs are returned.
+	FindByHost(ctx context.Context, host string, cursor *KeysetCursor, limit int) ([]*entity.URL, error)
+
+	// FindTopByVisits lists at most limit URLs, most visited first. A non-nil since keeps
+	// only URLs created at or after it
+	FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error)
+
+	// List returns a page of all URLs sorted by orderBy, one of the ListOrder constants,
+	// together with the total number of URLs
+	List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error)
+
+	// Upsert atomically creates the URL or updates the destination of an existing short key.
+	// On create or update the entity's ID, CreatedAt and VisitCount reflect the stored row.
+	Upsert(ctx context.Context, url *entity.URL) (UpsertResult, error)
+
 	// Update updates an existing URL
 	Update(ctx context.Context, url *entity.URL) error
 
+	// Replace swaps the stored row for url's short key with url in one transaction, so
+	// nothing of the previous URL survives. Returns ErrNotFound when the key is not stored
+	Replace(ctx context.Context, url *entity.URL) error
+
 	// Delete deletes a URL by its short key
 	Delete(ctx context.Context, shortKey *valueobject.ShortKey) error
 
+	// SetEnabled pauses or resumes redirects for a URL without deleting it
+	SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error
+
+	// Disable stops redirects for a URL without deleting it, recording why
+	Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error
+
+	// RenewByCriteria moves the expiry of every matching, not yet expired URL to expiresAt
+	// in a single statement and returns the short keys it renewed
+	RenewByCriteria(ctx context.Context, criteria RenewCriteria, expiresAt time.Time) ([]string, error)
+
 	// ExistsByShortKey checks if a short key already exists
 	ExistsByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (bool, error)
 
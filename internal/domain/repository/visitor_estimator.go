@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// VisitorEstimator approximates the number of distinct visitors per short key.
+// Estimates trade exactness for constant memory per key, so they are suitable
+// for analytics but not for billing or limits.
+type VisitorEstimator interface {
+	// AddVisitor records visitor for shortKey and extends the estimate's lifetime to ttl
+	AddVisitor(ctx context.Context, shortKey, visitor string, ttl time.Duration) error
+
+	// EstimateVisitors returns the approximate number of distinct visitors for shortKey
+	EstimateVisitors(ctx context.Context, shortKey string) (int64, error)
+}
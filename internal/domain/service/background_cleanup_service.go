@@ -18,6 +18,7 @@ type BackgroundURLCleanupService struct {
 	urlRepo    repository.URLRepository
 	cacheRepo  repository.CacheRepository
 	config     *CleanupConfig
+	configMu   sync.RWMutex
 	stats      *CleanupStats
 	statsMutex sync.RWMutex
 	ticker     *time.Ticker
@@ -49,7 +50,9 @@ func NewBackgroundURLCleanupService(
 
 // StartCleanup starts the background cleanup process.
 func (s *BackgroundURLCleanupService) StartCleanup(ctx context.Context) error {
-	if !s.config.Enabled {
+	config := s.GetConfig()
+
+	if !config.Enabled {
 		log.Printf("[Cleanup] Cleanup service is disabled")
 		return nil
 	}
@@ -59,9 +62,11 @@ func (s *BackgroundURLCleanupService) StartCleanup(ctx context.Context) error {
 	}
 
 	log.Printf("[Cleanup] Starting URL cleanup service with interval: %v, batch size: %d",
-		s.config.CleanupInterval, s.config.BatchSize)
+		config.CleanupInterval, config.BatchSize)
 
-	s.ticker = time.NewTicker(s.config.CleanupInterval)
+	s.configMu.Lock()
+	s.ticker = time.NewTicker(config.CleanupInterval)
+	s.configMu.Unlock()
 
 	s.wg.Add(1)
 
@@ -114,10 +119,12 @@ func (s *BackgroundURLCleanupService) cleanupLoop(ctx context.Context) {
 				return
 			}
 
+			config := s.GetConfig()
+
 			// Create context with timeout for each cleanup operation
-			cleanupCtx, cancel := context.WithTimeout(ctx, s.config.MaxCleanupDuration)
+			cleanupCtx, cancel := context.WithTimeout(ctx, config.MaxCleanupDuration)
 
-			cleaned, err := s.CleanupExpiredBatch(cleanupCtx, s.config.BatchSize)
+			cleaned, err := s.CleanupExpiredBatch(cleanupCtx, config.BatchSize)
 
 			if err != nil {
 				log.Printf("[Cleanup] Cleanup batch failed: %v", err)
@@ -135,7 +142,7 @@ func (s *BackgroundURLCleanupService) CleanupExpiredBatch(ctx context.Context, b
 	start := time.Now()
 
 	// Calculate cutoff time with buffer to avoid clock skew issues
-	cutoffTime := time.Now().Add(-s.config.BufferTime)
+	cutoffTime := time.Now().Add(-s.GetConfig().BufferTime)
 
 	log.Printf("[Cleanup] Starting batch cleanup for URLs expired before %v", cutoffTime)
 
@@ -221,6 +228,71 @@ func (s *BackgroundURLCleanupService) updateStats(cleaned int, err error, durati
 	}
 }
 
+// CheckBacklog reports whether expired URLs are piling up faster than cleanup drains them.
+// Exceeding the warning threshold is reported as degraded, exceeding the fail threshold as unhealthy.
+func (s *BackgroundURLCleanupService) CheckBacklog(ctx context.Context) (*BacklogStatus, error) {
+	config := s.GetConfig()
+
+	// Only count URLs that are already eligible for deletion
+	cutoffTime := time.Now().Add(-config.BufferTime)
+
+	count, err := s.urlRepo.GetExpiredCount(ctx, cutoffTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired URLs: %w", err)
+	}
+
+	status := &BacklogStatus{
+		Status:       BacklogHealthy,
+		ExpiredCount: count,
+	}
+
+	switch {
+	case config.BacklogFailThreshold > 0 && count > config.BacklogFailThreshold:
+		status.Status = BacklogUnhealthy
+		status.Warning = fmt.Sprintf("expired URL backlog %d exceeds hard limit %d", count, config.BacklogFailThreshold)
+	case config.BacklogWarnThreshold > 0 && count > config.BacklogWarnThreshold:
+		status.Status = BacklogDegraded
+		status.Warning = fmt.Sprintf("expired URL backlog %d exceeds threshold %d", count, config.BacklogWarnThreshold)
+	}
+
+	return status, nil
+}
+
+// GetConfig returns a copy of the configuration currently in effect.
+func (s *BackgroundURLCleanupService) GetConfig() CleanupConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+
+	return *s.config
+}
+
+// UpdateConfig validates and applies new tuning values to the running service.
+// Nil fields keep their current value. A changed interval resets the ticker, so
+// the next run happens one new interval from now.
+func (s *BackgroundURLCleanupService) UpdateConfig(update CleanupConfigUpdate) (CleanupConfig, error) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	next := *s.config
+	update.apply(&next)
+
+	if err := next.Validate(); err != nil {
+		return *s.config, err
+	}
+
+	intervalChanged := next.CleanupInterval != s.config.CleanupInterval
+	s.config = &next
+
+	if intervalChanged && s.ticker != nil && atomic.LoadInt32(&s.running) == 1 {
+		s.ticker.Reset(next.CleanupInterval)
+	}
+
+	log.Printf("[Cleanup] Configuration updated: interval %v, batch size %d, buffer time %v",
+		next.CleanupInterval, next.BatchSize, next.BufferTime)
+
+	return next, nil
+}
+
 // GetCleanupStats returns statistics about cleanup operations.
 func (s *BackgroundURLCleanupService) GetCleanupStats() *CleanupStats {
 	s.statsMutex.RLock()
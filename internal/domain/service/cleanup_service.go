@@ -3,6 +3,8 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 )
 
@@ -22,6 +24,38 @@ type URLCleanupService interface {
 
 	// GetCleanupStats returns statistics about cleanup operations.
 	GetCleanupStats() *CleanupStats
+
+	// CheckBacklog reports whether expired URLs are piling up faster than cleanup drains them.
+	CheckBacklog(ctx context.Context) (*BacklogStatus, error)
+
+	// GetConfig returns the configuration currently in effect.
+	GetConfig() CleanupConfig
+
+	// UpdateConfig validates and applies new tuning values without a restart.
+	UpdateConfig(update CleanupConfigUpdate) (CleanupConfig, error)
+}
+
+// Bounds for runtime-tunable cleanup settings.
+const (
+	MinCleanupInterval = time.Second
+	MaxCleanupBatch    = 10000
+)
+
+// ErrInvalidCleanupConfig is returned when cleanup settings are out of range.
+var ErrInvalidCleanupConfig = errors.New("invalid cleanup configuration")
+
+// Backlog health states reported by CheckBacklog.
+const (
+	BacklogHealthy   = "healthy"
+	BacklogDegraded  = "degraded"
+	BacklogUnhealthy = "unhealthy"
+)
+
+// BacklogStatus describes the size of the expired URL backlog awaiting cleanup.
+type BacklogStatus struct {
+	Status       string `json:"status"`
+	ExpiredCount int64  `json:"expired_count"`
+	Warning      string `json:"warning,omitempty"`
 }
 
 // CleanupStats contains statistics about cleanup operations.
@@ -51,15 +85,63 @@ type CleanupConfig struct {
 
 	// Enable cleanup service (allows disabling in production if needed)
 	Enabled bool `json:"enabled"`
+
+	// Backlog size above which readiness reports degraded (0 disables the warning)
+	BacklogWarnThreshold int64 `json:"backlog_warn_threshold"`
+
+	// Backlog size above which readiness fails (0 disables the hard limit)
+	BacklogFailThreshold int64 `json:"backlog_fail_threshold"`
+}
+
+// Validate checks the settings that can be changed at runtime.
+func (c *CleanupConfig) Validate() error {
+	if c.CleanupInterval < MinCleanupInterval {
+		return fmt.Errorf("%w: cleanup_interval must be at least %v", ErrInvalidCleanupConfig, MinCleanupInterval)
+	}
+
+	if c.BatchSize < 1 || c.BatchSize > MaxCleanupBatch {
+		return fmt.Errorf("%w: batch_size must be between 1 and %d", ErrInvalidCleanupConfig, MaxCleanupBatch)
+	}
+
+	if c.BufferTime < 0 {
+		return fmt.Errorf("%w: buffer_time cannot be negative", ErrInvalidCleanupConfig)
+	}
+
+	return nil
+}
+
+// CleanupConfigUpdate lists the settings that can be changed on a running service.
+// Nil fields are left unchanged.
+type CleanupConfigUpdate struct {
+	CleanupInterval *time.Duration
+	BatchSize       *int
+	BufferTime      *time.Duration
+}
+
+// apply copies the set fields onto config.
+func (u CleanupConfigUpdate) apply(config *CleanupConfig) {
+	if u.CleanupInterval != nil {
+		config.CleanupInterval = *u.CleanupInterval
+	}
+
+	if u.BatchSize != nil {
+		config.BatchSize = *u.BatchSize
+	}
+
+	if u.BufferTime != nil {
+		config.BufferTime = *u.BufferTime
+	}
 }
 
 // DefaultCleanupConfig returns sensible defaults for cleanup configuration.
 func DefaultCleanupConfig() *CleanupConfig {
 	return &CleanupConfig{
-		CleanupInterval:    15 * time.Minute, // Run every 15 minutes
-		BatchSize:          1000,             // Process 1000 records per batch
-		BufferTime:         1 * time.Hour,    // Delete only after 1 hour past expiration
-		MaxCleanupDuration: 5 * time.Minute,  // Maximum 5 minutes per cleanup run
-		Enabled:            true,             // Enabled by default
+		CleanupInterval:      15 * time.Minute, // Run every 15 minutes
+		BatchSize:            1000,             // Process 1000 records per batch
+		BufferTime:           1 * time.Hour,    // Delete only after 1 hour past expiration
+		MaxCleanupDuration:   5 * time.Minute,  // Maximum 5 minutes per cleanup run
+		Enabled:              true,             // Enabled by default
+		BacklogWarnThreshold: 10000,            // Warn once 10k expired URLs are waiting
+		BacklogFailThreshold: 0,                // Never fail readiness by default
 	}
 }
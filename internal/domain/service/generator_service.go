@@ -1,9 +1,28 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"log"
+
 	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
 )
 
+// ErrKeyspaceExhausted is returned by short key generators that check for collisions
+// when no unused key is found within their attempt budget.
+var ErrKeyspaceExhausted = errors.New("unable to find an unused short key")
+
+// ErrShortKeyTooLong is returned by deterministic short key generators when an ID
+// encodes to a key longer than valueobject.MaxShortKeyLength.
+var ErrShortKeyTooLong = errors.New("encoded short key exceeds the maximum length")
+
+// ErrNotDecodable is returned by short key generators whose keys carry no ID.
+var ErrNotDecodable = errors.New("short keys cannot be decoded to an ID")
+
+// MaxBlockedKeyRetries bounds how many keys GenerateShortKey draws in a row after
+// the blocklist rejects one.
+const MaxBlockedKeyRetries = 10
+
 // IDGenerator defines the interface for ID generation.
 type IDGenerator interface {
 	// Generate generates a unique ID
@@ -19,10 +38,28 @@ type ShortKeyGenerator interface {
 	DecodeToID(shortKey *valueobject.ShortKey) (int64, error)
 }
 
+// ExistenceCheckingGenerator is implemented by short key generators that check every
+// key against existing URLs before returning it, so callers need not check it again.
+type ExistenceCheckingGenerator interface {
+	// ChecksExistence reports whether returned keys are known to be unused
+	ChecksExistence() bool
+}
+
+// KeyBlocklist decides which generated short keys must not be handed out, such as
+// keys that spell offensive words.
+type KeyBlocklist interface {
+	// Blocks reports whether key must be replaced by a newly generated one
+	Blocks(key string) bool
+}
+
 // GeneratorService combines ID and short key generation.
 type GeneratorService struct {
 	idGenerator       IDGenerator
 	shortKeyGenerator ShortKeyGenerator
+	// Used by GenerateShortKey when the primary generator's key would be too long
+	fallback ShortKeyGenerator
+	// Generated keys it blocks are discarded and drawn again
+	blocklist KeyBlocklist
 }
 
 // NewGeneratorService creates a new GeneratorService.
@@ -33,14 +70,55 @@ func NewGeneratorService(idGen IDGenerator, shortKeyGen ShortKeyGenerator) *Gene
 	}
 }
 
-// GenerateShortKey generates a new short key.
+// SetFallback sets the generator GenerateShortKey switches to when the primary one
+// fails with ErrShortKeyTooLong, typically a collision-checked random generator.
+// Keys it produces do not decode back to their ID.
+func (s *GeneratorService) SetFallback(fallback ShortKeyGenerator) {
+	s.fallback = fallback
+}
+
+// SetBlocklist sets the blocklist generated keys are checked against; nil disables it.
+// GenerateShortKey draws a new ID and key whenever the blocklist rejects one.
+func (s *GeneratorService) SetBlocklist(blocklist KeyBlocklist) {
+	s.blocklist = blocklist
+}
+
+// GenerateShortKey generates a new short key. Keys rejected by the blocklist are
+// replaced, up to MaxBlockedKeyRetries times, before it fails with ErrKeyspaceExhausted.
 func (s *GeneratorService) GenerateShortKey() (*valueobject.ShortKey, int64, error) {
+	for retry := 0; ; retry++ {
+		shortKey, id, err := s.generateShortKey()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if s.blocklist == nil || !s.blocklist.Blocks(shortKey.Value()) {
+			return shortKey, id, nil
+		}
+
+		if retry == MaxBlockedKeyRetries {
+			return nil, 0, fmt.Errorf("%w: %d generated keys in a row were blocked", ErrKeyspaceExhausted, retry+1)
+		}
+
+		log.Printf("[GeneratorService] Discarding blocked key for ID %d, generating another", id)
+	}
+}
+
+// generateShortKey draws a new ID and encodes it, using the fallback generator when
+// the primary one's key would be too long.
+func (s *GeneratorService) generateShortKey() (*valueobject.ShortKey, int64, error) {
 	id, err := s.idGenerator.Generate()
 	if err != nil {
 		return nil, 0, err
 	}
 
 	shortKey, err := s.shortKeyGenerator.GenerateFromID(id)
+	if errors.Is(err, ErrShortKeyTooLong) && s.fallback != nil {
+		log.Printf("[GeneratorService] Key for ID %d would be too long, using fallback generator: %v", id, err)
+
+		shortKey, err = s.fallback.GenerateFromID(id)
+	}
+
 	if err != nil {
 		return nil, 0, err
 	}
@@ -48,6 +126,49 @@ func (s *GeneratorService) GenerateShortKey() (*valueobject.ShortKey, int64, err
 	return shortKey, id, nil
 }
 
+// GenerateFromID derives the short key for an existing ID without drawing a new one.
+// Only deterministic short key generators (Base62) map the same ID to the same key,
+// so the fallback generator is never used here.
+func (s *GeneratorService) GenerateFromID(id int64) (*valueobject.ShortKey, error) {
+	return s.shortKeyGenerator.GenerateFromID(id)
+}
+
+// ChecksExistence reports whether the primary generator only returns keys it has
+// already checked are unused, such as the random generator.
+func (s *GeneratorService) ChecksExistence() bool {
+	checking, ok := s.shortKeyGenerator.(ExistenceCheckingGenerator)
+
+	return ok && checking.ChecksExistence()
+}
+
+// DecodeToID decodes a short key made by the primary generator back to its ID.
+func (s *GeneratorService) DecodeToID(shortKey *valueobject.ShortKey) (int64, error) {
+	return s.shortKeyGenerator.DecodeToID(shortKey)
+}
+
+// VerifyRoundTrip encodes id with the primary generator and decodes the key back,
+// reporting whether the same ID comes out. It is a cheap check that encoding and
+// decoding agree, for example after an alphabet change. Encode and decode failures are
+// returned as errors; generators whose keys carry no ID fail with ErrNotDecodable.
+func (s *GeneratorService) VerifyRoundTrip(id int64) (bool, error) {
+	shortKey, err := s.shortKeyGenerator.GenerateFromID(id)
+	if err != nil {
+		return false, fmt.Errorf("encode ID %d: %w", id, err)
+	}
+
+	decoded, err := s.shortKeyGenerator.DecodeToID(shortKey)
+	if err != nil {
+		return false, fmt.Errorf("decode %s: %w", shortKey.Value(), err)
+	}
+
+	if decoded != id {
+		log.Printf("[GeneratorService] ID %d encoded as %s but decoded to %d", id, shortKey.Value(), decoded)
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // GenerateID generates a new unique ID.
 func (s *GeneratorService) GenerateID() (int64, error) {
 	return s.idGenerator.Generate()
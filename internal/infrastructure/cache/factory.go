@@ -0,0 +1,56 @@
+// Package cache selects the cache repository implementation configured for the service.
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/cache/memory"
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+// Supported cache backends.
+const (
+	BackendRedis  = "redis"
+	BackendMemory = "memory"
+)
+
+var (
+	// ErrUnknownBackend is returned by NewRepository for an unsupported backend value.
+	ErrUnknownBackend = errors.New("unknown cache backend")
+	// ErrRedisClientRequired is returned by NewRepository when the redis backend is
+	// selected without a Redis client.
+	ErrRedisClientRequired = errors.New("redis cache backend requires a redis client")
+)
+
+// Config selects and configures the cache backend.
+type Config struct {
+	Backend   string
+	KeyPrefix string
+}
+
+// NeedsRedis reports whether backend stores its entries in Redis.
+// An empty backend defaults to Redis.
+func NeedsRedis(backend string) bool {
+	return backend == "" || backend == BackendRedis
+}
+
+// NewRepository returns the cache repository for cfg.Backend. redisClient is only
+// used by the redis backend and may be nil otherwise.
+func NewRepository(cfg Config, redisClient *redis.Client) (repository.CacheRepository, error) {
+	switch cfg.Backend {
+	case "", BackendRedis:
+		if redisClient == nil {
+			return nil, ErrRedisClientRequired
+		}
+
+		return redisCache.NewCacheRepository(redisClient, cfg.KeyPrefix), nil
+	case BackendMemory:
+		return memory.NewCacheRepository(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, cfg.Backend)
+	}
+}
@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// sweepEvery is the number of writes between sweeps of expired entries.
+const sweepEvery = 1024
+
+// item is a cached value and when it expires; a zero expiresAt never expires.
+type item struct {
+	value     string
+	expiresAt time.Time
+}
+
+// expired reports whether the item has expired at now.
+func (i item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+// visitorSet holds the distinct visitors of one short key.
+type visitorSet struct {
+	visitors  map[string]struct{}
+	expiresAt time.Time
+}
+
+// CacheRepository implements the CacheRepository interface in process memory.
+type CacheRepository struct {
+	mu       sync.Mutex
+	items    map[string]item
+	visitors map[string]*visitorSet
+	writes   int
+}
+
+// NewCacheRepository creates an empty in-memory cache repository.
+func NewCacheRepository() *CacheRepository {
+	return &CacheRepository{
+		items:    make(map[string]item),
+		visitors: make(map[string]*visitorSet),
+	}
+}
+
+// expiry returns when an entry written now with ttl expires; ttl <= 0 never expires.
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(ttl)
+}
+
+// Set stores a key-value pair with TTL.
+func (r *CacheRepository) Set(_ context.Context, key, value string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.items[key] = item{value: value, expiresAt: expiry(now, ttl)}
+
+	r.writes++
+	if r.writes%sweepEvery == 0 {
+		r.sweepLocked(now)
+	}
+
+	return nil
+}
+
+// Get retrieves a value by key, returning repository.ErrCacheMiss when it is absent or expired.
+func (r *CacheRepository) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	it, ok := r.items[key]
+	if !ok {
+		return "", repository.ErrCacheMiss
+	}
+
+	if it.expired(time.Now()) {
+		delete(r.items, key)
+		return "", repository.ErrCacheMiss
+	}
+
+	return it.value, nil
+}
+
+// Delete removes a key from cache.
+func (r *CacheRepository) Delete(_ context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.items, key)
+
+	return nil
+}
+
+// Exists checks if a key exists in cache.
+func (r *CacheRepository) Exists(ctx context.Context, key string) (bool, error) {
+	_, err := r.Get(ctx, key)
+	if err == repository.ErrCacheMiss {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// SetCacheEntry stores a structured cache entry with metadata.
+// Entries are stored encoded, as in Redis, so callers never share the stored value.
+func (r *CacheRepository) SetCacheEntry(ctx context.Context, key string, entry *repository.CacheEntry, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return r.Set(ctx, key, string(data), ttl)
+}
+
+// GetCacheEntry retrieves a structured cache entry. A plain string stored with Set is
+// returned as a legacy entry holding that string as its long URL.
+func (r *CacheRepository) GetCacheEntry(ctx context.Context, key string) (*repository.CacheEntry, error) {
+	val, err := r.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var entry repository.CacheEntry
+	if err := json.Unmarshal([]byte(val), &entry); err != nil {
+		return &repository.CacheEntry{
+			LongURL:   val,
+			CreatedAt: time.Now(),
+		}, nil
+	}
+
+	return &entry, nil
+}
+
+// SetTombstone stores a tombstone marker for an expired/deleted URL.
+func (r *CacheRepository) SetTombstone(ctx context.Context, key, reason string, ttl time.Duration) error {
+	tombstone := &repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      reason,
+		CreatedAt:   time.Now(),
+	}
+
+	return r.SetCacheEntry(ctx, key, tombstone, ttl)
+}
+
+// FlushNamespace deletes every cached entry. The cache is private to this process,
+// so everything in it belongs to the service.
+func (r *CacheRepository) FlushNamespace(_ context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.items = make(map[string]item)
+	r.visitors = make(map[string]*visitorSet)
+
+	return nil
+}
+
+// AddVisitor adds visitor to the short key's distinct visitors and refreshes their TTL.
+func (r *CacheRepository) AddVisitor(_ context.Context, shortKey, visitor string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	set, ok := r.visitors[shortKey]
+	if !ok || (!set.expiresAt.IsZero() && !now.Before(set.expiresAt)) {
+		set = &visitorSet{visitors: make(map[string]struct{})}
+		r.visitors[shortKey] = set
+	}
+
+	set.visitors[visitor] = struct{}{}
+	set.expiresAt = expiry(now, ttl)
+
+	return nil
+}
+
+// EstimateVisitors returns the exact number of distinct visitors recorded for shortKey.
+func (r *CacheRepository) EstimateVisitors(_ context.Context, shortKey string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	set, ok := r.visitors[shortKey]
+	if !ok {
+		return 0, nil
+	}
+
+	if !set.expiresAt.IsZero() && !time.Now().Before(set.expiresAt) {
+		delete(r.visitors, shortKey)
+		return 0, nil
+	}
+
+	return int64(len(set.visitors)), nil
+}
+
+// sweepLocked removes expired entries and visitor sets. Callers hold r.mu.
+func (r *CacheRepository) sweepLocked(now time.Time) {
+	for key, it := range r.items {
+		if it.expired(now) {
+			delete(r.items, key)
+		}
+	}
+
+	for key, set := range r.visitors {
+		if !set.expiresAt.IsZero() && !now.Before(set.expiresAt) {
+			delete(r.visitors, key)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package memory provides an in-process implementation of the cache repository.
+//
+// It keeps entries in a map guarded by a mutex, honouring the same TTL, miss and
+// tombstone semantics as the Redis implementation, so the service can run without
+// Redis during local development and tests. Entries are private to one process:
+// running several instances with the memory backend gives each its own cache.
+//
+// Expired entries are removed when read and swept periodically as new entries
+// are written. Distinct visitor counts are exact rather than estimated.
+package memory
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,30 +15,79 @@ import (
 
 var (
 	// ErrCacheMiss is returned when a key is not found in the cache.
-	ErrCacheMiss = errors.New("cache miss")
+	ErrCacheMiss = repository.ErrCacheMiss
+	// ErrNoNamespace is returned by FlushNamespace when no key prefix is configured,
+	// since flushing would otherwise touch every key in a shared Redis.
+	ErrNoNamespace = errors.New("cache key prefix is not configured")
 )
 
+// flushScanCount is the SCAN page size used when flushing the namespace.
+const flushScanCount = 500
+
+// globEscaper escapes glob metacharacters so the prefix is matched literally by SCAN.
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
 // CacheRepository implements the CacheRepository interface for Redis.
 type CacheRepository struct {
-	client *redis.Client
+	client         *redis.Client
+	keyPrefix      string
+	commandTimeout time.Duration
 }
 
-// NewCacheRepository creates a new Redis cache repository.
-func NewCacheRepository(client *redis.Client) *CacheRepository {
+// NewCacheRepository creates a new Redis cache repository whose keys are stored under keyPrefix.
+// Each cache operation is bounded by the client's read and write timeouts so a
+// stalled Redis cannot hold a request for longer than a single round trip.
+func NewCacheRepository(client *redis.Client, keyPrefix string) *CacheRepository {
+	opts := client.Options()
+
 	return &CacheRepository{
-		client: client,
+		client:         client,
+		keyPrefix:      keyPrefix,
+		commandTimeout: opts.ReadTimeout + opts.WriteTimeout,
 	}
 }
 
+// key returns the namespaced Redis key.
+func (r *CacheRepository) key(key string) string {
+	return r.keyPrefix + key
+}
+
+// withTimeout derives a short-lived context for a single cache operation.
+func (r *CacheRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.commandTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, r.commandTimeout)
+}
+
+// isTimeout reports whether err is a Redis command timing out.
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
 // Set stores a key-value pair with TTL.
 func (r *CacheRepository) Set(ctx context.Context, key, value string, ttl time.Duration) error {
-	return r.client.Set(ctx, key, value, ttl).Err()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Set(ctx, r.key(key), value, ttl).Err()
 }
 
 // Get retrieves a value by key.
+// A timed out lookup is reported as a cache miss so callers fall back to the database.
 func (r *CacheRepository) Get(ctx context.Context, key string) (string, error) {
-	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	val, err := r.client.Get(ctx, r.key(key)).Result()
+	if err == redis.Nil || isTimeout(err) {
 		return "", ErrCacheMiss
 	}
 
@@ -49,12 +100,18 @@ func (r *CacheRepository) Get(ctx context.Context, key string) (string, error) {
 
 // Delete removes a key from cache.
 func (r *CacheRepository) Delete(ctx context.Context, key string) error {
-	return r.client.Del(ctx, key).Err()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Del(ctx, r.key(key)).Err()
 }
 
 // Exists checks if a key exists in cache.
 func (r *CacheRepository) Exists(ctx context.Context, key string) (bool, error) {
-	count, err := r.client.Exists(ctx, key).Result()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	count, err := r.client.Exists(ctx, r.key(key)).Result()
 	if err != nil {
 		return false, err
 	}
@@ -62,6 +119,37 @@ func (r *CacheRepository) Exists(ctx context.Context, key string) (bool, error)
 	return count > 0, nil
 }
 
+// visitorsKey returns the HyperLogLog key holding distinct visitors for shortKey.
+func (r *CacheRepository) visitorsKey(shortKey string) string {
+	return r.key("visitors:" + shortKey)
+}
+
+// AddVisitor adds visitor to the short key's HyperLogLog and refreshes its TTL.
+// Each HyperLogLog uses at most 12 KB regardless of how many visitors it has seen.
+func (r *CacheRepository) AddVisitor(ctx context.Context, shortKey, visitor string, ttl time.Duration) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	key := r.visitorsKey(shortKey)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.PFAdd(ctx, key, visitor)
+		pipe.Expire(ctx, key, ttl)
+
+		return nil
+	})
+
+	return err
+}
+
+// EstimateVisitors returns the HyperLogLog estimate of distinct visitors (standard error 0.81%).
+func (r *CacheRepository) EstimateVisitors(ctx context.Context, shortKey string) (int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.PFCount(ctx, r.visitorsKey(shortKey)).Result()
+}
+
 // SetCacheEntry stores a structured cache entry with metadata.
 func (r *CacheRepository) SetCacheEntry(ctx context.Context, key string, entry *repository.CacheEntry, ttl time.Duration) error {
 	data, err := json.Marshal(entry)
@@ -69,13 +157,20 @@ func (r *CacheRepository) SetCacheEntry(ctx context.Context, key string, entry *
 		return err
 	}
 
-	return r.client.Set(ctx, key, data, ttl).Err()
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Set(ctx, r.key(key), data, ttl).Err()
 }
 
 // GetCacheEntry retrieves a structured cache entry.
+// A timed out lookup is reported as a cache miss so callers fall back to the database.
 func (r *CacheRepository) GetCacheEntry(ctx context.Context, key string) (*repository.CacheEntry, error) {
-	val, err := r.client.Get(ctx, key).Result()
-	if err == redis.Nil {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	val, err := r.client.Get(ctx, r.key(key)).Result()
+	if err == redis.Nil || isTimeout(err) {
 		return nil, ErrCacheMiss
 	}
 
@@ -108,15 +203,64 @@ func (r *CacheRepository) SetTombstone(ctx context.Context, key, reason string,
 	return r.SetCacheEntry(ctx, key, tombstone, ttl)
 }
 
-// NewRedisClient creates a new Redis client.
-func NewRedisClient(addr, password string, db, poolSize, minIdleConns int) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:         addr,
-		Password:     password,
-		DB:           db,
-		PoolSize:     poolSize,
-		MinIdleConns: minIdleConns,
+// FlushNamespace deletes every key under the configured prefix.
+// It walks the keyspace with SCAN and deletes page by page; FLUSHALL is never used
+// because the Redis instance may be shared with other services.
+func (r *CacheRepository) FlushNamespace(ctx context.Context) error {
+	if r.keyPrefix == "" {
+		return ErrNoNamespace
+	}
+
+	var cursor uint64
+
+	for {
+		keys, next, err := r.client.Scan(ctx, cursor, globEscaper.Replace(r.keyPrefix)+"*", flushScanCount).Result()
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// ClientConfig holds the settings used to build a Redis client.
+type ClientConfig struct {
+	Addr         string
+	Password     string
+	DB           int
+	PoolSize     int
+	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// NewClient creates a Redis client from cfg without checking connectivity.
+func NewClient(cfg ClientConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:         cfg.Addr,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		MinIdleConns: cfg.MinIdleConns,
+		DialTimeout:  cfg.DialTimeout,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
 	})
+}
+
+// NewRedisClient creates a new Redis client and verifies the connection.
+func NewRedisClient(cfg ClientConfig) (*redis.Client, error) {
+	client := NewClient(cfg)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -7,15 +7,22 @@ import (
 
 	"github.com/spf13/viper"
 
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
 	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
 )
 
 // Config holds all application configuration.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	App      AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	Redis     RedisConfig
+	Cache     CacheConfig
+	App       AppConfig
+	Analytics AnalyticsConfig
 }
 
 // ServerConfig holds server configuration.
@@ -47,22 +54,143 @@ type RedisConfig struct {
 	DB           int
 	PoolSize     int
 	MinIdleConns int
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	KeyPrefix    string
+}
+
+// CacheConfig holds cache backend configuration.
+type CacheConfig struct {
+	// Where cached URLs live: "redis" (shared by all instances) or "memory" (per instance, no Redis needed)
+	Backend string
+}
+
+// AnalyticsConfig holds analytics recording configuration.
+type AnalyticsConfig struct {
+	// Record truncated SHA-256 short keys and anonymized IPs instead of raw values
+	HashIdentifiers bool `mapstructure:"hash_identifiers"`
+	// Store every redirect in url_clicks for the daily click timeline
+	RecordClicks bool `mapstructure:"record_clicks"`
+	// Clicks buffered in memory before new ones are dropped
+	ClickQueueSize int `mapstructure:"click_queue_size"`
+	// Clicks written per INSERT
+	ClickBatchSize int `mapstructure:"click_batch_size"`
+	// Longest a click waits in a partial batch before it is written
+	ClickFlushInterval time.Duration `mapstructure:"click_flush_interval"`
 }
 
 // AppConfig holds application-specific configuration.
 type AppConfig struct {
-	BaseURL           string
-	CacheTTL          time.Duration
-	SnowflakeNodeID   int64
+	BaseURL         string
+	CacheTTL        time.Duration
+	SnowflakeNodeID int64
+	// RFC 3339 instant Snowflake timestamps count from; empty uses snowflake.DefaultEpoch
+	SnowflakeEpoch    string
 	RateLimitRequests int
 	RateLimitWindow   time.Duration
 	GinMode           string
+	// Where rate limit counters live: "memory" (per instance) or "redis" (shared by all instances)
+	RateLimitBackend string
+	// Requests per window for route groups ("shorten", "redirect"); others use RateLimitRequests
+	RateLimitGroups map[string]int
+	// Header carrying an API key; requests with a key from RateLimitAPIKeys get their own bucket
+	RateLimitAPIKeyHeader string
+	// Client name to API key; unknown keys are counted by IP like anonymous requests
+	RateLimitAPIKeys map[string]string
+	// Factor applied to every rate limit for requests with a known API key
+	RateLimitAPIKeyMultiplier int
+	// Requests in flight at once on database write routes; excess get 503 (0 disables)
+	DBConcurrencyLimit int
+	// Throttled redirect response for browsers: "html" (429 page), "unavailable" (503 page) or "json"
+	RedirectThrottleResponse string
+	// Methods that redirect on short-key paths; others receive 405 Method Not Allowed
+	RedirectMethods []string
+	// Request body media types accepted when shortening; others receive 415 Unsupported Media Type
+	ShortenContentTypes []string
+	// Bearer token identifying admin callers; empty disables admin-only request options
+	AdminToken string
+	// Items accepted per batch request: shared default and per-endpoint overrides (shorten, resolve, stats)
+	BatchMaxItems int
+	BatchLimits   map[string]int
+	// Destination URL schemes accepted; others are rejected
+	AllowedSchemes []string
+	// Reject destinations on localhost or private, loopback and link-local addresses
+	BlockPrivateHosts bool
+	// Reject destinations without a recognised TLD, except allowlisted hosts
+	RequireValidTLD  bool
+	AllowedBareHosts []string
+	// Destinations with user:pass@ userinfo: "reject", "strip" or "allow"
+	URLCredentials string
+	// Reuse existing short keys only within the requesting owner's namespace
+	OwnerScopedDedup bool
+	// Bounds on cache TTLs: floor for near-expiry URLs, ceiling for all (0 disables)
+	MinCacheTTL time.Duration
+	MaxCacheTTL time.Duration
+	// Failed shorten-path cache writes retried after a delay, up to a bounded queue (0 disables)
+	CacheRetryQueueSize int
+	CacheRetryDelay     time.Duration
+	// Consecutive cache errors that make lookups skip the cache for a cooldown (0 disables)
+	CacheBreakerThreshold int
+	CacheBreakerCooldown  time.Duration
+	// Trailing text stripped from requested short keys before lookup (empty disables)
+	StripKeySuffixes []string
+	// Short key generation: "base62" or "snowflake-base62" (Snowflake-derived), or "random" (collision-aware growth)
+	ShortKeyStrategy           string
+	ShortKeyMinLength          int
+	ShortKeyMaxLength          int
+	ShortKeyCollisionThreshold float64
+	// Key alphabet: "default", "lowercase", "novowels", "digits" or "custom" (uses KeyAlphabet)
+	KeyProfile  string
+	KeyAlphabet string
+	// Regenerate keys containing offensive words (embedded list plus KeyBlocklistWords)
+	KeyBlocklist      bool
+	KeyBlocklistWords []string
+	// Custom keys rejected because they match a route or other reserved word (case-insensitive)
+	ReservedShortKeys []string
+	// Custom key reuse once the owning URL has expired
+	AllowExpiredKeyReuse bool
+	ExpiredKeyRetention  time.Duration
+	// Collision handling: generation attempts, custom key suggestion probes, backoff between checks
+	MaxKeyAttempts      int
+	MaxSuggestionProbes int
+	CollisionBackoff    time.Duration
+	// Adds X-Cache (HIT, MISS or TOMBSTONE) to redirect and stats responses
+	CacheStatusHeader bool
+	// Cache-Control max-age on successful stats responses (0 omits the header)
+	StatsCacheMaxAge time.Duration
+	// X-Processing-Time-Micros: "always", "never", "sampled" (1 in N) or "debug" (?debug only)
+	ProcessingTimeMode       string
+	ProcessingTimeSampleRate int
+	// Wrap successful JSON responses in {"data": ..., "meta": ...}; field naming "snake" or "camel"
+	ResponseEnvelope bool
+	JSONFieldNaming  string
+	// Gzip or deflate response bodies of at least CompressionMinSize bytes for clients that accept it
+	CompressionEnabled bool
+	CompressionMinSize int
+	// CORS origins allowed ("*" for any) and how long browsers may cache preflight responses
+	CORSAllowOrigins []string
+	CORSMaxAge       time.Duration
+	// Probabilistic early refresh of cache entries nearing expiry
+	CacheEarlyRefreshWindow      time.Duration
+	CacheEarlyRefreshProbability float64
+	// Serve recently expired cache entries when the database lookup fails
+	StaleWhileError       bool
+	StaleWhileErrorWindow time.Duration
+	// Estimate distinct visitors per short key with Redis HyperLogLogs kept for the TTL
+	UniqueVisitorEstimation bool
+	UniqueVisitorTTL        time.Duration
 	// Cleanup configuration for expired URLs
 	CleanupEnabled     bool
 	CleanupInterval    time.Duration
 	CleanupBatchSize   int
 	CleanupBufferTime  time.Duration
 	CleanupMaxDuration time.Duration
+	// Rows removed per DELETE statement within a cleanup batch
+	CleanupDeleteChunkSize int
+	// Readiness thresholds for the expired URL backlog
+	CleanupBacklogWarnThreshold int64
+	CleanupBacklogFailThreshold int64
 }
 
 // Load loads configuration from file and environment variables.
@@ -118,14 +246,84 @@ func setDefaults() {
 	viper.SetDefault("redis.db", 0)
 	viper.SetDefault("redis.poolsize", 10)
 	viper.SetDefault("redis.minidleconns", 5)
+	viper.SetDefault("redis.dialtimeout", "2s")
+	viper.SetDefault("redis.readtimeout", "200ms")
+	viper.SetDefault("redis.writetimeout", "200ms")
+	viper.SetDefault("redis.keyprefix", "urlshortener:")
+
+	// Cache defaults
+	viper.SetDefault("cache.backend", "redis")
+
+	// Analytics defaults
+	viper.SetDefault("analytics.hash_identifiers", false)
+	viper.SetDefault("analytics.record_clicks", true)
+	viper.SetDefault("analytics.click_queue_size", 10000)
+	viper.SetDefault("analytics.click_batch_size", 100)
+	viper.SetDefault("analytics.click_flush_interval", "1s")
 
 	// App defaults
 	viper.SetDefault("app.baseurl", "http://localhost:8080")
 	viper.SetDefault("app.cachettl", "24h")
 	viper.SetDefault("app.snowflakenodeid", 1)
+	viper.SetDefault("app.snowflakeepoch", "")
 	viper.SetDefault("app.ratelimitrequests", 100)
 	viper.SetDefault("app.ratelimitwindow", "1m")
+	viper.SetDefault("app.ratelimitbackend", "memory")
+	viper.SetDefault("app.ratelimitgroups", map[string]int{"shorten": 20, "redirect": 1000})
+	viper.SetDefault("app.ratelimitapikeyheader", "")
+	viper.SetDefault("app.ratelimitapikeys", map[string]string{})
+	viper.SetDefault("app.ratelimitapikeymultiplier", 10)
 	viper.SetDefault("app.ginmode", "release")
+	viper.SetDefault("app.dbconcurrencylimit", 50)
+	viper.SetDefault("app.redirectthrottleresponse", "html")
+	viper.SetDefault("app.redirectmethods", []string{"GET", "HEAD"})
+	viper.SetDefault("app.shortencontenttypes", []string{"application/json"})
+	viper.SetDefault("app.admintoken", "")
+	viper.SetDefault("app.batchmaxitems", 100)
+	viper.SetDefault("app.batchlimits", map[string]int{})
+	viper.SetDefault("app.allowedschemes", valueobject.DefaultAllowedSchemes)
+	viper.SetDefault("app.blockprivatehosts", true)
+	viper.SetDefault("app.requirevalidtld", false)
+	viper.SetDefault("app.allowedbarehosts", []string{"localhost"})
+	viper.SetDefault("app.urlcredentials", "reject")
+	viper.SetDefault("app.ownerscopeddedup", false)
+	viper.SetDefault("app.mincachettl", "1m")
+	viper.SetDefault("app.maxcachettl", "0s")
+	viper.SetDefault("app.cacheretryqueuesize", 1000)
+	viper.SetDefault("app.cacheretrydelay", "5s")
+	viper.SetDefault("app.cachebreakerthreshold", 5)
+	viper.SetDefault("app.cachebreakercooldown", "30s")
+	viper.SetDefault("app.stripkeysuffixes", []string{})
+	viper.SetDefault("app.shortkeystrategy", "base62")
+	viper.SetDefault("app.shortkeyminlength", 7)
+	viper.SetDefault("app.shortkeymaxlength", 12)
+	viper.SetDefault("app.shortkeycollisionthreshold", 0.1)
+	viper.SetDefault("app.keyprofile", "default")
+	viper.SetDefault("app.keyalphabet", "")
+	viper.SetDefault("app.keyblocklist", false)
+	viper.SetDefault("app.keyblocklistwords", []string{})
+	viper.SetDefault("app.reservedshortkeys", valueobject.DefaultReservedShortKeys)
+	viper.SetDefault("app.allowexpiredkeyreuse", false)
+	viper.SetDefault("app.expiredkeyretention", "1h")
+	viper.SetDefault("app.maxkeyattempts", 10)
+	viper.SetDefault("app.maxsuggestionprobes", 5)
+	viper.SetDefault("app.collisionbackoff", "0s")
+	viper.SetDefault("app.cachestatusheader", false)
+	viper.SetDefault("app.statscachemaxage", "5s")
+	viper.SetDefault("app.processingtimemode", "always")
+	viper.SetDefault("app.processingtimesamplerate", 100)
+	viper.SetDefault("app.responseenvelope", false)
+	viper.SetDefault("app.jsonfieldnaming", "snake")
+	viper.SetDefault("app.compressionenabled", true)
+	viper.SetDefault("app.compressionminsize", 1024)
+	viper.SetDefault("app.corsalloworigins", []string{"*"})
+	viper.SetDefault("app.corsmaxage", "10m")
+	viper.SetDefault("app.cacheearlyrefreshwindow", "1m")
+	viper.SetDefault("app.cacheearlyrefreshprobability", 0.05)
+	viper.SetDefault("app.stalewhileerror", false)
+	viper.SetDefault("app.stalewhileerrorwindow", "10m")
+	viper.SetDefault("app.uniquevisitorestimation", false)
+	viper.SetDefault("app.uniquevisitorttl", "720h")
 
 	// Cleanup defaults
 	viper.SetDefault("app.cleanupenabled", true)
@@ -133,6 +331,9 @@ func setDefaults() {
 	viper.SetDefault("app.cleanupbatchsize", 1000)
 	viper.SetDefault("app.cleanupbuffertime", "1h")
 	viper.SetDefault("app.cleanupmaxduration", "5m")
+	viper.SetDefault("app.cleanupdeletechunksize", 500)
+	viper.SetDefault("app.cleanupbacklogwarnthreshold", 10000)
+	viper.SetDefault("app.cleanupbacklogfailthreshold", 0)
 }
 
 // GetDSN returns the PostgreSQL connection string.
@@ -149,10 +350,104 @@ func (c *RedisConfig) GetRedisAddr() string {
 // GetCleanupConfig creates a cleanup service configuration from app config.
 func (c *AppConfig) GetCleanupConfig() *service.CleanupConfig {
 	return &service.CleanupConfig{
-		Enabled:            c.CleanupEnabled,
-		CleanupInterval:    c.CleanupInterval,
-		BatchSize:          c.CleanupBatchSize,
-		BufferTime:         c.CleanupBufferTime,
-		MaxCleanupDuration: c.CleanupMaxDuration,
+		Enabled:              c.CleanupEnabled,
+		CleanupInterval:      c.CleanupInterval,
+		BatchSize:            c.CleanupBatchSize,
+		BufferTime:           c.CleanupBufferTime,
+		MaxCleanupDuration:   c.CleanupMaxDuration,
+		BacklogWarnThreshold: c.CleanupBacklogWarnThreshold,
+		BacklogFailThreshold: c.CleanupBacklogFailThreshold,
+	}
+}
+
+// GetShortenConfig creates a URL shortening use case configuration from app config.
+func (c *AppConfig) GetShortenConfig() *usecase.ShortenURLConfig {
+	return &usecase.ShortenURLConfig{
+		AllowExpiredKeyReuse:    c.AllowExpiredKeyReuse,
+		ExpiredKeyRetention:     c.ExpiredKeyRetention,
+		MaxKeyAttempts:          c.MaxKeyAttempts,
+		ReservedShortKeys:       c.ReservedShortKeys,
+		MaxSuggestionProbes:     c.MaxSuggestionProbes,
+		CollisionBackoff:        c.CollisionBackoff,
+		EarlyRefreshWindow:      c.CacheEarlyRefreshWindow,
+		EarlyRefreshProbability: c.CacheEarlyRefreshProbability,
+		StaleWhileError:         c.StaleWhileError,
+		StaleWindow:             c.StaleWhileErrorWindow,
+		AllowedSchemes:          c.AllowedSchemes,
+		BlockPrivateHosts:       c.BlockPrivateHosts,
+		RequireValidTLD:         c.RequireValidTLD,
+		AllowedBareHosts:        c.AllowedBareHosts,
+		URLCredentials:          c.URLCredentials,
+		OwnerScopedDedup:        c.OwnerScopedDedup,
+		MinCacheTTL:             c.MinCacheTTL,
+		MaxCacheTTL:             c.MaxCacheTTL,
+		CacheRetryQueueSize:     c.CacheRetryQueueSize,
+		CacheRetryDelay:         c.CacheRetryDelay,
+		CacheBreakerThreshold:   c.CacheBreakerThreshold,
+		CacheBreakerCooldown:    c.CacheBreakerCooldown,
+		StripKeySuffixes:        c.StripKeySuffixes,
+	}
+}
+
+// GetSnowflakeEpoch returns the configured Snowflake epoch, or snowflake.DefaultEpoch when unset.
+func (c *AppConfig) GetSnowflakeEpoch() (time.Time, error) {
+	if strings.TrimSpace(c.SnowflakeEpoch) == "" {
+		return snowflake.DefaultEpoch, nil
+	}
+
+	epoch, err := time.Parse(time.RFC3339, strings.TrimSpace(c.SnowflakeEpoch))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid snowflake epoch %q: %w", c.SnowflakeEpoch, err)
 	}
+
+	return epoch, nil
+}
+
+// snowflakeEpochOrDefault is GetSnowflakeEpoch for reporting, where a bad value is
+// caught at startup and need not be surfaced again.
+func (c *AppConfig) snowflakeEpochOrDefault() time.Time {
+	epoch, err := c.GetSnowflakeEpoch()
+	if err != nil {
+		return snowflake.DefaultEpoch
+	}
+
+	return epoch.UTC()
+}
+
+// GetRuntimeInfo describes the key generation settings in effect for GET /api/admin/info.
+// The alphabet is the one the key profile resolves to, with all but its ends masked.
+func (c *AppConfig) GetRuntimeInfo() *dto.RuntimeInfoResponse {
+	strategy := strings.ToLower(strings.TrimSpace(c.ShortKeyStrategy))
+	if strategy == "" {
+		strategy = "base62"
+	}
+
+	alphabet, err := base62.AlphabetForProfile(c.KeyProfile, c.KeyAlphabet)
+	if err != nil {
+		alphabet = ""
+	}
+
+	return &dto.RuntimeInfoResponse{
+		Version:           Version,
+		BaseURL:           c.BaseURL,
+		IDStrategy:        strategy,
+		KeyProfile:        c.KeyProfile,
+		KeyAlphabet:       maskAlphabet(alphabet),
+		KeyAlphabetLength: len(alphabet),
+		Snowflake: dto.SnowflakeInfo{
+			NodeID: c.SnowflakeNodeID,
+			Epoch:  c.snowflakeEpochOrDefault().Format(time.RFC3339Nano),
+		},
+	}
+}
+
+// maskAlphabet keeps the first and last two characters of alphabet, enough to tell
+// alphabets apart without publishing the full ordering.
+func maskAlphabet(alphabet string) string {
+	const visible = 2
+	if len(alphabet) <= 2*visible {
+		return strings.Repeat("*", len(alphabet))
+	}
+
+	return alphabet[:visible] + strings.Repeat("*", len(alphabet)-2*visible) + alphabet[len(alphabet)-visible:]
 }
@@ -0,0 +1,6 @@
+package config
+
+// Version identifies the running build. It is set at build time with
+//
+//	go build -ldflags "-X github.com/Shofyan/url-shortener/internal/infrastructure/config.Version=v1.2.3"
+var Version = "dev"
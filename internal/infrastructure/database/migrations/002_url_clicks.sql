@@ -0,0 +1,17 @@
+-- Per-click analytics
+-- One row per redirect, used for click timelines and referrer breakdowns.
+
+CREATE TABLE IF NOT EXISTS url_clicks (
+    id BIGSERIAL PRIMARY KEY,
+    short_key VARCHAR(12) NOT NULL,
+    clicked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    referrer TEXT
+);
+
+-- Timeline and referrer queries always filter by short key and time range
+CREATE INDEX IF NOT EXISTS idx_url_clicks_short_key_clicked_at ON url_clicks(short_key, clicked_at);
+
+COMMENT ON TABLE url_clicks IS 'Individual redirect events for time-bucketed analytics';
+COMMENT ON COLUMN url_clicks.short_key IS 'Short key that was visited (not a foreign key so clicks survive cleanup)';
+COMMENT ON COLUMN url_clicks.clicked_at IS 'Time of the redirect (UTC)';
+COMMENT ON COLUMN url_clicks.referrer IS 'Referer header of the request, NULL for direct visits';
@@ -0,0 +1,6 @@
+-- Allow URLs to be paused without deleting them
+-- Disabled URLs keep their key and stats but stop redirecting until re-enabled.
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN urls.enabled IS 'Whether the short URL currently redirects (FALSE while paused by its owner)';
@@ -0,0 +1,6 @@
+-- Allow individual URLs to override the global cache TTL
+-- Hot URLs can stay cached longer while frequently changing ones expire from the cache sooner.
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS cache_ttl_seconds INTEGER;
+
+COMMENT ON COLUMN urls.cache_ttl_seconds IS 'Per-URL cache TTL in seconds (NULL uses the service default)';
@@ -0,0 +1,16 @@
+-- Index URLs by destination host
+-- Lets abuse and analytics tooling list every short link pointing at a given host.
+-- New rows get the host from the application on save; existing rows are backfilled here.
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS host TEXT;
+
+-- Extract the hostname: skip the scheme and any userinfo, stop at port, path, query or fragment.
+-- IPv6 literals lose their brackets, matching url.URL.Hostname().
+UPDATE urls
+SET host = lower(btrim(substring(long_url FROM '^[A-Za-z][A-Za-z0-9+.-]*://(?:[^@/?#]*@)?(\[[^]]*\]|[^:/?#]*)'), '[]'))
+WHERE host IS NULL;
+
+-- Supports FindByHost keyset pagination (newest first)
+CREATE INDEX IF NOT EXISTS idx_urls_host_created_at ON urls(host, created_at DESC, id DESC);
+
+COMMENT ON COLUMN urls.host IS 'Lowercased destination hostname without port, derived from long_url';
@@ -0,0 +1,10 @@
+-- Record which owner namespace created each URL
+-- Enables owner-scoped deduplication: one short link per destination per owner.
+-- URLs created without an owner keep the empty string.
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS owner TEXT NOT NULL DEFAULT '';
+
+-- Supports FindByLongURLAndOwner (newest first)
+CREATE INDEX IF NOT EXISTS idx_urls_owner_long_url ON urls(owner, long_url, created_at DESC);
+
+COMMENT ON COLUMN urls.owner IS 'Namespace of the caller that created the URL; empty when unowned';
@@ -0,0 +1,19 @@
+-- Destination history
+-- One row per change of a short key's destination, kept for auditing.
+
+CREATE TABLE IF NOT EXISTS url_destination_history (
+    id BIGSERIAL PRIMARY KEY,
+    short_key VARCHAR(12) NOT NULL,
+    previous_url TEXT NOT NULL,
+    new_url TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- History is always read per short key in change order
+CREATE INDEX IF NOT EXISTS idx_url_destination_history_short_key_changed_at ON url_destination_history(short_key, changed_at);
+
+COMMENT ON TABLE url_destination_history IS 'Audit trail of destination changes made through UpdateDestination';
+COMMENT ON COLUMN url_destination_history.short_key IS 'Short key that was repointed (not a foreign key so history survives deletion)';
+COMMENT ON COLUMN url_destination_history.previous_url IS 'Destination before the change';
+COMMENT ON COLUMN url_destination_history.new_url IS 'Destination after the change';
+COMMENT ON COLUMN url_destination_history.changed_at IS 'Time of the change (UTC)';
@@ -0,0 +1,7 @@
+-- Record why a URL was disabled
+-- Takedowns keep the row, its stats and its key; only redirects stop.
+-- A URL disabled with a reason is never re-enabled; pausing without one can be undone.
+
+ALTER TABLE urls ADD COLUMN IF NOT EXISTS disabled_reason TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN urls.disabled_reason IS 'Why the URL was taken down (e.g. abuse); empty when enabled or only paused';
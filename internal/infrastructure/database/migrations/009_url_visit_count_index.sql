@@ -0,0 +1,5 @@
+-- Index URLs by visit count
+-- Lets the top-URLs leaderboard read the most-visited rows straight off the index
+-- instead of sorting the whole table; id breaks ties between equal counts.
+
+CREATE INDEX IF NOT EXISTS idx_urls_visit_count ON urls(visit_count DESC, id DESC);
@@ -0,0 +1,8 @@
+-- Record request context with each click
+-- Lets analytics break clicks down by browser and client network, not just referrer.
+
+ALTER TABLE url_clicks ADD COLUMN IF NOT EXISTS user_agent TEXT;
+ALTER TABLE url_clicks ADD COLUMN IF NOT EXISTS client_ip TEXT;
+
+COMMENT ON COLUMN url_clicks.user_agent IS 'User-Agent header of the request, NULL when absent';
+COMMENT ON COLUMN url_clicks.client_ip IS 'Client IP of the request; anonymized when analytics identifiers are hashed';
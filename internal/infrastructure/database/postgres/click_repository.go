@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// ClickRepository implements the ClickRepository interface for PostgreSQL.
+type ClickRepository struct {
+	db *sql.DB
+}
+
+// NewClickRepository creates a new PostgreSQL click repository.
+func NewClickRepository(db *sql.DB) *ClickRepository {
+	return &ClickRepository{db: db}
+}
+
+// clickInsertColumns is the number of values inserted per click.
+const clickInsertColumns = 5
+
+// RecordClicks stores a batch of clicks with one multi-row INSERT. Empty referrers,
+// user agents and client IPs are stored as NULL.
+func (r *ClickRepository) RecordClicks(ctx context.Context, clicks []repository.Click) error {
+	if len(clicks) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(clicks))
+	args := make([]interface{}, 0, len(clicks)*clickInsertColumns)
+
+	for i, click := range clicks {
+		n := i * clickInsertColumns
+		values[i] = fmt.Sprintf("($%d, $%d, NULLIF($%d, ''), NULLIF($%d, ''), NULLIF($%d, ''))", n+1, n+2, n+3, n+4, n+5)
+		args = append(args, click.ShortKey, click.ClickedAt.UTC(), click.Referrer, click.UserAgent, click.ClientIP)
+	}
+
+	query := "INSERT INTO url_clicks (short_key, clicked_at, referrer, user_agent, client_ip) VALUES " + strings.Join(values, ", ")
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+
+	return err
+}
+
+// GetClicksByDay returns click counts grouped by UTC day for clicks in [from, to).
+func (r *ClickRepository) GetClicksByDay(ctx context.Context, shortKey string, from, to time.Time) ([]repository.DailyClicks, error) {
+	query := `
+		SELECT date_trunc('day', clicked_at) AS day, COUNT(*)
+		FROM url_clicks
+		WHERE short_key = $1 AND clicked_at >= $2 AND clicked_at < $3
+		GROUP BY day
+		ORDER BY day
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, shortKey, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	var days []repository.DailyClicks
+
+	for rows.Next() {
+		var day repository.DailyClicks
+		if err := rows.Scan(&day.Day, &day.Clicks); err != nil {
+			return nil, err
+		}
+
+		day.Day = day.Day.UTC()
+		days = append(days, day)
+	}
+
+	return days, rows.Err()
+}
+
+// GetTopReferrers returns the referrers with the most clicks in [from, to), highest first.
+func (r *ClickRepository) GetTopReferrers(
+	ctx context.Context,
+	shortKey string,
+	from, to time.Time,
+	limit int,
+) ([]repository.ReferrerCount, error) {
+	query := `
+		SELECT COALESCE(NULLIF(referrer, ''), 'direct') AS source, COUNT(*) AS clicks
+		FROM url_clicks
+		WHERE short_key = $1 AND clicked_at >= $2 AND clicked_at < $3
+		GROUP BY source
+		ORDER BY clicks DESC, source
+		LIMIT $4
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, shortKey, from.UTC(), to.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	var referrers []repository.ReferrerCount
+
+	for rows.Next() {
+		var referrer repository.ReferrerCount
+		if err := rows.Scan(&referrer.Referrer, &referrer.Clicks); err != nil {
+			return nil, err
+		}
+
+		referrers = append(referrers, referrer)
+	}
+
+	return referrers, rows.Err()
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// DestinationHistoryRepository implements the DestinationHistoryRepository interface for PostgreSQL.
+type DestinationHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewDestinationHistoryRepository creates a new PostgreSQL destination history repository.
+func NewDestinationHistoryRepository(db *sql.DB) *DestinationHistoryRepository {
+	return &DestinationHistoryRepository{db: db}
+}
+
+// RecordDestinationChange appends change to the history of shortKey.
+func (r *DestinationHistoryRepository) RecordDestinationChange(
+	ctx context.Context,
+	shortKey string,
+	change repository.DestinationChange,
+) error {
+	query := `
+		INSERT INTO url_destination_history (short_key, previous_url, new_url, changed_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, shortKey, change.PreviousURL, change.NewURL, change.ChangedAt.UTC())
+
+	return err
+}
+
+// GetDestinationHistory returns every recorded change of shortKey, oldest first.
+func (r *DestinationHistoryRepository) GetDestinationHistory(ctx context.Context, shortKey string) ([]repository.DestinationChange, error) {
+	query := `
+		SELECT previous_url, new_url, changed_at
+		FROM url_destination_history
+		WHERE short_key = $1
+		ORDER BY changed_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, shortKey)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	var changes []repository.DestinationChange
+
+	for rows.Next() {
+		var change repository.DestinationChange
+		if err := rows.Scan(&change.PreviousURL, &change.NewURL, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+
+		change.ChangedAt = change.ChangedAt.UTC()
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
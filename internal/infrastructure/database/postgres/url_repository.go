@@ -4,39 +4,132 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
 	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// batchInsertChunkSize is the number of rows per INSERT statement in BatchSave, keeping
+// each statement well under Postgres' limit of 65535 bind parameters.
+const batchInsertChunkSize = 1000
 
-	// Import postgres driver for database/sql.
-	_ "github.com/lib/pq"
+// DefaultDeleteChunkSize is the number of rows removed per DELETE statement during batch cleanup.
+const DefaultDeleteChunkSize = 500
+
+const (
+	// DefaultIncrementAttempts is how many times IncrementVisitCount runs its UPDATE
+	// before giving up on a transient error.
+	DefaultIncrementAttempts = 3
+
+	// DefaultIncrementBackoff is the delay before the first retry; it doubles on each attempt.
+	DefaultIncrementBackoff = 5 * time.Millisecond
+)
+
+// Postgres error codes that are safe to retry: the statement lost a race, not the data.
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
 )
 
 var (
 	// ErrNotFound is returned when a URL is not found in the database.
-	ErrNotFound = errors.New("URL not found")
+	ErrNotFound = repository.ErrNotFound
 )
 
 // URLRepository implements the URLRepository interface for PostgreSQL.
 type URLRepository struct {
-	db *sql.DB
+	db                *sql.DB
+	deleteChunkSize   int
+	incrementAttempts int
+	incrementBackoff  time.Duration
 }
 
 // NewURLRepository creates a new PostgreSQL URL repository.
 func NewURLRepository(db *sql.DB) *URLRepository {
-	return &URLRepository{db: db}
+	return &URLRepository{
+		db:                db,
+		deleteChunkSize:   DefaultDeleteChunkSize,
+		incrementAttempts: DefaultIncrementAttempts,
+		incrementBackoff:  DefaultIncrementBackoff,
+	}
+}
+
+// SetDeleteChunkSize sets how many rows DeleteExpiredBatch removes per statement.
+// Smaller chunks keep each statement's row locks short on large backlogs.
+// Non-positive values restore the default.
+func (r *URLRepository) SetDeleteChunkSize(size int) {
+	if size <= 0 {
+		size = DefaultDeleteChunkSize
+	}
+
+	r.deleteChunkSize = size
+}
+
+// SetIncrementRetry sets how many attempts IncrementVisitCount makes on transient
+// errors and the initial backoff between them. Non-positive values restore the defaults.
+func (r *URLRepository) SetIncrementRetry(attempts int, backoff time.Duration) {
+	if attempts <= 0 {
+		attempts = DefaultIncrementAttempts
+	}
+
+	if backoff <= 0 {
+		backoff = DefaultIncrementBackoff
+	}
+
+	r.incrementAttempts = attempts
+	r.incrementBackoff = backoff
+}
+
+// isTransientError reports whether err is a serialization failure or deadlock,
+// both of which Postgres expects the client to retry.
+func isTransientError(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+}
+
+// cacheTTLSeconds converts a per-URL cache TTL to its column value, storing NULL when unset.
+func cacheTTLSeconds(ttl time.Duration) sql.NullInt64 {
+	if ttl <= 0 {
+		return sql.NullInt64{}
+	}
+
+	return sql.NullInt64{Int64: int64(ttl / time.Second), Valid: true}
+}
+
+// Ping checks that the database is reachable.
+func (r *URLRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
 }
 
 // Save saves a new URL mapping.
 func (r *URLRepository) Save(ctx context.Context, url *entity.URL) error {
 	query := `
-		INSERT INTO urls (id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO urls (` + urlInsertColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.db.ExecContext(ctx, query, urlInsertArgs(url)...)
+
+	return err
+}
+
+// urlInsertColumns are the columns Save and BatchSave write, in argument order.
+const urlInsertColumns = "id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds, host, owner"
+
+// urlInsertArgs returns the values for urlInsertColumns.
+func urlInsertArgs(url *entity.URL) []interface{} {
+	return []interface{}{
 		url.ID,
 		url.ShortKey.Value(),
 		url.LongURL.Value(),
@@ -44,21 +137,156 @@ func (r *URLRepository) Save(ctx context.Context, url *entity.URL) error {
 		url.ExpiresAt,
 		url.VisitCount,
 		url.LastAccessedAt,
-	)
+		url.IsEnabled(),
+		cacheTTLSeconds(url.CacheTTL),
+		url.LongURL.Host(),
+		url.Owner,
+	}
+}
 
-	return err
+// BatchSave saves many new URL mappings in a single transaction, with one multi-row
+// INSERT per batchInsertChunkSize URLs. Rows whose short key is taken are skipped by
+// ON CONFLICT and detected from the keys the INSERT returns, so a collision fails with
+// a *repository.DuplicateKeyError naming the key and the transaction is rolled back.
+func (r *URLRepository) BatchSave(ctx context.Context, urls []*entity.URL) error {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	// Repeats within the batch would be skipped by ON CONFLICT without reaching the table
+	seen := make(map[string]bool, len(urls))
+
+	for _, url := range urls {
+		key := url.ShortKey.Value()
+		if seen[key] {
+			return &repository.DuplicateKeyError{ShortKey: key}
+		}
+
+		seen[key] = true
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("Warning: Failed to rollback transaction: %v", rollbackErr)
+		}
+	}()
+
+	for start := 0; start < len(urls); start += batchInsertChunkSize {
+		end := start + batchInsertChunkSize
+		if end > len(urls) {
+			end = len(urls)
+		}
+
+		if err := insertURLChunk(ctx, tx, urls[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// insertURLChunk inserts urls with one statement and returns a DuplicateKeyError for
+// the first URL whose short key was already stored.
+func insertURLChunk(ctx context.Context, tx *sql.Tx, urls []*entity.URL) error {
+	columns := strings.Count(urlInsertColumns, ",") + 1
+	values := make([]string, len(urls))
+	args := make([]interface{}, 0, len(urls)*columns)
+
+	for i, url := range urls {
+		placeholders := make([]string, columns)
+		for j := range placeholders {
+			placeholders[j] = fmt.Sprintf("$%d", i*columns+j+1)
+		}
+
+		values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+		args = append(args, urlInsertArgs(url)...)
+	}
+
+	query := "INSERT INTO urls (" + urlInsertColumns + ") VALUES " + strings.Join(values, ", ") +
+		" ON CONFLICT (short_key) DO NOTHING RETURNING short_key"
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	inserted := make(map[string]bool, len(urls))
+
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return err
+		}
+
+		inserted[key] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, url := range urls {
+		if !inserted[url.ShortKey.Value()] {
+			return &repository.DuplicateKeyError{ShortKey: url.ShortKey.Value()}
+		}
+	}
+
+	return nil
 }
 
 // FindByShortKey retrieves a URL by its short key.
 func (r *URLRepository) FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error) {
 	query := `
-		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason, owner
 		FROM urls
 		WHERE short_key = $1
 	`
 
-	row := r.db.QueryRowContext(ctx, query, shortKey.Value())
+	return scanURLRow(r.db.QueryRowContext(ctx, query, shortKey.Value()))
+}
+
+// FindByLongURL retrieves a URL by its long URL.
+func (r *URLRepository) FindByLongURL(ctx context.Context, longURL *valueobject.LongURL) (*entity.URL, error) {
+	query := `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason, owner
+		FROM urls
+		WHERE long_url = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return scanURLRow(r.db.QueryRowContext(ctx, query, longURL.Value()))
+}
 
+// FindByLongURLAndOwner retrieves the newest URL for longURL created by owner.
+func (r *URLRepository) FindByLongURLAndOwner(
+	ctx context.Context,
+	longURL *valueobject.LongURL,
+	owner string,
+) (*entity.URL, error) {
+	query := `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason, owner
+		FROM urls
+		WHERE owner = $1 AND long_url = $2
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	return scanURLRow(r.db.QueryRowContext(ctx, query, owner, longURL.Value()))
+}
+
+// scanURLRow scans a single URL selected with the disabled_reason and owner columns,
+// mapping no rows to ErrNotFound.
+func scanURLRow(row *sql.Row) (*entity.URL, error) {
 	var (
 		id             int64
 		shortKeyStr    string
@@ -67,9 +295,16 @@ func (r *URLRepository) FindByShortKey(ctx context.Context, shortKey *valueobjec
 		expiresAt      sql.NullTime
 		visitCount     int64
 		lastAccessedAt sql.NullTime
+		enabled        bool
+		cacheTTL       sql.NullInt64
+		disabledReason string
+		owner          string
 	)
 
-	err := row.Scan(&id, &shortKeyStr, &longURLStr, &createdAt, &expiresAt, &visitCount, &lastAccessedAt)
+	err := row.Scan(
+		&id, &shortKeyStr, &longURLStr, &createdAt, &expiresAt, &visitCount, &lastAccessedAt, &enabled, &cacheTTL,
+		&disabledReason, &owner,
+	)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrNotFound
@@ -82,11 +317,14 @@ func (r *URLRepository) FindByShortKey(ctx context.Context, shortKey *valueobjec
 	lu, _ := valueobject.NewLongURL(longURLStr)
 
 	url := &entity.URL{
-		ID:         id,
-		ShortKey:   sk,
-		LongURL:    lu,
-		CreatedAt:  createdAt,
-		VisitCount: visitCount,
+		ID:             id,
+		ShortKey:       sk,
+		LongURL:        lu,
+		CreatedAt:      createdAt,
+		VisitCount:     visitCount,
+		Disabled:       !enabled,
+		DisabledReason: disabledReason,
+		Owner:          owner,
 	}
 
 	if expiresAt.Valid {
@@ -97,74 +335,246 @@ func (r *URLRepository) FindByShortKey(ctx context.Context, shortKey *valueobjec
 		url.LastAccessedAt = &lastAccessedAt.Time
 	}
 
+	if cacheTTL.Valid {
+		url.CacheTTL = time.Duration(cacheTTL.Int64) * time.Second
+	}
+
 	return url, nil
 }
 
-// FindByLongURL retrieves a URL by its long URL.
-func (r *URLRepository) FindByLongURL(ctx context.Context, longURL *valueobject.LongURL) (*entity.URL, error) {
+// FindByHost lists URLs whose destination host equals host, newest first,
+// using keyset pagination over (created_at, id).
+func (r *URLRepository) FindByHost(
+	ctx context.Context,
+	host string,
+	cursor *repository.KeysetCursor,
+	limit int,
+) ([]*entity.URL, error) {
 	query := `
-		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason
 		FROM urls
-		WHERE long_url = $1
-		ORDER BY created_at DESC
-		LIMIT 1
+		WHERE host = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
 	`
+	args := []interface{}{host, limit}
 
-	row := r.db.QueryRowContext(ctx, query, longURL.Value())
-
-	var (
-		id             int64
-		shortKeyStr    string
-		longURLStr     string
-		createdAt      time.Time
-		expiresAt      sql.NullTime
-		visitCount     int64
-		lastAccessedAt sql.NullTime
-	)
+	if cursor != nil {
+		query = `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason
+		FROM urls
+		WHERE host = $1 AND (created_at, id) < ($3, $4)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+		args = append(args, cursor.CreatedAt, cursor.ID)
+	}
 
-	err := row.Scan(&id, &shortKeyStr, &longURLStr, &createdAt, &expiresAt, &visitCount, &lastAccessedAt)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, ErrNotFound
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	return scanURLRows(rows)
+}
+
+// scanURLRows reads every row of a query selecting the columns FindByHost, FindTopByVisits and List select.
+func scanURLRows(rows *sql.Rows) ([]*entity.URL, error) {
+	var urls []*entity.URL
+
+	for rows.Next() {
+		var (
+			url                         entity.URL
+			shortKeyValue, longURLValue string
+			enabled                     bool
+			cacheTTL                    sql.NullInt64
+		)
+
+		err := rows.Scan(
+			&url.ID,
+			&shortKeyValue,
+			&longURLValue,
+			&url.CreatedAt,
+			&url.ExpiresAt,
+			&url.VisitCount,
+			&url.LastAccessedAt,
+			&enabled,
+			&cacheTTL,
+			&url.DisabledReason,
+		)
+		if err != nil {
+			return nil, err
 		}
 
+		if url.ShortKey, err = valueobject.NewShortKey(shortKeyValue); err != nil {
+			return nil, err
+		}
+
+		if url.LongURL, err = valueobject.NewLongURL(longURLValue); err != nil {
+			return nil, err
+		}
+
+		url.Disabled = !enabled
+
+		if cacheTTL.Valid {
+			url.CacheTTL = time.Duration(cacheTTL.Int64) * time.Second
+		}
+
+		urls = append(urls, &url)
+	}
+
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	sk, _ := valueobject.NewShortKey(shortKeyStr)
-	lu, _ := valueobject.NewLongURL(longURLStr)
+	return urls, nil
+}
 
-	url := &entity.URL{
-		ID:         id,
-		ShortKey:   sk,
-		LongURL:    lu,
-		CreatedAt:  createdAt,
-		VisitCount: visitCount,
+// listOrderClauses maps each List order to its ORDER BY clause. Only these fixed
+// strings are ever placed in the query, so orderBy cannot inject SQL.
+var listOrderClauses = map[string]string{
+	repository.ListOrderCreatedAt:  "created_at DESC, id DESC",
+	repository.ListOrderVisitCount: "visit_count DESC, id DESC",
+	repository.ListOrderExpiresAt:  "expires_at DESC NULLS LAST, id DESC",
+}
+
+// List returns a page of all URLs sorted by orderBy and the total number of URLs.
+func (r *URLRepository) List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error) {
+	orderClause, ok := listOrderClauses[orderBy]
+	if !ok {
+		return nil, 0, fmt.Errorf("%w: %q", repository.ErrInvalidListOrder, orderBy)
 	}
 
-	if expiresAt.Valid {
-		url.ExpiresAt = &expiresAt.Time
+	var total int64
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM urls`).Scan(&total); err != nil {
+		return nil, 0, err
 	}
 
-	if lastAccessedAt.Valid {
-		url.LastAccessedAt = &lastAccessedAt.Time
+	query := `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason
+		FROM urls
+		ORDER BY ` + orderClause + `
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return url, nil
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	urls, err := scanURLRows(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return urls, total, nil
+}
+
+// FindTopByVisits lists the most-visited URLs, walking idx_urls_visit_count so only
+// the returned rows are read. The since filter is applied while walking the index.
+func (r *URLRepository) FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error) {
+	query := `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason
+		FROM urls
+		ORDER BY visit_count DESC, id DESC
+		LIMIT $1
+	`
+	args := []interface{}{limit}
+
+	if since != nil {
+		query = `
+		SELECT id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds,
+			disabled_reason
+		FROM urls
+		WHERE created_at >= $2
+		ORDER BY visit_count DESC, id DESC
+		LIMIT $1
+	`
+		args = append(args, *since)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	return scanURLRows(rows)
+}
+
+// Upsert atomically creates the URL or updates the destination of an existing short key.
+func (r *URLRepository) Upsert(ctx context.Context, url *entity.URL) (repository.UpsertResult, error) {
+	// xmax is zero for freshly inserted rows, which distinguishes a create from an update.
+	// The WHERE clause turns an identical destination into a no-op that returns no row.
+	query := `
+		INSERT INTO urls (id, short_key, long_url, created_at, expires_at, visit_count, last_accessed_at, enabled, cache_ttl_seconds, host, owner)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (short_key) DO UPDATE
+		SET long_url = EXCLUDED.long_url, expires_at = EXCLUDED.expires_at, host = EXCLUDED.host
+		WHERE urls.long_url IS DISTINCT FROM EXCLUDED.long_url
+		RETURNING id, created_at, visit_count, (xmax = 0) AS inserted
+	`
+
+	var inserted bool
+
+	err := r.db.QueryRowContext(ctx, query,
+		url.ID,
+		url.ShortKey.Value(),
+		url.LongURL.Value(),
+		url.CreatedAt,
+		url.ExpiresAt,
+		url.VisitCount,
+		url.LastAccessedAt,
+		url.IsEnabled(),
+		cacheTTLSeconds(url.CacheTTL),
+		url.LongURL.Host(),
+		url.Owner,
+	).Scan(&url.ID, &url.CreatedAt, &url.VisitCount, &inserted)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return repository.UpsertUnchanged, nil
+		}
+
+		return "", err
+	}
+
+	if inserted {
+		return repository.UpsertCreated, nil
+	}
+
+	return repository.UpsertUpdated, nil
 }
 
 // Update updates an existing URL.
 func (r *URLRepository) Update(ctx context.Context, url *entity.URL) error {
 	query := `
 		UPDATE urls
-		SET long_url = $1, expires_at = $2, visit_count = $3
-		WHERE short_key = $4
+		SET long_url = $1, expires_at = $2, visit_count = $3, enabled = $4, cache_ttl_seconds = $5, host = $6
+		WHERE short_key = $7
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		url.LongURL.Value(),
 		url.ExpiresAt,
 		url.VisitCount,
+		url.IsEnabled(),
+		cacheTTLSeconds(url.CacheTTL),
+		url.LongURL.Host(),
 		url.ShortKey.Value(),
 	)
 
@@ -205,6 +615,151 @@ func (r *URLRepository) Delete(ctx context.Context, shortKey *valueobject.ShortK
 	return nil
 }
 
+// Replace deletes the row stored for url's short key and inserts url in its place within
+// one transaction, so a reclaimed key keeps none of the previous URL's ID, timestamps,
+// owner, visits or takedown.
+func (r *URLRepository) Replace(ctx context.Context, url *entity.URL) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			log.Printf("Warning: Failed to rollback transaction: %v", rollbackErr)
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM urls WHERE short_key = $1`, url.ShortKey.Value())
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	query := `
+		INSERT INTO urls (` + urlInsertColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+
+	if _, err := tx.ExecContext(ctx, query, urlInsertArgs(url)...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetEnabled pauses or resumes redirects for a URL without deleting it.
+// A URL taken down with a reason is never re-enabled and reports ErrNotFound.
+func (r *URLRepository) SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error {
+	query := `UPDATE urls SET enabled = $1 WHERE short_key = $2 AND NOT ($1 AND disabled_reason <> '')`
+
+	return r.execAffectingKey(ctx, query, enabled, shortKey.Value())
+}
+
+// Disable stops redirects for a URL, recording the reason.
+func (r *URLRepository) Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error {
+	query := `UPDATE urls SET enabled = FALSE, disabled_reason = $1 WHERE short_key = $2`
+
+	return r.execAffectingKey(ctx, query, reason, shortKey.Value())
+}
+
+// execAffectingKey runs an UPDATE of a single short key, returning ErrNotFound when no row matched.
+func (r *URLRepository) execAffectingKey(ctx context.Context, query string, args ...interface{}) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RenewByCriteria moves the expiry of every matching URL to expiresAt with a single UPDATE.
+// Only URLs that have an expiry and have not reached it yet are renewed; permanent
+// URLs are left alone rather than being given an expiry.
+func (r *URLRepository) RenewByCriteria(
+	ctx context.Context,
+	criteria repository.RenewCriteria,
+	expiresAt time.Time,
+) ([]string, error) {
+	conditions := []string{"expires_at IS NOT NULL", "expires_at > $2"}
+	args := []interface{}{expiresAt, time.Now()}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if criteria.Host != "" {
+		addCondition("host = $%d", criteria.Host)
+	}
+
+	if criteria.Owner != "" {
+		addCondition("owner = $%d", criteria.Owner)
+	}
+
+	if criteria.KeyPrefix != "" {
+		addCondition("short_key LIKE $%d", escapeLike(criteria.KeyPrefix)+"%")
+	}
+
+	if criteria.CreatedAfter != nil {
+		addCondition("created_at >= $%d", *criteria.CreatedAfter)
+	}
+
+	if criteria.CreatedBefore != nil {
+		addCondition("created_at < $%d", *criteria.CreatedBefore)
+	}
+
+	query := `UPDATE urls SET expires_at = $1 WHERE ` + strings.Join(conditions, " AND ") + ` RETURNING short_key`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close() // Ignore error on deferred close
+	}()
+
+	var shortKeys []string
+
+	for rows.Next() {
+		var shortKey string
+		if err := rows.Scan(&shortKey); err != nil {
+			return nil, err
+		}
+
+		shortKeys = append(shortKeys, shortKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return shortKeys, nil
+}
+
+// escapeLike escapes LIKE wildcards so value is matched literally.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
 // ExistsByShortKey checks if a short key already exists.
 func (r *URLRepository) ExistsByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM urls WHERE short_key = $1)`
@@ -220,6 +775,8 @@ func (r *URLRepository) ExistsByShortKey(ctx context.Context, shortKey *valueobj
 }
 
 // IncrementVisitCount atomically increments visit count and updates last_accessed_at.
+// Serialization failures and deadlocks are retried with exponential backoff, so
+// callers do not need their own retry loop.
 func (r *URLRepository) IncrementVisitCount(ctx context.Context, shortKey *valueobject.ShortKey) error {
 	log.Printf("[IncrementVisitCount] Incrementing visit count for short key: %s", shortKey.Value())
 
@@ -230,7 +787,31 @@ func (r *URLRepository) IncrementVisitCount(ctx context.Context, shortKey *value
 		WHERE short_key = $1
 	`
 
-	result, err := r.db.ExecContext(ctx, query, shortKey.Value())
+	var (
+		result sql.Result
+		err    error
+	)
+
+	backoff := r.incrementBackoff
+
+	for attempt := 1; ; attempt++ {
+		result, err = r.db.ExecContext(ctx, query, shortKey.Value())
+		if err == nil || !isTransientError(err) || attempt >= r.incrementAttempts {
+			break
+		}
+
+		log.Printf("[IncrementVisitCount] Transient error for %s (attempt %d/%d), retrying in %v: %v",
+			shortKey.Value(), attempt, r.incrementAttempts, backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+
 	if err != nil {
 		log.Printf("[IncrementVisitCount] Error incrementing visit count for %s: %v", shortKey.Value(), err)
 		return err
@@ -312,6 +893,7 @@ func (r *URLRepository) FindExpiredURLs(ctx context.Context, before time.Time, m
 }
 
 // DeleteExpiredBatch deletes multiple URLs by their short keys in a single transaction.
+// The keys are removed in chunks of deleteChunkSize rows per statement.
 func (r *URLRepository) DeleteExpiredBatch(ctx context.Context, shortKeys []*valueobject.ShortKey) error {
 	if len(shortKeys) == 0 {
 		return nil
@@ -324,7 +906,7 @@ func (r *URLRepository) DeleteExpiredBatch(ctx context.Context, shortKeys []*val
 	}
 
 	defer func() {
-		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
 			log.Printf("Warning: Failed to rollback transaction: %v", rollbackErr)
 		}
 	}()
@@ -338,13 +920,24 @@ func (r *URLRepository) DeleteExpiredBatch(ctx context.Context, shortKeys []*val
 		keyValues[i] = key.Value()
 	}
 
-	result, err := tx.ExecContext(ctx, query, keyValues)
-	if err != nil {
-		return err
+	var rowsAffected int64
+
+	for start := 0; start < len(keyValues); start += r.deleteChunkSize {
+		end := start + r.deleteChunkSize
+		if end > len(keyValues) {
+			end = len(keyValues)
+		}
+
+		result, err := tx.ExecContext(ctx, query, pq.Array(keyValues[start:end]))
+		if err != nil {
+			return err
+		}
+
+		affected, _ := result.RowsAffected()
+		rowsAffected += affected
 	}
 
 	// Log the number of deleted records for monitoring
-	rowsAffected, _ := result.RowsAffected()
 	if rowsAffected != int64(len(shortKeys)) {
 		// Some records might have already been deleted - this is acceptable
 		// in a concurrent environment where multiple cleanup processes might run
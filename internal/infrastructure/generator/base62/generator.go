@@ -1,10 +1,14 @@
 package base62
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"math"
 	"strings"
+	"unicode"
 
+	"github.com/Shofyan/url-shortener/internal/domain/service"
 	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
 )
 
@@ -12,23 +16,190 @@ const base62Chars = "23456789ABCDEFGHJKMNPQRSTUVWXYZabcdefghjkmnpqrstuvwxyz"
 
 // Excluded characters: 0, O, l, 1 for readability compliance
 
+// Key profiles select a preset alphabet for generated short keys.
+const (
+	// ProfileDefault mixes digits and both cases, minus look-alike characters.
+	ProfileDefault = "default"
+	// ProfileLowercase drops upper case so keys survive case-insensitive handling.
+	ProfileLowercase = "lowercase"
+	// ProfileNoVowels drops vowels so keys cannot spell accidental words.
+	ProfileNoVowels = "novowels"
+	// ProfileDigits produces numeric keys only.
+	ProfileDigits = "digits"
+	// ProfileCustom uses a caller-supplied alphabet.
+	ProfileCustom = "custom"
+)
+
+var profileAlphabets = map[string]string{
+	ProfileDefault:   base62Chars,
+	ProfileLowercase: "23456789abcdefghjkmnpqrstuvwxyz",
+	ProfileNoVowels:  "23456789BCDFGHJKMNPQRSTVWXYZbcdfghjkmnpqrstvwxyz",
+	ProfileDigits:    "0123456789",
+}
+
+var (
+	// ErrUnknownProfile is returned for a key profile that has no preset alphabet.
+	ErrUnknownProfile = errors.New("unknown key profile")
+	// ErrInvalidAlphabet is returned when an alphabet cannot encode IDs bijectively
+	// or contains characters a short key may not use.
+	ErrInvalidAlphabet = errors.New("invalid key alphabet")
+	// ErrKeyTooLong is returned when decoding a key longer than any this generator produces.
+	ErrKeyTooLong = errors.New("short key too long to decode")
+	// ErrEncodedTooLong is returned by GenerateFromID when an ID encodes to more
+	// characters than a short key allows.
+	ErrEncodedTooLong = service.ErrShortKeyTooLong
+	// ErrIDOutOfRange is returned when a key decodes to a value beyond the int64 range.
+	ErrIDOutOfRange = errors.New("short key decodes beyond the ID range")
+	// ErrInvalidMinLength is returned for a minimum key length beyond the short key limit.
+	ErrInvalidMinLength = errors.New("invalid minimum key length")
+	// ErrInvalidPadChar is returned for a pad character that would change decoded IDs
+	// or is not allowed in short keys.
+	ErrInvalidPadChar = errors.New("invalid pad character")
+)
+
+// AlphabetForProfile returns the alphabet for a key profile. An empty profile means
+// ProfileDefault, and ProfileCustom returns custom after validating it.
+func AlphabetForProfile(profile, custom string) (string, error) {
+	profile = strings.ToLower(strings.TrimSpace(profile))
+	if profile == "" {
+		profile = ProfileDefault
+	}
+
+	if profile == ProfileCustom {
+		if err := ValidateAlphabet(custom); err != nil {
+			return "", err
+		}
+
+		return custom, nil
+	}
+
+	alphabet, ok := profileAlphabets[profile]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownProfile, profile)
+	}
+
+	return alphabet, nil
+}
+
+// ValidateAlphabet checks that an alphabet has at least two distinct characters, so
+// every ID has exactly one encoding, and that each is allowed in a short key.
+// Errors name the offending character and its index.
+func ValidateAlphabet(alphabet string) error {
+	if len(alphabet) < 2 {
+		return fmt.Errorf("%w: need at least 2 characters, got %d", ErrInvalidAlphabet, len(alphabet))
+	}
+
+	firstSeen := make(map[rune]int, len(alphabet))
+
+	for i, char := range alphabet {
+		if _, err := valueobject.NewShortKey(string(char)); err != nil {
+			return fmt.Errorf("%w: character %q at index %d is not allowed in short keys (letters, digits, '-' and '_' only)",
+				ErrInvalidAlphabet, char, i)
+		}
+
+		if first, ok := firstSeen[char]; ok {
+			return fmt.Errorf("%w: duplicate character %q at index %d (first seen at index %d)",
+				ErrInvalidAlphabet, char, i, first)
+		}
+
+		firstSeen[char] = i
+	}
+
+	return nil
+}
+
 // Generator implements the ShortKeyGenerator interface using Base62 encoding.
-type Generator struct{}
+// Despite the name it encodes in whatever base its alphabet provides.
+type Generator struct {
+	alphabet string
+	// Keys shorter than minLength are left-padded with padChar
+	minLength int
+	padChar   byte
+}
 
-// NewGenerator creates a new Base62 generator.
+// NewGenerator creates a new Base62 generator with the default alphabet.
 func NewGenerator() *Generator {
-	return &Generator{}
+	return &Generator{alphabet: base62Chars, padChar: base62Chars[0]}
 }
 
-// GenerateFromID converts an ID to a Base62 encoded short key.
-func (g *Generator) GenerateFromID(id int64) (*valueobject.ShortKey, error) {
-	if id == 0 {
-		return valueobject.NewShortKey("0")
+// NewGeneratorWithAlphabet creates a generator that encodes IDs using alphabet.
+func NewGeneratorWithAlphabet(alphabet string) (*Generator, error) {
+	if err := ValidateAlphabet(alphabet); err != nil {
+		return nil, err
+	}
+
+	return &Generator{alphabet: alphabet, padChar: alphabet[0]}, nil
+}
+
+// NewGeneratorWithMinLength creates a generator with the default alphabet whose keys
+// are at least min characters long. Shorter encodings are left-padded with the
+// alphabet's zero character, which leaves the decoded ID unchanged.
+func NewGeneratorWithMinLength(min int) (*Generator, error) {
+	gen := NewGenerator()
+	if err := gen.SetMinLength(min); err != nil {
+		return nil, err
 	}
 
-	encoded := g.encode(id)
+	return gen, nil
+}
+
+// SetMinLength sets the minimum key length; values below 2 disable padding.
+func (g *Generator) SetMinLength(min int) error {
+	if min > valueobject.MaxShortKeyLength {
+		return fmt.Errorf("%w: %d exceeds the short key limit of %d", ErrInvalidMinLength, min, valueobject.MaxShortKeyLength)
+	}
+
+	g.minLength = min
+
+	return nil
+}
+
+// SetPadChar sets the character keys are padded with. It must be the alphabet's zero
+// character or a short key character outside the alphabet, so padding can be told
+// apart from encoded digits.
+func (g *Generator) SetPadChar(pad rune) error {
+	if pad > unicode.MaxASCII {
+		return fmt.Errorf("%w: %q is not ASCII", ErrInvalidPadChar, pad)
+	}
+
+	if _, err := valueobject.NewShortKey(string(pad)); err != nil {
+		return fmt.Errorf("%w: %q is not allowed in short keys", ErrInvalidPadChar, pad)
+	}
+
+	if index := strings.IndexRune(g.alphabet, pad); index > 0 {
+		return fmt.Errorf("%w: %q encodes the digit %d", ErrInvalidPadChar, pad, index)
+	}
+
+	g.padChar = byte(pad)
+
+	return nil
+}
+
+// Alphabet returns the characters keys are encoded with.
+func (g *Generator) Alphabet() string {
+	return g.alphabet
+}
+
+// MaxKeyLength returns the length of the longest key this generator can produce,
+// i.e. the encoding of the largest int64 ID.
+func (g *Generator) MaxKeyLength() int {
+	if length := len(g.encode(math.MaxInt64)); length > g.minLength {
+		return length
+	}
+
+	return g.minLength
+}
+
+// GenerateFromID converts an ID to a Base62 encoded short key.
+func (g *Generator) GenerateFromID(id int64) (*valueobject.ShortKey, error) {
+	encoded := g.pad(g.encode(id))
 	log.Printf("[Base62] Generated ID: %d, Encoded: %s, Length: %d", id, encoded, len(encoded))
 
+	if len(encoded) > valueobject.MaxShortKeyLength {
+		return nil, fmt.Errorf("%w: id %d encodes to %d characters (max %d)",
+			ErrEncodedTooLong, id, len(encoded), valueobject.MaxShortKeyLength)
+	}
+
 	shortKey, err := valueobject.NewShortKey(encoded)
 	if err != nil {
 		log.Printf("[Base62] Error creating short key from encoded string '%s': %v", encoded, err)
@@ -38,24 +209,44 @@ func (g *Generator) GenerateFromID(id int64) (*valueobject.ShortKey, error) {
 	return shortKey, nil
 }
 
-// DecodeToID decodes a Base62 short key back to an ID.
+// DecodeToID decodes a Base62 short key back to an ID. Keys longer than MaxKeyLength
+// (or the short key limit) and keys whose value overflows int64 are rejected rather
+// than decoded to a wrapped-around ID.
 func (g *Generator) DecodeToID(shortKey *valueobject.ShortKey) (int64, error) {
-	return g.decode(shortKey.Value()), nil
+	maxLength := g.MaxKeyLength()
+	if maxLength > valueobject.MaxShortKeyLength {
+		maxLength = valueobject.MaxShortKeyLength
+	}
+
+	if len(shortKey.Value()) > maxLength {
+		return 0, fmt.Errorf("%w: %d characters, at most %d", ErrKeyTooLong, len(shortKey.Value()), maxLength)
+	}
+
+	return g.decode(strings.TrimLeft(shortKey.Value(), string(g.padChar)))
+}
+
+// pad left-pads encoded to the minimum key length.
+func (g *Generator) pad(encoded string) string {
+	if len(encoded) >= g.minLength {
+		return encoded
+	}
+
+	return strings.Repeat(string(g.padChar), g.minLength-len(encoded)) + encoded
 }
 
 // encode converts a number to Base62.
 func (g *Generator) encode(num int64) string {
 	if num == 0 {
-		return "0"
+		return g.alphabet[:1]
 	}
 
 	var result strings.Builder
 
-	base := int64(len(base62Chars))
+	base := int64(len(g.alphabet))
 
 	for num > 0 {
 		remainder := num % base
-		result.WriteByte(base62Chars[remainder])
+		result.WriteByte(g.alphabet[remainder])
 
 		num /= base
 	}
@@ -71,18 +262,25 @@ func (g *Generator) encode(num int64) string {
 	return string(runes)
 }
 
-// decode converts a Base62 string to a number.
-func (g *Generator) decode(encoded string) int64 {
+// decode converts a Base62 string to a number. Integer arithmetic keeps large
+// Snowflake IDs exact, which float powers of the base would not.
+func (g *Generator) decode(encoded string) (int64, error) {
 	var num int64
 
-	base := int64(len(base62Chars))
-	length := len(encoded)
+	base := int64(len(g.alphabet))
+
+	for _, char := range encoded {
+		index := strings.IndexRune(g.alphabet, char)
+		if index < 0 {
+			return 0, fmt.Errorf("%w: %q is not in the key alphabet", valueobject.ErrInvalidShortKey, char)
+		}
+
+		if num > (math.MaxInt64-int64(index))/base {
+			return 0, fmt.Errorf("%w: %q", ErrIDOutOfRange, encoded)
+		}
 
-	for i, char := range encoded {
-		power := length - i - 1
-		index := strings.IndexRune(base62Chars, char)
-		num += int64(index) * int64(math.Pow(float64(base), float64(power)))
+		num = num*base + int64(index)
 	}
 
-	return num
+	return num, nil
 }
@@ -0,0 +1,67 @@
+package blocklist
+
+import (
+	_ "embed"
+	"strings"
+)
+
+//go:embed words.txt
+var embeddedWords string
+
+// lookAlikes maps digits to the letters they are read as in a key.
+var lookAlikes = strings.NewReplacer("0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t")
+
+// Blocklist reports whether short keys contain blocked words. It is read-only after
+// construction and safe for concurrent use.
+type Blocklist struct {
+	words []string
+}
+
+// New creates a Blocklist of the embedded words plus extra. Extra words are trimmed
+// and lowercased; blank ones are ignored.
+func New(extra ...string) *Blocklist {
+	b := &Blocklist{}
+
+	for _, line := range strings.Split(embeddedWords, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			b.add(line)
+		}
+	}
+
+	for _, word := range extra {
+		b.add(word)
+	}
+
+	return b
+}
+
+// add appends word to the list in its normalized form.
+func (b *Blocklist) add(word string) {
+	if word = normalize(strings.TrimSpace(word)); word != "" {
+		b.words = append(b.words, word)
+	}
+}
+
+// Len returns the number of blocked words.
+func (b *Blocklist) Len() int {
+	return len(b.words)
+}
+
+// Blocks reports whether key contains a blocked word, ignoring case and reading
+// digit look-alikes as letters.
+func (b *Blocklist) Blocks(key string) bool {
+	normalized := normalize(key)
+
+	for _, word := range b.words {
+		if strings.Contains(normalized, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalize lowercases s and replaces digit look-alikes with their letters.
+func normalize(s string) string {
+	return lookAlikes.Replace(strings.ToLower(s))
+}
@@ -0,0 +1,11 @@
+// Package blocklist keeps generated short keys from spelling offensive words.
+//
+// Generated keys are effectively random strings over letters and digits, so now
+// and then one contains a word nobody wants on a printed flyer. A Blocklist holds
+// an embedded list of such words, plus any added from configuration, and reports
+// whether a key contains one of them. GeneratorService consults it after each key
+// is generated and draws a new key on a match; custom keys are not checked.
+//
+// Matching is case-insensitive and also reads common digit look-alikes as letters
+// (0 as o, 1 as i, 3 as e, 4 as a, 5 as s, 7 as t), so "5hit" is caught as well.
+package blocklist
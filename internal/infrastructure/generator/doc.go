@@ -0,0 +1,6 @@
+// Package generator assembles the configured short key generator.
+//
+// It turns the short key strategy and key profile from configuration into a
+// ready GeneratorService, validating the alphabet up front so a bad setting
+// fails at startup instead of producing keys that cannot be stored or decoded.
+package generator
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/blocklist"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+)
+
+// Short key strategies.
+const (
+	// StrategyBase62 encodes Snowflake IDs, so keys are decodable back to their ID.
+	StrategyBase62 = "base62"
+	// StrategySnowflakeBase62 is an alias of StrategyBase62 naming both halves of the scheme.
+	StrategySnowflakeBase62 = "snowflake-base62"
+	// StrategyRandom draws random keys and grows their length as the keyspace fills.
+	StrategyRandom = "random"
+)
+
+var (
+	// ErrUnknownStrategy is returned for a short key strategy other than base62 (snowflake-base62) or random.
+	ErrUnknownStrategy = errors.New("unknown short key strategy")
+	// ErrAlphabetTooSmall is returned when Base62 keys over the alphabet would not fit a short key.
+	ErrAlphabetTooSmall = errors.New("key alphabet too small for the base62 strategy")
+)
+
+// Config selects and tunes the short key generator.
+type Config struct {
+	// Short key strategy: StrategyBase62 (the default when empty, alias StrategySnowflakeBase62) or StrategyRandom
+	Strategy string
+
+	// Key profile naming a preset alphabet, or base62.ProfileCustom
+	KeyProfile string
+
+	// Alphabet used when KeyProfile is base62.ProfileCustom
+	KeyAlphabet string
+
+	// Random strategy tuning; its Alphabet is replaced by the resolved key alphabet
+	Random random.Config
+
+	// Regenerate keys containing offensive words from the embedded list or BlockedWords
+	Blocklist    bool
+	BlockedWords []string
+}
+
+// NewShortKeyGenerator validates cfg and builds the short key generator it selects.
+// The checker is only consulted by the random strategy.
+func NewShortKeyGenerator(cfg Config, checker random.ExistenceChecker) (service.ShortKeyGenerator, error) {
+	alphabet, err := base62.AlphabetForProfile(cfg.KeyProfile, cfg.KeyAlphabet)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key profile %q: %w", cfg.KeyProfile, err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(cfg.Strategy)) {
+	case "", StrategyBase62, StrategySnowflakeBase62:
+		gen, err := base62.NewGeneratorWithAlphabet(alphabet)
+		if err != nil {
+			return nil, err
+		}
+
+		// Snowflake IDs use the full int64 range, so small alphabets overflow the key column
+		if gen.MaxKeyLength() > valueobject.MaxShortKeyLength {
+			return nil, fmt.Errorf("%w: %d characters need keys of up to %d characters (max %d); use the random strategy",
+				ErrAlphabetTooSmall, len(alphabet), gen.MaxKeyLength(), valueobject.MaxShortKeyLength)
+		}
+
+		return gen, nil
+	case StrategyRandom:
+		return newRandomGenerator(cfg, alphabet, checker)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, cfg.Strategy)
+	}
+}
+
+// newRandomGenerator builds a random generator from cfg.Random over alphabet.
+func newRandomGenerator(cfg Config, alphabet string, checker random.ExistenceChecker) (*random.Generator, error) {
+	randomCfg := cfg.Random
+	randomCfg.Alphabet = alphabet
+
+	return random.NewGenerator(checker, randomCfg)
+}
+
+// NewGeneratorServiceFromConfig builds the GeneratorService for cfg, failing with a
+// descriptive error when the strategy or alphabet is misconfigured. Base62 services
+// fall back to random keys for any ID whose encoding would exceed the key length.
+// With cfg.Blocklist set, keys containing blocked words are regenerated.
+func NewGeneratorServiceFromConfig(cfg Config, idGen service.IDGenerator, checker random.ExistenceChecker) (*service.GeneratorService, error) {
+	shortKeyGen, err := NewShortKeyGenerator(cfg, checker)
+	if err != nil {
+		return nil, err
+	}
+
+	genService := service.NewGeneratorService(idGen, shortKeyGen)
+
+	if _, ok := shortKeyGen.(*base62.Generator); ok {
+		alphabet, _ := base62.AlphabetForProfile(cfg.KeyProfile, cfg.KeyAlphabet)
+
+		fallback, err := newRandomGenerator(cfg, alphabet, checker)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fallback key generator: %w", err)
+		}
+
+		genService.SetFallback(fallback)
+	}
+
+	if cfg.Blocklist {
+		genService.SetBlocklist(blocklist.New(cfg.BlockedWords...))
+	}
+
+	return genService, nil
+}
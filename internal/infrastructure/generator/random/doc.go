@@ -0,0 +1,16 @@
+// Package random provides random short key generation with collision-aware length growth.
+//
+// Unlike the Base62 generator, which derives keys from sequential Snowflake IDs,
+// this generator draws keys uniformly at random from the readability-safe alphabet.
+// Keys start at a configurable minimum length so early links stay short, and the
+// generator lengthens them automatically once the observed collision rate shows
+// that the keyspace at the current length is filling up.
+//
+// Features:
+//   - Cryptographically random keys that do not reveal creation order
+//   - Configurable minimum and maximum key length
+//   - Collision tracking over a sliding window of attempts
+//   - Automatic growth of key length when collisions exceed a threshold
+//
+// Random keys are not reversible, so DecodeToID always fails.
+package random
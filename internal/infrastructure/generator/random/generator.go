@@ -0,0 +1,215 @@
+package random
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+const keyChars = "23456789ABCDEFGHJKMNPQRSTUVWXYZabcdefghjkmnpqrstuvwxyz"
+
+// Excluded characters: 0, O, l, 1 for readability compliance
+
+var (
+	// ErrKeyspaceExhausted is returned when no free key is found within the attempt budget.
+	ErrKeyspaceExhausted = service.ErrKeyspaceExhausted
+	// ErrNotDecodable is returned by DecodeToID because random keys carry no ID.
+	ErrNotDecodable = service.ErrNotDecodable
+	// ErrInvalidConfig is returned when the generator configuration is inconsistent.
+	ErrInvalidConfig = errors.New("invalid random generator configuration")
+)
+
+// ExistenceChecker reports whether a short key is already taken.
+// repository.URLRepository satisfies this interface.
+type ExistenceChecker interface {
+	ExistsByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (bool, error)
+}
+
+// Config contains configuration for the random generator.
+type Config struct {
+	// Length of the first keys generated
+	MinLength int
+
+	// Length the generator never grows beyond (ShortKey allows up to 12)
+	MaxLength int
+
+	// Fraction of colliding attempts within a window that triggers growth
+	CollisionThreshold float64
+
+	// Number of attempts observed before the collision rate is evaluated
+	WindowSize int
+
+	// Maximum attempts per key before giving up
+	MaxAttempts int
+
+	// Pause between existence checks after a collision
+	CollisionBackoff time.Duration
+
+	// Timeout for each existence check
+	CheckTimeout time.Duration
+
+	// Characters keys are drawn from (empty uses the default alphabet)
+	Alphabet string
+}
+
+// DefaultConfig returns sensible defaults for random key generation.
+func DefaultConfig() Config {
+	return Config{
+		MinLength:          7,                      // 54^7 ≈ 1.3 trillion keys
+		MaxLength:          12,                     // Maximum short key length
+		CollisionThreshold: 0.1,                    // Grow once 10% of attempts collide
+		WindowSize:         100,                    // Evaluate every 100 attempts
+		MaxAttempts:        10,                     // Retry up to 10 times per key
+		CollisionBackoff:   0,                      // Retry immediately
+		CheckTimeout:       500 * time.Millisecond, // Bound each existence check
+		Alphabet:           keyChars,               // Digits and both cases, minus look-alikes
+	}
+}
+
+// Generator implements the ShortKeyGenerator interface using random keys.
+type Generator struct {
+	checker ExistenceChecker
+	config  Config
+
+	mu         sync.Mutex
+	length     int
+	attempts   int
+	collisions int
+}
+
+// NewGenerator creates a new random generator.
+func NewGenerator(checker ExistenceChecker, config Config) (*Generator, error) {
+	if checker == nil || config.MinLength < 1 || config.MaxLength < config.MinLength ||
+		config.MaxLength > 12 || config.WindowSize < 1 || config.MaxAttempts < 1 ||
+		(config.Alphabet != "" && len(config.Alphabet) < 2) {
+		return nil, ErrInvalidConfig
+	}
+
+	if config.Alphabet == "" {
+		config.Alphabet = keyChars
+	}
+
+	return &Generator{
+		checker: checker,
+		config:  config,
+		length:  config.MinLength,
+	}, nil
+}
+
+// Length returns the length of keys currently being generated.
+func (g *Generator) Length() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.length
+}
+
+// GenerateFromID generates an unused random short key. The ID is ignored;
+// it remains the URL's primary key but is not encoded in the short key.
+func (g *Generator) GenerateFromID(_ int64) (*valueobject.ShortKey, error) {
+	for attempt := 0; attempt < g.config.MaxAttempts; attempt++ {
+		encoded, err := randomString(g.config.Alphabet, g.Length())
+		if err != nil {
+			return nil, err
+		}
+
+		shortKey, err := valueobject.NewShortKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		exists, err := g.exists(shortKey)
+		if err != nil {
+			return nil, err
+		}
+
+		g.recordAttempt(exists)
+
+		if !exists {
+			return shortKey, nil
+		}
+
+		log.Printf("[Random] Collision on short key %s (attempt %d)", encoded, attempt+1)
+
+		if g.config.CollisionBackoff > 0 && attempt+1 < g.config.MaxAttempts {
+			time.Sleep(g.config.CollisionBackoff)
+		}
+	}
+
+	return nil, ErrKeyspaceExhausted
+}
+
+// ChecksExistence reports that every key is checked against existing URLs before it
+// is returned.
+func (g *Generator) ChecksExistence() bool {
+	return true
+}
+
+// DecodeToID is not supported for random keys.
+func (g *Generator) DecodeToID(_ *valueobject.ShortKey) (int64, error) {
+	return 0, ErrNotDecodable
+}
+
+// exists checks the key against storage with a bounded timeout.
+func (g *Generator) exists(shortKey *valueobject.ShortKey) (bool, error) {
+	ctx := context.Background()
+
+	if g.config.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, g.config.CheckTimeout)
+		defer cancel()
+	}
+
+	return g.checker.ExistsByShortKey(ctx, shortKey)
+}
+
+// recordAttempt tracks collisions and grows the key length once a full window
+// of attempts shows a collision rate above the configured threshold.
+func (g *Generator) recordAttempt(collided bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.attempts++
+	if collided {
+		g.collisions++
+	}
+
+	if g.attempts < g.config.WindowSize {
+		return
+	}
+
+	rate := float64(g.collisions) / float64(g.attempts)
+	if rate > g.config.CollisionThreshold && g.length < g.config.MaxLength {
+		g.length++
+		log.Printf("[Random] Collision rate %.2f exceeded %.2f, growing key length to %d",
+			rate, g.config.CollisionThreshold, g.length)
+	}
+
+	g.attempts = 0
+	g.collisions = 0
+}
+
+// randomString returns a uniformly random string of the given length drawn from alphabet.
+func randomString(alphabet string, length int) (string, error) {
+	buf := make([]byte, length)
+	max := big.NewInt(int64(len(alphabet)))
+
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+
+		buf[i] = alphabet[n.Int64()]
+	}
+
+	return string(buf), nil
+}
@@ -15,6 +15,7 @@
 //   - Guaranteed uniqueness across distributed instances
 //   - Time-ordered IDs for natural sorting
 //   - High throughput (up to 4096 IDs per millisecond per node)
+//   - Several node IDs per process via MultiNodeGenerator for higher rates
 //   - No network coordination required
 //   - Configurable node ID and epoch settings
 //   - Built-in clock drift protection
@@ -1,35 +1,171 @@
 package snowflake
 
 import (
+	"errors"
+	"fmt"
 	"sync"
+	"time"
+)
+
+// Bit layout of an ID, as documented in the package comment.
+const (
+	timestampBits = 41
+	nodeBits      = 10
+	sequenceBits  = 12
+	maxTimestamp  = 1<<timestampBits - 1
+	nodeMask      = 1<<nodeBits - 1
+	sequenceMask  = 1<<sequenceBits - 1
+)
+
+// MaxNodeID is the largest node ID that fits the node bits.
+const MaxNodeID = nodeMask
+
+// DefaultEpoch is the epoch NewGenerator uses: 2010-11-04T01:42:54.657Z, the epoch of
+// the original Twitter Snowflake. IDs issued before epochs became configurable use it,
+// so changing it for an existing deployment breaks the ordering of old and new IDs.
+var DefaultEpoch = time.UnixMilli(1288834974657).UTC()
 
-	"github.com/bwmarrin/snowflake"
+var (
+	// ErrInvalidID is returned when parsing a value that cannot be a Snowflake ID.
+	ErrInvalidID = errors.New("invalid snowflake ID")
+	// ErrInvalidNodeID is returned for a node ID outside 0..MaxNodeID.
+	ErrInvalidNodeID = errors.New("invalid snowflake node ID")
+	// ErrInvalidEpoch is returned for an epoch in the future.
+	ErrInvalidEpoch = errors.New("invalid snowflake epoch")
+	// ErrTimestampOverflow is returned once the time since the epoch no longer fits the timestamp bits.
+	ErrTimestampOverflow = errors.New("snowflake timestamp overflow")
+	// ErrClockMovedBackwards is returned when the clock reads earlier than it did for a
+	// previous ID; issuing IDs then could repeat ones already handed out.
+	ErrClockMovedBackwards = errors.New("clock moved backwards")
 )
 
+// Parts are the fields embedded in a Snowflake ID.
+type Parts struct {
+	Timestamp time.Time
+	NodeID    int64
+	Sequence  int64
+}
+
 // Generator implements the IDGenerator interface using Snowflake.
+//
+// Clock drift: the default clock is monotonic, so wall clock adjustments such as NTP
+// steps do not affect it. If the clock still reads earlier than for the previous ID,
+// Generate fails with ErrClockMovedBackwards instead of blocking until it catches up.
+// When the 4096 sequence numbers of a millisecond are used up, generation continues in
+// the next millisecond without waiting, so IDs can briefly run ahead of the clock.
 type Generator struct {
-	node *snowflake.Node
-	mu   sync.Mutex
+	nodeID int64
+	epoch  time.Time
+	now    func() time.Time
+
+	mu sync.Mutex
+	// Timestamp of the last ID, and the clock reading when it was issued
+	lastMillis int64
+	lastClock  int64
+	sequence   int64
 }
 
-// NewGenerator creates a new Snowflake ID generator.
+// NewGenerator creates a new Snowflake ID generator using DefaultEpoch.
 func NewGenerator(nodeID int64) (*Generator, error) {
-	node, err := snowflake.NewNode(nodeID)
-	if err != nil {
-		return nil, err
+	return NewGeneratorWithEpoch(nodeID, DefaultEpoch)
+}
+
+// NewGeneratorWithEpoch creates a Snowflake ID generator whose timestamps count from
+// epoch. A recent epoch extends the roughly 69 years the 41 timestamp bits cover;
+// every instance issuing IDs into the same table must use the same one.
+func NewGeneratorWithEpoch(nodeID int64, epoch time.Time) (*Generator, error) {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return nil, fmt.Errorf("%w: %d, must be between 0 and %d", ErrInvalidNodeID, nodeID, MaxNodeID)
 	}
 
+	now := time.Now()
+	if epoch.After(now) {
+		return nil, fmt.Errorf("%w: %s is in the future", ErrInvalidEpoch, epoch.Format(time.RFC3339))
+	}
+
+	// Anchor the epoch to the current reading so elapsed time uses the monotonic clock
 	return &Generator{
-		node: node,
+		nodeID:     nodeID,
+		epoch:      now.Add(epoch.Sub(now)),
+		now:        time.Now,
+		lastMillis: -1,
+		lastClock:  -1,
 	}, nil
 }
 
+// SetClock replaces the clock the generator reads, for tests that need to control time.
+func (g *Generator) SetClock(now func() time.Time) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.now = now
+}
+
 // Generate generates a unique Snowflake ID.
 func (g *Generator) Generate() (int64, error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	id := g.node.Generate()
+	clock := g.millisSinceEpoch()
+	if clock < g.lastClock {
+		return 0, fmt.Errorf("%w: by %dms", ErrClockMovedBackwards, g.lastClock-clock)
+	}
+
+	// Stay on a millisecond borrowed after a sequence overflow until the clock reaches it
+	millis := clock
+	if millis < g.lastMillis {
+		millis = g.lastMillis
+	}
+
+	if millis == g.lastMillis {
+		g.sequence = (g.sequence + 1) & sequenceMask
+
+		// Sequence exhausted for this millisecond: roll into the next one
+		if g.sequence == 0 {
+			millis++
+		}
+	} else {
+		g.sequence = 0
+	}
+
+	if millis > maxTimestamp {
+		return 0, fmt.Errorf("%w: epoch %s is too far in the past", ErrTimestampOverflow, g.Epoch().Format(time.RFC3339))
+	}
+
+	g.lastMillis = millis
+	g.lastClock = clock
 
-	return id.Int64(), nil
+	return millis<<(nodeBits+sequenceBits) | g.nodeID<<sequenceBits | g.sequence, nil
+}
+
+// millisSinceEpoch returns the milliseconds elapsed since the generator's epoch.
+func (g *Generator) millisSinceEpoch() int64 {
+	return g.now().Sub(g.epoch).Milliseconds()
+}
+
+// Epoch returns the instant this generator's timestamps count from.
+func (g *Generator) Epoch() time.Time {
+	return time.UnixMilli(g.epoch.UnixMilli()).UTC()
+}
+
+// NodeID returns the node ID embedded in the IDs this generator produces.
+func (g *Generator) NodeID() int64 {
+	return g.nodeID
+}
+
+// Parse splits id into its timestamp, node ID and sequence number. The timestamp is
+// relative to this generator's epoch, so IDs from a generator with another epoch
+// parse to the wrong time.
+func (g *Generator) Parse(id int64) (Parts, error) {
+	if id < 0 {
+		return Parts{}, fmt.Errorf("%w: %d is negative", ErrInvalidID, id)
+	}
+
+	millis := id >> (nodeBits + sequenceBits)
+
+	return Parts{
+		Timestamp: g.Epoch().Add(time.Duration(millis) * time.Millisecond),
+		NodeID:    (id >> sequenceBits) & nodeMask,
+		Sequence:  id & sequenceMask,
+	}, nil
 }
@@ -0,0 +1,17 @@
+// Package stateless provides self-contained short tokens that carry their destination.
+//
+// Instead of storing a mapping and handing out a key that points at it, this
+// generator compresses the long URL into the token itself and signs it, so a
+// stateless or serverless deployment can redirect without a database lookup.
+// Tokens are base64url encoded as HMAC-SHA256(compressed) truncated to
+// SignatureSize bytes, followed by the DEFLATE-compressed destination.
+//
+// Features:
+//   - Redirects resolve without storage or cache
+//   - Tampered or forged tokens are rejected by constant-time signature checks
+//   - Destination length is capped so tokens stay reasonably short
+//   - Decompression is bounded, so crafted tokens cannot inflate without limit
+//
+// Tokens are longer than ShortKey allows and cannot be revoked, counted or
+// expired individually; they are not a replacement for stored short keys.
+package stateless
@@ -0,0 +1,135 @@
+package stateless
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+const (
+	// SignatureSize is the number of HMAC-SHA256 bytes kept in each token.
+	SignatureSize = 16
+
+	// MinSecretLength is the shortest signing secret accepted.
+	MinSecretLength = 32
+
+	// DefaultMaxURLLength is the longest destination encoded when Config leaves it unset.
+	DefaultMaxURLLength = 512
+)
+
+var (
+	// ErrSecretTooShort is returned when the signing secret is shorter than MinSecretLength.
+	ErrSecretTooShort = fmt.Errorf("signing secret must be at least %d bytes", MinSecretLength)
+	// ErrDestinationTooLong is returned when a long URL exceeds the configured maximum length.
+	ErrDestinationTooLong = errors.New("destination URL is too long for a stateless token")
+	// ErrInvalidToken is returned when a token is not well-formed.
+	ErrInvalidToken = errors.New("invalid stateless token")
+	// ErrInvalidSignature is returned when a token's signature does not match its payload.
+	ErrInvalidSignature = errors.New("stateless token signature mismatch")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Config contains configuration for the stateless generator.
+type Config struct {
+	// Secret used to sign tokens; every instance resolving the tokens needs the same one
+	Secret []byte
+
+	// Longest destination accepted, in bytes (zero uses DefaultMaxURLLength)
+	MaxURLLength int
+}
+
+// Generator encodes destinations into signed tokens and resolves them back.
+type Generator struct {
+	secret       []byte
+	maxURLLength int
+}
+
+// NewGenerator creates a stateless generator.
+func NewGenerator(cfg Config) (*Generator, error) {
+	if len(cfg.Secret) < MinSecretLength {
+		return nil, ErrSecretTooShort
+	}
+
+	maxURLLength := cfg.MaxURLLength
+	if maxURLLength <= 0 {
+		maxURLLength = DefaultMaxURLLength
+	}
+
+	return &Generator{
+		secret:       append([]byte(nil), cfg.Secret...),
+		maxURLLength: maxURLLength,
+	}, nil
+}
+
+// Generate returns the signed token carrying longURL.
+func (g *Generator) Generate(longURL *valueobject.LongURL) (string, error) {
+	destination := longURL.Value()
+	if len(destination) > g.maxURLLength {
+		return "", ErrDestinationTooLong
+	}
+
+	var compressed bytes.Buffer
+
+	writer, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := writer.Write([]byte(destination)); err != nil {
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	token := append(g.sign(compressed.Bytes()), compressed.Bytes()...)
+
+	return encoding.EncodeToString(token), nil
+}
+
+// Resolve verifies token and returns the destination it carries.
+func (g *Generator) Resolve(token string) (*valueobject.LongURL, error) {
+	raw, err := encoding.DecodeString(token)
+	if err != nil || len(raw) <= SignatureSize {
+		return nil, ErrInvalidToken
+	}
+
+	signature, payload := raw[:SignatureSize], raw[SignatureSize:]
+	if !hmac.Equal(signature, g.sign(payload)) {
+		return nil, ErrInvalidSignature
+	}
+
+	// Read one byte past the limit to detect destinations that are too long
+	reader := flate.NewReader(bytes.NewReader(payload))
+	defer func() {
+		_ = reader.Close() // Ignore error on deferred close
+	}()
+
+	destination, err := io.ReadAll(io.LimitReader(reader, int64(g.maxURLLength)+1))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if len(destination) > g.maxURLLength {
+		return nil, ErrDestinationTooLong
+	}
+
+	return valueobject.NewLongURL(string(destination))
+}
+
+// sign returns the truncated HMAC-SHA256 of payload.
+func (g *Generator) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, g.secret)
+	_, _ = mac.Write(payload) // hash.Hash writes never fail
+
+	return mac.Sum(nil)[:SignatureSize]
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+// RegisterCleanupMetrics exports the cleanup service's statistics on reg. The values
+// are read from stats at scrape time, so nothing has to be updated as cleanup runs.
+func RegisterCleanupMetrics(reg prometheus.Registerer, stats func() *service.CleanupStats) error {
+	collectors := []prometheus.Collector{
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   Namespace,
+			Name:        "cleanup_runs_total",
+			Help:        "Expired URL cleanup runs, by result.",
+			ConstLabels: prometheus.Labels{"result": "success"},
+		}, func() float64 { return float64(stats().SuccessfulRuns) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace:   Namespace,
+			Name:        "cleanup_runs_total",
+			Help:        "Expired URL cleanup runs, by result.",
+			ConstLabels: prometheus.Labels{"result": "failure"},
+		}, func() float64 { return float64(stats().FailedRuns) }),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cleanup_records_total",
+			Help:      "Expired URLs removed by cleanup.",
+		}, func() float64 { return float64(stats().TotalCleaned) }),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "cleanup_last_run_timestamp_seconds",
+			Help:      "Unix time of the last cleanup run, or 0 before the first.",
+		}, func() float64 {
+			last := stats().LastCleanupTime
+			if last.IsZero() {
+				return 0
+			}
+
+			return float64(last.Unix())
+		}),
+	}
+
+	for _, collector := range collectors {
+		if err := reg.Register(collector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
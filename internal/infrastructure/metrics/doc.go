@@ -0,0 +1,17 @@
+// Package metrics provides Prometheus instrumentation for the URL shortener service.
+//
+// Collectors are registered on a caller-supplied registerer so tests can use an
+// isolated registry; the service registers them on the default registry, which is
+// exposed at GET /metrics.
+//
+// Available metrics:
+//   - redirect_duration_seconds: redirect latency histogram, labelled by cache status
+//   - redirects_total: redirect counter, labelled by cache status
+//   - http_requests_total, http_request_duration_seconds: every HTTP request, labelled by
+//     method and route template (and status for the counter)
+//   - shorten_requests_total: shorten requests, labelled by outcome
+//   - cache_lookups_total: short key lookups, labelled by cache status
+//   - rate_limited_requests_total: requests denied by the rate limiter, labelled by route group
+//   - cleanup_runs_total, cleanup_records_total, cleanup_last_run_timestamp_seconds:
+//     expired URL cleanup activity, read from the cleanup service's stats
+package metrics
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RateLimitMetrics counts requests denied by the rate limiter, labelled by route group.
+// Visitors are left out of the labels so each client IP is not its own series; the
+// in-memory limiter's throttle report names the most throttled ones.
+type RateLimitMetrics struct {
+	throttled *prometheus.CounterVec
+}
+
+// NewRateLimitMetrics creates the rate limit collector and registers it on reg.
+func NewRateLimitMetrics(reg prometheus.Registerer) (*RateLimitMetrics, error) {
+	m := &RateLimitMetrics{
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rate_limited_requests_total",
+			Help:      "Requests denied by the rate limiter, by route group.",
+		}, []string{"group"}),
+	}
+
+	if err := reg.Register(m.throttled); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// ObserveThrottle counts one denied request in group. The unnamed group, used by
+// routes without a group of their own, is recorded as "default".
+func (m *RateLimitMetrics) ObserveThrottle(group string) {
+	if group == "" {
+		group = "default"
+	}
+
+	m.throttled.WithLabelValues(group).Inc()
+}
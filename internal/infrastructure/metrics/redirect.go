@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace prefixes every metric the service exports.
+const Namespace = "url_shortener"
+
+// RedirectLatencySLO is the p99 redirect latency target the histogram buckets are tuned for.
+const RedirectLatencySLO = 20 * time.Millisecond
+
+// RedirectLatencyBuckets are the upper bounds, in seconds, of the redirect latency
+// histogram. They are dense below RedirectLatencySLO so the p99 can be read off
+// accurately, and coarse above it where only the size of a breach matters.
+var RedirectLatencyBuckets = []float64{
+	0.001, 0.0025, 0.005, 0.0075, 0.01, 0.015, 0.02, // up to the SLO
+	0.05, 0.1, 0.25, 0.5, 1,
+}
+
+// RedirectMetrics records the latency and count of redirects. Both are labelled with
+// the cache status of the lookup (hit, miss or tombstone) so latency can be attributed
+// to the cache or the database.
+type RedirectMetrics struct {
+	latency *prometheus.HistogramVec
+	total   *prometheus.CounterVec
+}
+
+// NewRedirectMetrics creates the redirect collectors and registers them on reg.
+func NewRedirectMetrics(reg prometheus.Registerer) (*RedirectMetrics, error) {
+	m := &RedirectMetrics{
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "redirect_duration_seconds",
+			Help:      "Time taken to resolve and answer a redirect request.",
+			Buckets:   RedirectLatencyBuckets,
+		}, []string{"cache"}),
+		total: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "redirects_total",
+			Help:      "Redirect requests served, by cache status.",
+		}, []string{"cache"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.latency, m.total} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveRedirect records one redirect that took elapsed. An empty cacheStatus, for
+// requests rejected before the cache was consulted, is recorded as "none".
+func (m *RedirectMetrics) ObserveRedirect(cacheStatus string, elapsed time.Duration) {
+	label := strings.ToLower(cacheStatus)
+	if label == "" {
+		label = "none"
+	}
+
+	m.latency.WithLabelValues(label).Observe(elapsed.Seconds())
+	m.total.WithLabelValues(label).Inc()
+}
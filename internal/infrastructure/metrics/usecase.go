@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UseCaseMetrics counts shorten requests by outcome and cache lookups by status. Its
+// cache counter gives the hit ratio as hit / (hit + miss + tombstone).
+type UseCaseMetrics struct {
+	shortens     *prometheus.CounterVec
+	cacheLookups *prometheus.CounterVec
+}
+
+// NewUseCaseMetrics creates the use case collectors and registers them on reg.
+func NewUseCaseMetrics(reg prometheus.Registerer) (*UseCaseMetrics, error) {
+	m := &UseCaseMetrics{
+		shortens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "shorten_requests_total",
+			Help:      "URLs submitted for shortening, by outcome (created, reused or failed).",
+		}, []string{"outcome"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "cache_lookups_total",
+			Help:      "Short key lookups, by cache status (hit, miss or tombstone).",
+		}, []string{"cache"}),
+	}
+
+	for _, collector := range []prometheus.Collector{m.shortens, m.cacheLookups} {
+		if err := reg.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// ObserveShorten counts one shorten request with the given outcome.
+func (m *UseCaseMetrics) ObserveShorten(outcome string) {
+	m.shortens.WithLabelValues(outcome).Inc()
+}
+
+// ObserveCacheLookup counts one lookup with the given cache status.
+func (m *UseCaseMetrics) ObserveCacheLookup(status string) {
+	m.cacheLookups.WithLabelValues(strings.ToLower(status)).Inc()
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+)
+
+// AnalyticsHandler handles click analytics HTTP requests.
+type AnalyticsHandler struct {
+	useCase *usecase.AnalyticsUseCase
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler.
+func NewAnalyticsHandler(useCase *usecase.AnalyticsUseCase) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		useCase: useCase,
+	}
+}
+
+// GetFullStats handles GET /api/stats/:shortKey/full requests.
+// The timeline range is selected with from/to (YYYY-MM-DD, inclusive) or days (ending today),
+// defaulting to the last 30 days; referrers sets how many top referrers to return.
+func (h *AnalyticsHandler) GetFullStats(c *gin.Context) {
+	query, err := parseFullStatsQuery(c, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	stats, err := h.useCase.GetFullStats(c.Request.Context(), c.Param("shortKey"), query)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetTopURLs handles GET /api/admin/analytics/top requests, returning the most-visited
+// URLs. limit sets the leaderboard size; since (RFC 3339 or YYYY-MM-DD) keeps only URLs
+// created at or after it.
+func (h *AnalyticsHandler) GetTopURLs(c *gin.Context) {
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+
+	var since *time.Time
+
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := parseSince(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "since must be an RFC 3339 timestamp or a YYYY-MM-DD date",
+			})
+
+			return
+		}
+
+		since = &parsed
+	}
+
+	resp, err := h.useCase.TopURLs(c.Request.Context(), limit, since)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseSince parses an RFC 3339 timestamp or a YYYY-MM-DD date (midnight UTC).
+func parseSince(raw string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return parsed, nil
+	}
+
+	return time.Parse("2006-01-02", raw)
+}
+
+// GetTimeseries handles GET /api/analytics/:shortKey/timeseries requests, returning
+// daily click counts. The range is selected as for GetFullStats.
+func (h *AnalyticsHandler) GetTimeseries(c *gin.Context) {
+	from, to, err := parseTimelineRange(c, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	timeseries, err := h.useCase.GetTimeseries(c.Request.Context(), c.Param("shortKey"), from, to)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, timeseries)
+}
+
+// parseFullStatsQuery reads the timeline range and referrer limit from the query string.
+func parseFullStatsQuery(c *gin.Context, now time.Time) (usecase.FullStatsQuery, error) {
+	var (
+		query usecase.FullStatsQuery
+		err   error
+	)
+
+	query.From, query.To, err = parseTimelineRange(c, now)
+	if err != nil {
+		return query, err
+	}
+
+	if referrers := c.Query("referrers"); referrers != "" {
+		n, err := strconv.Atoi(referrers)
+		if err != nil {
+			return query, err
+		}
+
+		query.TopReferrers = n
+	}
+
+	return query, nil
+}
+
+// parseTimelineRange reads a timeline range from from/to (YYYY-MM-DD, inclusive) or days
+// (ending today), defaulting to the last usecase.DefaultTimelineDays days.
+func parseTimelineRange(c *gin.Context, now time.Time) (time.Time, time.Time, error) {
+	from, to := now.AddDate(0, 0, -(usecase.DefaultTimelineDays-1)), now
+
+	if days := c.Query("days"); days != "" {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return from, to, usecase.ErrInvalidTimeRange
+		}
+
+		from = now.AddDate(0, 0, -(n - 1))
+	}
+
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return from, to, usecase.ErrInvalidTimeRange
+		}
+
+		from = parsed
+	}
+
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			return from, to, usecase.ErrInvalidTimeRange
+		}
+
+		to = parsed
+	}
+
+	return from, to, nil
+}
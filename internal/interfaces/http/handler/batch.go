@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// Batch endpoint names used to look up their item limits.
+const (
+	BatchShorten = "shorten"
+	BatchResolve = "resolve"
+	BatchStats   = "stats"
+)
+
+// DefaultBatchMaxItems is the item limit for batch endpoints without a configured one.
+const DefaultBatchMaxItems = 100
+
+// BatchLimits caps how many items each batch endpoint accepts in one request.
+type BatchLimits struct {
+	// Limit for endpoints missing from Endpoints; DefaultBatchMaxItems when not positive
+	Default int
+	// Per-endpoint limits keyed by BatchShorten, BatchResolve or BatchStats
+	Endpoints map[string]int
+}
+
+// For returns the item limit for endpoint.
+func (l BatchLimits) For(endpoint string) int {
+	if limit, ok := l.Endpoints[endpoint]; ok && limit > 0 {
+		return limit
+	}
+
+	if l.Default > 0 {
+		return l.Default
+	}
+
+	return DefaultBatchMaxItems
+}
+
+// SetBatchLimits sets the item limits for the batch endpoints.
+func (h *URLHandler) SetBatchLimits(limits BatchLimits) {
+	h.batchLimits = limits
+}
+
+// bindBatch reads a JSON array request body for endpoint. Items are decoded one at a
+// time, so an array over the endpoint's limit is rejected with 422 as soon as its first
+// extra item starts, without reading the rest of the body. It writes the error response
+// itself and reports whether the handler should continue.
+func (h *URLHandler) bindBatch(c *gin.Context, endpoint string) ([]json.RawMessage, bool) {
+	invalid := func(err error) ([]json.RawMessage, bool) {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "request body must be a JSON array: " + err.Error(),
+		})
+
+		return nil, false
+	}
+
+	dec := json.NewDecoder(c.Request.Body)
+
+	token, err := dec.Token()
+	if err != nil {
+		return invalid(err)
+	}
+
+	if token != json.Delim('[') {
+		return invalid(fmt.Errorf("unexpected %v", token))
+	}
+
+	limit := h.batchLimits.For(endpoint)
+	items := []json.RawMessage{}
+
+	for dec.More() {
+		if len(items) == limit {
+			c.JSON(http.StatusUnprocessableEntity, dto.ErrorResponse{
+				Error:   "too_many_items",
+				Message: fmt.Sprintf("batch %s accepts at most %d items", endpoint, limit),
+			})
+
+			return nil, false
+		}
+
+		var item json.RawMessage
+		if err := dec.Decode(&item); err != nil {
+			return invalid(err)
+		}
+
+		items = append(items, item)
+	}
+
+	// Consume the closing bracket so a truncated array is rejected
+	if _, err := dec.Token(); err != nil {
+		return invalid(err)
+	}
+
+	return items, true
+}
+
+// ShortenURLBatch handles POST /api/shorten/batch requests. Each item succeeds or fails
+// on its own and is reported at its index, so one bad URL does not fail the batch.
+func (h *URLHandler) ShortenURLBatch(c *gin.Context) {
+	items, ok := h.bindBatch(c, BatchShorten)
+	if !ok {
+		return
+	}
+
+	isAdmin := middleware.IsAdmin(c)
+	results := make([]dto.BatchShortenResult, len(items))
+
+	// Items that decode are shortened together; indexes maps them back to their position
+	reqs := make([]*dto.ShortenURLRequest, 0, len(items))
+	indexes := make([]int, 0, len(items))
+
+	for i, item := range items {
+		results[i].Index = i
+
+		var req dto.ShortenURLRequest
+		if err := json.Unmarshal(item, &req); err != nil {
+			results[i].Error = &dto.ErrorResponse{Error: "invalid_request", Message: err.Error()}
+			continue
+		}
+
+		if req.ID != 0 && !isAdmin {
+			log.Printf("[ShortenURLBatch] Ignoring id from non-admin caller %s", c.ClientIP())
+
+			req.ID = 0
+		}
+
+		reqs = append(reqs, &req)
+		indexes = append(indexes, i)
+	}
+
+	responses, errs := h.useCase.ShortenBatch(c.Request.Context(), reqs)
+
+	for j, index := range indexes {
+		if errs[j] != nil {
+			_, errResp := buildErrorResponse(errs[j])
+			results[index].Error = &errResp
+
+			continue
+		}
+
+		results[index].Result = responses[j]
+	}
+
+	c.JSON(http.StatusOK, dto.BatchShortenResponse{Results: results})
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"embed"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+//go:embed templates/error.html
+var errorPageFS embed.FS
+
+// errorPageTemplate is the embedded template used for browser-facing error pages.
+var errorPageTemplate = template.Must(template.ParseFS(errorPageFS, "templates/error.html"))
+
+// errorPageData is the data passed to the error page template.
+type errorPageData struct {
+	Lang       string
+	StatusCode int
+	errorPageMessages
+}
+
+// wantsHTML reports whether the client is a browser expecting an HTML response.
+func wantsHTML(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "text/html")
+}
+
+// respondRedirectError writes an error for the redirect route, rendering a translated
+// HTML page for browsers and a JSON body for API clients.
+func respondRedirectError(c *gin.Context, statusCode int, resp dto.ErrorResponse) {
+	if !wantsHTML(c) {
+		c.JSON(statusCode, resp)
+
+		return
+	}
+
+	lang := negotiateLanguage(c.GetHeader("Accept-Language"))
+	data := errorPageData{
+		Lang:              lang,
+		StatusCode:        statusCode,
+		errorPageMessages: lookupErrorMessages(lang, resp.Error),
+	}
+
+	var buf bytes.Buffer
+	if err := errorPageTemplate.Execute(&buf, data); err != nil {
+		log.Printf("[ErrorPage] Failed to render error page: %v", err)
+		c.JSON(statusCode, resp)
+
+		return
+	}
+
+	c.Header("Content-Language", lang)
+	c.Header("Vary", "Accept, Accept-Language")
+	c.Data(statusCode, "text/html; charset=utf-8", buf.Bytes())
+}
+
+// Throttle responses for the redirect route, selected by app.redirectthrottleresponse.
+const (
+	// ThrottleResponseJSON always answers throttled redirects with a 429 JSON body.
+	ThrottleResponseJSON = "json"
+	// ThrottleResponseHTML serves browsers a translated 429 "slow down" page.
+	ThrottleResponseHTML = "html"
+	// ThrottleResponseUnavailable serves browsers a translated 503 page.
+	ThrottleResponseUnavailable = "unavailable"
+)
+
+// RedirectThrottleResponder returns the handler that writes rate-limited responses on the
+// redirect route. Browsers get an HTML page so a throttled click does not show raw JSON;
+// API clients always get the 429 JSON body.
+func RedirectThrottleResponder(mode string) gin.HandlerFunc {
+	statusCode := http.StatusTooManyRequests
+	if mode == ThrottleResponseUnavailable {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	return func(c *gin.Context) {
+		if mode == ThrottleResponseJSON || !wantsHTML(c) {
+			c.JSON(http.StatusTooManyRequests, dto.ErrorResponse{
+				Error:   "rate_limit_exceeded",
+				Message: "Too many requests. Please try again later.",
+			})
+
+			return
+		}
+
+		respondRedirectError(c, statusCode, dto.ErrorResponse{
+			Error:   "rate_limit_exceeded",
+			Message: "Too many requests. Please try again later.",
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/apperror"
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// resolveError returns the HTTP status and error code used to report err.
+// Application errors carry their own; input validation errors from the domain are
+// reported as bad requests, and anything else is an internal error.
+func resolveError(err error) (int, string) {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr.HTTPStatus, appErr.Code
+	}
+
+	switch {
+	case errors.Is(err, valueobject.ErrInvalidURL),
+		errors.Is(err, valueobject.ErrEmptyURL),
+		errors.Is(err, valueobject.ErrURLTooLong),
+		errors.Is(err, valueobject.ErrInvalidHost),
+		errors.Is(err, valueobject.ErrCredentialsInURL),
+		errors.Is(err, valueobject.ErrInvalidShortKey),
+		errors.Is(err, valueobject.ErrEmptyShortKey):
+		return http.StatusBadRequest, "invalid_request"
+	case errors.Is(err, valueobject.ErrDisallowedScheme):
+		return http.StatusBadRequest, "disallowed_scheme"
+	case errors.Is(err, valueobject.ErrReservedShortKey):
+		return http.StatusBadRequest, "reserved_short_key"
+	}
+
+	return http.StatusInternalServerError, "internal_error"
+}
+
+// RespondError writes err as a JSON error response with the status resolved by resolveError.
+// Server errors are also attached to the context so they reach the error logger.
+func RespondError(c *gin.Context, err error) {
+	statusCode, resp := buildErrorResponse(err)
+	if statusCode >= http.StatusInternalServerError {
+		_ = c.Error(err)
+	}
+
+	c.JSON(statusCode, resp)
+}
+
+// buildErrorResponse returns the status and body RespondError would write for err.
+func buildErrorResponse(err error) (int, dto.ErrorResponse) {
+	statusCode, errorCode := resolveError(err)
+
+	resp := dto.ErrorResponse{
+		Error:   errorCode,
+		Message: err.Error(),
+	}
+
+	var conflict *usecase.CustomKeyConflictError
+	if errors.As(err, &conflict) {
+		resp.Suggestions = conflict.Suggestions
+	}
+
+	var disabled *usecase.URLDisabledError
+	if errors.As(err, &disabled) {
+		resp.Reason = disabled.Reason
+	}
+
+	return statusCode, resp
+}
+
+// MethodNotAllowed returns a handler that rejects a request with 405 Method Not Allowed,
+// advertising the allowed methods in the Allow header.
+func MethodNotAllowed(allowed []string) gin.HandlerFunc {
+	allow := strings.Join(allowed, ", ")
+
+	return func(c *gin.Context) {
+		c.Header("Allow", allow)
+		c.AbortWithStatusJSON(http.StatusMethodNotAllowed, dto.ErrorResponse{
+			Error:   "method_not_allowed",
+			Message: c.Request.Method + " is not supported on this path; allowed: " + allow,
+		})
+	}
+}
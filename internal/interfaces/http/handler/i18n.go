@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// defaultLanguage is used when none of the languages requested by the client are supported.
+const defaultLanguage = "en"
+
+// genericErrorCode is the catalog entry shown for error codes that have no page of their own.
+const genericErrorCode = "error"
+
+// errorPageMessages holds the translated strings shown on the browser-facing error pages.
+type errorPageMessages struct {
+	Title    string
+	Heading  string
+	Message  string
+	HomeLink string
+}
+
+// errorPageCatalog maps a language tag and error code to its translated error page strings.
+var errorPageCatalog = map[string]map[string]errorPageMessages{
+	"en": {
+		"not_found": {
+			Title:    "Link not found",
+			Heading:  "This short link does not exist",
+			Message:  "Please check the address and try again.",
+			HomeLink: "Create a new short link",
+		},
+		"url_expired": {
+			Title:    "Link expired",
+			Heading:  "This short link has expired",
+			Message:  "The link you followed is no longer available.",
+			HomeLink: "Create a new short link",
+		},
+		"url_deleted": {
+			Title:    "Link removed",
+			Heading:  "This short link has been removed",
+			Message:  "The link you followed existed but is no longer available.",
+			HomeLink: "Create a new short link",
+		},
+		"url_disabled": {
+			Title:    "Link paused",
+			Heading:  "This short link is temporarily disabled",
+			Message:  "The owner has paused this link. Please try again later.",
+			HomeLink: "Create a new short link",
+		},
+		"rate_limit_exceeded": {
+			Title:    "Slow down",
+			Heading:  "Too many requests",
+			Message:  "You are opening links too quickly. Please wait a moment and try again.",
+			HomeLink: "Create a new short link",
+		},
+		genericErrorCode: {
+			Title:    "Something went wrong",
+			Heading:  "We could not open this short link",
+			Message:  "Something went wrong on our side. Please try again later.",
+			HomeLink: "Create a new short link",
+		},
+	},
+	"es": {
+		"not_found": {
+			Title:    "Enlace no encontrado",
+			Heading:  "Este enlace corto no existe",
+			Message:  "Por favor, revisa la dirección e inténtalo de nuevo.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+		"url_expired": {
+			Title:    "Enlace caducado",
+			Heading:  "Este enlace corto ha caducado",
+			Message:  "El enlace que has seguido ya no está disponible.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+		"url_deleted": {
+			Title:    "Enlace eliminado",
+			Heading:  "Este enlace corto ha sido eliminado",
+			Message:  "El enlace que has seguido existía, pero ya no está disponible.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+		"url_disabled": {
+			Title:    "Enlace pausado",
+			Heading:  "Este enlace corto está desactivado temporalmente",
+			Message:  "El propietario ha pausado este enlace. Inténtalo de nuevo más tarde.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+		"rate_limit_exceeded": {
+			Title:    "Más despacio",
+			Heading:  "Demasiadas solicitudes",
+			Message:  "Estás abriendo enlaces demasiado rápido. Espera un momento e inténtalo de nuevo.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+		genericErrorCode: {
+			Title:    "Algo salió mal",
+			Heading:  "No pudimos abrir este enlace corto",
+			Message:  "Algo salió mal por nuestra parte. Inténtalo de nuevo más tarde.",
+			HomeLink: "Crear un nuevo enlace corto",
+		},
+	},
+	"fr": {
+		"not_found": {
+			Title:    "Lien introuvable",
+			Heading:  "Ce lien court n'existe pas",
+			Message:  "Veuillez vérifier l'adresse et réessayer.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+		"url_expired": {
+			Title:    "Lien expiré",
+			Heading:  "Ce lien court a expiré",
+			Message:  "Le lien que vous avez suivi n'est plus disponible.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+		"url_deleted": {
+			Title:    "Lien supprimé",
+			Heading:  "Ce lien court a été supprimé",
+			Message:  "Le lien que vous avez suivi existait mais n'est plus disponible.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+		"url_disabled": {
+			Title:    "Lien suspendu",
+			Heading:  "Ce lien court est temporairement désactivé",
+			Message:  "Le propriétaire a suspendu ce lien. Veuillez réessayer plus tard.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+		"rate_limit_exceeded": {
+			Title:    "Ralentissez",
+			Heading:  "Trop de requêtes",
+			Message:  "Vous ouvrez des liens trop rapidement. Veuillez patienter un instant et réessayer.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+		genericErrorCode: {
+			Title:    "Une erreur est survenue",
+			Heading:  "Impossible d'ouvrir ce lien court",
+			Message:  "Une erreur est survenue de notre côté. Veuillez réessayer plus tard.",
+			HomeLink: "Créer un nouveau lien court",
+		},
+	},
+}
+
+// languagePreference is a single entry of an Accept-Language header.
+type languagePreference struct {
+	tag     string
+	quality float64
+}
+
+// negotiateLanguage picks the best supported language from an Accept-Language header,
+// falling back to English when nothing matches.
+func negotiateLanguage(acceptLanguage string) string {
+	var prefs []languagePreference
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		tag, params, _ := strings.Cut(part, ";")
+		pref := languagePreference{tag: strings.ToLower(strings.TrimSpace(tag)), quality: 1}
+
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if quality, err := strconv.ParseFloat(q, 64); err == nil {
+				pref.quality = quality
+			}
+		}
+
+		prefs = append(prefs, pref)
+	}
+
+	// Stable sort keeps the client's ordering for equal quality values
+	sort.SliceStable(prefs, func(i, j int) bool {
+		return prefs[i].quality > prefs[j].quality
+	})
+
+	for _, pref := range prefs {
+		if pref.quality <= 0 {
+			continue
+		}
+
+		// Match on the primary subtag so "fr-CA" resolves to "fr"
+		primary, _, _ := strings.Cut(pref.tag, "-")
+		if _, ok := errorPageCatalog[primary]; ok {
+			return primary
+		}
+	}
+
+	return defaultLanguage
+}
+
+// lookupErrorMessages returns the translated strings for an error code, falling back to
+// English and then to the generic error page for codes the catalog does not cover.
+func lookupErrorMessages(lang, errorCode string) errorPageMessages {
+	if messages, ok := errorPageCatalog[lang][errorCode]; ok {
+		return messages
+	}
+
+	if messages, ok := errorPageCatalog[defaultLanguage][errorCode]; ok {
+		return messages
+	}
+
+	return errorPageCatalog[lang][genericErrorCode]
+}
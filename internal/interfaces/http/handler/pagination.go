@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+// CursorQueryParam is the query parameter list endpoints read the page cursor from.
+const CursorQueryParam = "cursor"
+
+// RespondWithPage writes a paginated list response.
+// When more pages exist it also emits an RFC 5988 Link header pointing at the next page,
+// preserving the request's other query parameters.
+func RespondWithPage[T any](c *gin.Context, page *dto.PageResponse[T]) {
+	if page.HasMore && page.NextCursor != "" {
+		next := *c.Request.URL
+		query := next.Query()
+		query.Set(CursorQueryParam, page.NextCursor)
+		next.RawQuery = query.Encode()
+
+		c.Header("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+	}
+
+	c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/url"
+)
+
+// passthroughExcluded are query parameters that configure the redirect route itself
+// and are not forwarded to the destination.
+var passthroughExcluded = map[string]bool{
+	FormatParam:     true,
+	CountVisitParam: true,
+}
+
+// PassthroughURL merges the query string and fragment of an incoming short URL request
+// onto the destination longURL, so tracking parameters such as utm_source survive the
+// redirect. Parameters the destination already sets keep its values, and a fragment is
+// only added when the destination has none. The destination's own query is kept byte
+// for byte; longURL is returned unchanged when nothing is added or it cannot be parsed.
+func PassthroughURL(longURL string, incoming *url.URL) string {
+	if incoming == nil || (incoming.RawQuery == "" && incoming.Fragment == "") {
+		return longURL
+	}
+
+	dest, err := url.Parse(longURL)
+	if err != nil {
+		return longURL
+	}
+
+	params, err := url.ParseQuery(incoming.RawQuery)
+	if err != nil {
+		return longURL
+	}
+
+	existing := dest.Query()
+
+	for key := range params {
+		if passthroughExcluded[key] || existing.Has(key) {
+			params.Del(key)
+		}
+	}
+
+	if extra := params.Encode(); extra != "" {
+		if dest.RawQuery != "" {
+			dest.RawQuery += "&"
+		}
+
+		dest.RawQuery += extra
+	}
+
+	if dest.Fragment == "" && incoming.Fragment != "" {
+		dest.Fragment = incoming.Fragment
+		dest.RawFragment = incoming.RawFragment
+	}
+
+	return dest.String()
+}
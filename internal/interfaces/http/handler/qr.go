@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/skip2/go-qrcode"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+// QR code image sizes in pixels, selected with the size query parameter.
+const (
+	DefaultQRSize = 256
+	MinQRSize     = 64
+	MaxQRSize     = 1024
+)
+
+// GenerateQRCode encodes content as a square PNG QR code of size pixels, using
+// medium error correction so a printed code survives light damage.
+func GenerateQRCode(content string, size int) ([]byte, error) {
+	return qrcode.Encode(content, qrcode.Medium, size)
+}
+
+// clampQRSize keeps a requested QR code size within MinQRSize..MaxQRSize.
+func clampQRSize(size int) int {
+	switch {
+	case size < MinQRSize:
+		return MinQRSize
+	case size > MaxQRSize:
+		return MaxQRSize
+	default:
+		return size
+	}
+}
+
+// GetQRCode handles GET /api/urls/:shortKey/qr requests. It returns a PNG QR code
+// of the full short URL, sized by the optional size query parameter (clamped to
+// MinQRSize..MaxQRSize). Missing keys get 404 and expired ones 410.
+func (h *URLHandler) GetQRCode(c *gin.Context) {
+	size := DefaultQRSize
+
+	if raw := c.Query("size"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "size must be an integer",
+			})
+
+			return
+		}
+
+		size = clampQRSize(parsed)
+	}
+
+	shortURL, err := h.useCase.GetShortURL(c.Request.Context(), c.Param("shortKey"))
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	png, err := GenerateQRCode(shortURL, size)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.Data(http.StatusOK, "image/png", png)
+}
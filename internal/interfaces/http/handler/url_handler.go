@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,12 +12,27 @@ import (
 	"github.com/Shofyan/url-shortener/internal/application/dto"
 	"github.com/Shofyan/url-shortener/internal/application/usecase"
 	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
 )
 
+// CacheStatusHeader reports which cache layer served a redirect or stats lookup.
+const CacheStatusHeader = "X-Cache"
+
 // URLHandler handles URL shortening HTTP requests.
 type URLHandler struct {
-	useCase        *usecase.ShortenURLUseCase
-	cleanupService service.URLCleanupService
+	useCase           *usecase.ShortenURLUseCase
+	cleanupService    service.URLCleanupService
+	cacheStatusHeader bool
+	statsCacheMaxAge  time.Duration
+	runtimeInfo       *dto.RuntimeInfoResponse
+	batchLimits       BatchLimits
+	redirectObserver  RedirectObserver
+}
+
+// RedirectObserver records the latency of each redirect request and the cache status
+// its lookup ended with (HIT, MISS, TOMBSTONE, or empty when the cache was not reached).
+type RedirectObserver interface {
+	ObserveRedirect(cacheStatus string, elapsed time.Duration)
 }
 
 // NewURLHandler creates a new URLHandler.
@@ -26,6 +43,42 @@ func NewURLHandler(useCase *usecase.ShortenURLUseCase, cleanupService service.UR
 	}
 }
 
+// SetCacheStatusHeader enables the X-Cache header (HIT, MISS or TOMBSTONE) on
+// redirect and stats responses, for debugging cache effectiveness.
+func (h *URLHandler) SetCacheStatusHeader(enabled bool) {
+	h.cacheStatusHeader = enabled
+}
+
+// SetStatsCacheMaxAge sets how long clients may reuse a successful stats response,
+// sent as Cache-Control: private, max-age. Zero omits the header.
+func (h *URLHandler) SetStatsCacheMaxAge(maxAge time.Duration) {
+	h.statsCacheMaxAge = maxAge
+}
+
+// SetRuntimeInfo sets the static part of the GET /api/admin/info response.
+func (h *URLHandler) SetRuntimeInfo(info *dto.RuntimeInfoResponse) {
+	h.runtimeInfo = info
+}
+
+// SetRedirectObserver sets where redirect latencies are recorded; nil disables recording.
+func (h *URLHandler) SetRedirectObserver(observer RedirectObserver) {
+	h.redirectObserver = observer
+}
+
+// observeRedirect reports a redirect that started at start to the redirect observer, if any.
+func (h *URLHandler) observeRedirect(status usecase.CacheStatus, start time.Time) {
+	if h.redirectObserver != nil {
+		h.redirectObserver.ObserveRedirect(string(status), time.Since(start))
+	}
+}
+
+// setCacheStatus writes the X-Cache header when enabled.
+func (h *URLHandler) setCacheStatus(c *gin.Context, status usecase.CacheStatus) {
+	if h.cacheStatusHeader {
+		c.Header(CacheStatusHeader, string(status))
+	}
+}
+
 // ShortenURL handles POST /api/shorten requests.
 func (h *URLHandler) ShortenURL(c *gin.Context) {
 	var req dto.ShortenURLRequest
@@ -38,85 +91,182 @@ func (h *URLHandler) ShortenURL(c *gin.Context) {
 		return
 	}
 
+	// Choosing the ID is reserved for admin migrations; everyone else gets a generated key
+	if req.ID != 0 && !middleware.IsAdmin(c) {
+		log.Printf("[ShortenURL] Ignoring id from non-admin caller %s", c.ClientIP())
+
+		req.ID = 0
+	}
+
 	resp, err := h.useCase.Shorten(c.Request.Context(), &req)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		errorCode := "internal_error"
-		errorMessage := err.Error()
+		RespondError(c, err)
 
-		switch err {
-		case usecase.ErrCustomKeyExists:
-			statusCode = http.StatusConflict
-			errorCode = "custom_key_exists"
-		default:
-			// Log the actual error for debugging
-			_ = c.Error(err)
-		}
+		return
+	}
 
-		c.JSON(statusCode, dto.ErrorResponse{
-			Error:   errorCode,
-			Message: errorMessage,
-		})
+	// Point clients at the short URL; a deduplicated request gets the existing one
+	c.Header("Location", resp.ShortURL)
 
-		return
+	status := http.StatusCreated
+	if resp.Reused {
+		status = http.StatusOK
 	}
 
-	c.JSON(http.StatusCreated, resp)
+	c.JSON(status, resp)
 }
 
-// RedirectURL handles GET /:shortKey requests.
+// RedirectURL handles GET and HEAD /:shortKey requests. HEAD answers with the same
+// status and Location header but is not counted as a visit, since link checkers and
+// preview unfurlers send it without following the link.
 func (h *URLHandler) RedirectURL(c *gin.Context) {
-	shortKey := c.Param("shortKey")
+	start := time.Now()
+	shortKey := h.useCase.TrimKeySuffixes(c.Param("shortKey"))
+
+	if c.Query(FormatParam) == FormatJSON {
+		h.observeRedirect(h.resolveAsJSON(c, shortKey), start)
+		return
+	}
 
 	log.Printf("[RedirectURL] Processing %s request for short key: %s from %s | User-Agent: %s | Referer: %s",
 		c.Request.Method, shortKey, c.ClientIP(), c.GetHeader("User-Agent"), c.GetHeader("Referer"))
 
-	longURL, err := h.useCase.GetLongURL(c.Request.Context(), shortKey)
-	if err != nil {
-		statusCode := http.StatusNotFound
-		errorCode := "not_found"
+	resolve := h.useCase.Resolve
+	if !countsVisit(c) {
+		resolve = h.useCase.ResolveWithoutVisit
+	}
 
-		if err == usecase.ErrURLExpired {
-			statusCode = http.StatusGone
-			errorCode = "url_expired"
-		}
+	longURL, cacheStatus, err := resolve(c.Request.Context(), shortKey)
+	h.setCacheStatus(c, cacheStatus)
 
-		c.JSON(statusCode, dto.ErrorResponse{
-			Error:   errorCode,
-			Message: err.Error(),
-		})
+	defer h.observeRedirect(cacheStatus, start)
+
+	if err != nil {
+		statusCode, resp := buildErrorResponse(err)
+		respondRedirectError(c, statusCode, resp)
 
 		return
 	}
 
+	if countsVisit(c) {
+		h.recordVisit(c, shortKey)
+	}
+
+	// Forward the request's own query string, such as campaign parameters
+	longURL = PassthroughURL(longURL, c.Request.URL)
+
 	log.Printf("[RedirectURL] Redirecting %s to %s", shortKey, longURL)
 	// 302 redirect for temporary redirect (allows tracking)
 	// Use 301 for permanent redirect if tracking is not needed
 	c.Redirect(http.StatusFound, longURL)
 }
 
+// Query parameters of the redirect route that return the destination as JSON instead of a 302.
+const (
+	// FormatParam set to FormatJSON selects the JSON response
+	FormatParam = "format"
+	FormatJSON  = "json"
+	// CountVisitParam set to "false" resolves without counting a visit
+	CountVisitParam = "count"
+)
+
+// countsVisit reports whether a redirect request counts as a visit; HEAD requests do not.
+func countsVisit(c *gin.Context) bool {
+	return c.Request.Method != http.MethodHead
+}
+
+// recordVisit hands a counted visit to visitor estimation and click recording, along
+// with the Referer, User-Agent and client IP of the request. Neither waits on storage.
+func (h *URLHandler) recordVisit(c *gin.Context, shortKey string) {
+	h.useCase.RecordVisitor(c.Request.Context(), shortKey, c.ClientIP())
+	h.useCase.RecordClick(usecase.ClickEvent{
+		ShortKey:  shortKey,
+		Referrer:  c.GetHeader("Referer"),
+		UserAgent: c.GetHeader("User-Agent"),
+		ClientIP:  c.ClientIP(),
+	})
+}
+
+// resolveAsJSON answers GET /s/:shortKey?format=json with {long_url, expires_at}.
+// The visit is counted like a redirect unless the request is HEAD or sets count=false.
+// It returns the cache status of the lookup.
+func (h *URLHandler) resolveAsJSON(c *gin.Context, shortKey string) usecase.CacheStatus {
+	countVisit := countsVisit(c) && c.Query(CountVisitParam) != "false"
+
+	resp, cacheStatus, err := h.useCase.ResolveDetails(c.Request.Context(), shortKey, countVisit)
+	h.setCacheStatus(c, cacheStatus)
+
+	if err != nil {
+		RespondError(c, err)
+		return cacheStatus
+	}
+
+	if countVisit {
+		h.recordVisit(c, shortKey)
+	}
+
+	c.JSON(http.StatusOK, resp)
+
+	return cacheStatus
+}
+
+// PreviewURL handles GET /api/preview/:shortKey requests. It describes the destination
+// of a short URL without redirecting to it or counting a visit. Missing keys get 404
+// and expired ones 410.
+func (h *URLHandler) PreviewURL(c *gin.Context) {
+	preview, err := h.useCase.Preview(c.Request.Context(), c.Param("shortKey"))
+	if err != nil {
+		RespondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
 // GetStats handles GET /api/stats/:shortKey requests.
 func (h *URLHandler) GetStats(c *gin.Context) {
 	shortKey := c.Param("shortKey")
 
 	stats, err := h.useCase.GetStats(c.Request.Context(), shortKey)
+
+	// Stats are always read from the database for up-to-date visit counts
+	h.setCacheStatus(c, usecase.CacheMiss)
+
 	if err != nil {
-		statusCode := http.StatusNotFound
-		errorCode := "not_found"
+		// Missing and expired keys may come back, so their answers must not be reused
+		c.Header("Cache-Control", "no-store")
+		RespondError(c, err)
 
-		if err == usecase.ErrURLExpired {
-			statusCode = http.StatusGone
-			errorCode = "url_expired"
-		}
+		return
+	}
 
-		c.JSON(statusCode, dto.ErrorResponse{
-			Error:   errorCode,
-			Message: err.Error(),
+	if seconds := int(h.statsCacheMaxAge / time.Second); seconds > 0 {
+		c.Header("Cache-Control", "private, max-age="+strconv.Itoa(seconds))
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// DecodeShortURL handles GET /decode?url={short_url} requests.
+// It accepts a full short URL as pasted by users, or a bare short key.
+func (h *URLHandler) DecodeShortURL(c *gin.Context) {
+	shortURL := c.Query("url")
+	if shortURL == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "url query parameter is required",
 		})
 
 		return
 	}
 
+	stats, err := h.useCase.DecodeShortURL(c.Request.Context(), shortURL)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
 	c.JSON(http.StatusOK, stats)
 }
 
@@ -128,6 +278,289 @@ func (h *URLHandler) HealthCheck(c *gin.Context) {
 	})
 }
 
+// ReadinessCheck handles GET /health/ready requests.
+// A cleanup backlog above the warning threshold still reports ready but with a warning,
+// while a backlog above the hard limit fails readiness.
+func (h *URLHandler) ReadinessCheck(c *gin.Context) {
+	checks := gin.H{}
+	status := "ready"
+	statusCode := http.StatusOK
+
+	if h.cleanupService != nil {
+		backlog, err := h.cleanupService.CheckBacklog(c.Request.Context())
+
+		switch {
+		case err != nil:
+			status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+			checks["cleanup_backlog"] = gin.H{
+				"status": service.BacklogUnhealthy,
+				"error":  err.Error(),
+			}
+		case backlog.Status == service.BacklogUnhealthy:
+			status = "not_ready"
+			statusCode = http.StatusServiceUnavailable
+			checks["cleanup_backlog"] = backlog
+		case backlog.Status == service.BacklogDegraded:
+			status = "degraded"
+			checks["cleanup_backlog"] = backlog
+		default:
+			checks["cleanup_backlog"] = backlog
+		}
+	}
+
+	c.JSON(statusCode, gin.H{
+		"status": status,
+		"checks": checks,
+	})
+}
+
+// SelfTest handles GET /api/admin/selftest requests, for post-deploy smoke checks.
+// It answers 200 when every check passed or was skipped and 503 when any failed,
+// with the per-check report either way.
+func (h *URLHandler) SelfTest(c *gin.Context) {
+	resp := h.useCase.SelfTest(c.Request.Context())
+
+	statusCode := http.StatusOK
+	if resp.Status == usecase.SelfTestFail {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	c.JSON(statusCode, resp)
+}
+
+// SetURLEnabled handles PUT /api/admin/urls/:shortKey/enabled requests.
+func (h *URLHandler) SetURLEnabled(c *gin.Context) {
+	var req dto.SetEnabledRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	shortKey := c.Param("shortKey")
+
+	if err := h.useCase.SetEnabled(c.Request.Context(), shortKey, *req.Enabled); err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"short_key": shortKey,
+		"enabled":   *req.Enabled,
+	})
+}
+
+// DisableURL handles POST /api/admin/urls/:shortKey/disable requests, taking a short
+// URL down while keeping its stats.
+func (h *URLHandler) DisableURL(c *gin.Context) {
+	var req dto.DisableURLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	shortKey := c.Param("shortKey")
+
+	if err := h.useCase.DisableURL(c.Request.Context(), shortKey, req.Reason); err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"short_key": shortKey,
+		"enabled":   false,
+		"reason":    req.Reason,
+	})
+}
+
+// UpdateURLDestination handles PUT /api/urls/:shortKey requests, repointing an
+// existing short key to a new destination.
+func (h *URLHandler) UpdateURLDestination(c *gin.Context) {
+	var req dto.UpdateDestinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := h.useCase.UpdateDestination(c.Request.Context(), c.Param("shortKey"), req.LongURL)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetDestinationHistory handles GET /api/urls/:shortKey/history requests.
+func (h *URLHandler) GetDestinationHistory(c *gin.Context) {
+	resp, err := h.useCase.GetDestinationHistory(c.Request.Context(), c.Param("shortKey"))
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteURL handles DELETE /api/urls/:shortKey requests.
+func (h *URLHandler) DeleteURL(c *gin.Context) {
+	if err := h.useCase.DeleteURL(c.Request.Context(), c.Param("shortKey")); err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListURLs handles GET /api/admin/urls requests. Without a host filter it lists every
+// URL, paginated by the limit and offset query parameters and ordered by sort; with one
+// it defers to ListURLsByHost.
+func (h *URLHandler) ListURLs(c *gin.Context) {
+	if _, ok := c.GetQuery("host"); ok {
+		h.ListURLsByHost(c)
+
+		return
+	}
+
+	limit, ok := intQuery(c, "limit")
+	if !ok {
+		return
+	}
+
+	offset, ok := intQuery(c, "offset")
+	if !ok {
+		return
+	}
+
+	resp, err := h.useCase.ListURLs(c.Request.Context(), limit, offset, c.Query("sort"))
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// intQuery parses the integer query parameter name, which defaults to 0 when absent.
+// It writes a 400 response itself and reports false when the value is not an integer.
+func intQuery(c *gin.Context, name string) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return 0, true
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: name + " must be an integer",
+		})
+
+		return 0, false
+	}
+
+	return value, true
+}
+
+// ListURLsByHost handles GET /api/admin/urls?host={host} requests.
+// Results are paginated with the cursor and limit query parameters.
+func (h *URLHandler) ListURLsByHost(c *gin.Context) {
+	host := c.Query("host")
+	if host == "" {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "host query parameter is required",
+		})
+
+		return
+	}
+
+	limit := 0
+
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "limit must be a positive integer",
+			})
+
+			return
+		}
+
+		limit = parsed
+	}
+
+	page, err := h.useCase.ListByHost(c.Request.Context(), host, c.Query(CursorQueryParam), limit)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	RespondWithPage(c, page)
+}
+
+// RenewURLs handles POST /api/admin/renew requests.
+func (h *URLHandler) RenewURLs(c *gin.Context) {
+	var req dto.RenewURLsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	resp, err := h.useCase.RenewURLs(c.Request.Context(), &req)
+	if err != nil {
+		RespondError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// FlushCache handles POST /api/admin/cache/flush requests.
+func (h *URLHandler) FlushCache(c *gin.Context) {
+	start := time.Now()
+
+	if err := h.useCase.FlushCache(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error:   "cache_flush_failed",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"flushed":     true,
+		"duration_ms": float64(time.Since(start).Nanoseconds()) / 1000000,
+		"timestamp":   time.Now().UTC(),
+	})
+}
+
 // GetCleanupStats handles GET /api/admin/cleanup/stats requests.
 func (h *URLHandler) GetCleanupStats(c *gin.Context) {
 	if h.cleanupService == nil {
@@ -143,6 +576,127 @@ func (h *URLHandler) GetCleanupStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetCleanupConfig handles GET /api/admin/cleanup/config requests.
+func (h *URLHandler) GetCleanupConfig(c *gin.Context) {
+	if h.cleanupService == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Cleanup service is not available",
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, buildCleanupConfigResponse(h.cleanupService.GetConfig()))
+}
+
+// UpdateCleanupConfig handles PUT /api/admin/cleanup/config requests.
+// Interval, batch size and buffer time take effect without a restart.
+func (h *URLHandler) UpdateCleanupConfig(c *gin.Context) {
+	if h.cleanupService == nil {
+		c.JSON(http.StatusServiceUnavailable, dto.ErrorResponse{
+			Error:   "service_unavailable",
+			Message: "Cleanup service is not available",
+		})
+
+		return
+	}
+
+	var req dto.UpdateCleanupConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	interval, err := parseOptionalDuration(req.CleanupInterval)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "cleanup_interval must be a duration such as \"5m\"",
+		})
+
+		return
+	}
+
+	bufferTime, err := parseOptionalDuration(req.BufferTime)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "buffer_time must be a duration such as \"1h\"",
+		})
+
+		return
+	}
+
+	update := service.CleanupConfigUpdate{
+		CleanupInterval: interval,
+		BatchSize:       req.BatchSize,
+		BufferTime:      bufferTime,
+	}
+
+	config, err := h.cleanupService.UpdateConfig(update)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidCleanupConfig) {
+			statusCode = http.StatusBadRequest
+		}
+
+		c.JSON(statusCode, dto.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, buildCleanupConfigResponse(config))
+}
+
+// parseOptionalDuration parses a duration field, returning nil when it was omitted.
+func parseOptionalDuration(value string) (*time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &duration, nil
+}
+
+// buildCleanupConfigResponse converts a cleanup configuration to its API representation.
+func buildCleanupConfigResponse(config service.CleanupConfig) dto.CleanupConfigResponse {
+	return dto.CleanupConfigResponse{
+		Enabled:            config.Enabled,
+		CleanupInterval:    config.CleanupInterval.String(),
+		BatchSize:          config.BatchSize,
+		BufferTime:         config.BufferTime.String(),
+		MaxCleanupDuration: config.MaxCleanupDuration.String(),
+	}
+}
+
+// GetRuntimeInfo handles GET /api/admin/info requests. The cleanup section reflects
+// the running service, so it includes changes made through the cleanup config endpoint.
+func (h *URLHandler) GetRuntimeInfo(c *gin.Context) {
+	var info dto.RuntimeInfoResponse
+	if h.runtimeInfo != nil {
+		info = *h.runtimeInfo
+	}
+
+	if h.cleanupService != nil {
+		cleanup := buildCleanupConfigResponse(h.cleanupService.GetConfig())
+		info.Cleanup = &cleanup
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
 // TriggerManualCleanup handles POST /api/admin/cleanup/manual requests.
 func (h *URLHandler) TriggerManualCleanup(c *gin.Context) {
 	if h.cleanupService == nil {
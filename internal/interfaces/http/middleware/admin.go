@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// adminContextKey marks a request whose caller presented the admin token.
+const adminContextKey = "is_admin"
+
+// IdentifyAdmin marks requests carrying "Authorization: Bearer <token>" as admin
+// requests without rejecting anyone else. Handlers use IsAdmin to gate admin-only
+// options. An empty token means no caller is ever treated as an admin.
+func IdentifyAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasAdminToken(c, token) {
+			c.Set(adminContextKey, true)
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdmin rejects requests without the admin bearer token with 401. An empty
+// token rejects every request, so admin-only endpoints stay closed until one is configured.
+func RequireAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAdminToken(c, token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":   "unauthorized",
+				"message": "a valid admin bearer token is required",
+			})
+
+			return
+		}
+
+		c.Set(adminContextKey, true)
+		c.Next()
+	}
+}
+
+// hasAdminToken reports whether the request carries "Authorization: Bearer <token>".
+func hasAdminToken(c *gin.Context, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	presented, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	return ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1
+}
+
+// IsAdmin reports whether IdentifyAdmin authenticated the caller as an admin.
+func IsAdmin(c *gin.Context) bool {
+	return c.GetBool(adminContextKey)
+}
@@ -0,0 +1,187 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Content codings applied by Compression, in order of preference.
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+// DefaultCompressionMinSize is the smallest body, in bytes, worth compressing.
+const DefaultCompressionMinSize = 1024
+
+// incompressibleTypes are content type prefixes that are already compressed.
+var incompressibleTypes = []string{
+	"image/",
+	"video/",
+	"audio/",
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"font/woff",
+}
+
+// Compression gzip or deflate encodes response bodies of at least minSize bytes for
+// clients that accept it. The body is held back until the handler chain finishes, so
+// headers set by earlier middleware after c.Next, such as X-Processing-Time-Micros,
+// are still sent. Redirects and other bodiless responses, partial content, responses
+// that already carry a Content-Encoding and already compressed types (images, video,
+// archives) are passed through unchanged. Values of minSize below 1 use
+// DefaultCompressionMinSize.
+func Compression(minSize int) gin.HandlerFunc {
+	if minSize < 1 {
+		minSize = DefaultCompressionMinSize
+	}
+
+	return func(c *gin.Context) {
+		// Caches must keep the encoded and plain variants apart
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		// Restored even when a handler panics, so Recovery's response is not buffered away
+		defer func() { c.Writer = writer.ResponseWriter }()
+
+		c.Next()
+
+		body := writer.body.Bytes()
+		if len(body) == 0 {
+			return
+		}
+
+		if len(body) >= minSize && compressible(writer.Status(), writer.Header()) {
+			if encoded, err := encode(encoding, body); err == nil && len(encoded) < len(body) {
+				header := writer.Header()
+				header.Set("Content-Encoding", encoding)
+				header.Set("Content-Length", strconv.Itoa(len(encoded)))
+				body = encoded
+			}
+		}
+
+		_, _ = writer.ResponseWriter.Write(body)
+	}
+}
+
+// negotiateEncoding returns the preferred coding the Accept-Encoding header allows,
+// or "" when the client accepts neither gzip nor deflate.
+func negotiateEncoding(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+	wildcard, wildcardSet := false, false
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		allowed := true
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if weight, err := strconv.ParseFloat(q, 64); err == nil && weight == 0 {
+				allowed = false
+			}
+		}
+
+		if name == "*" {
+			wildcard, wildcardSet = allowed, true
+			continue
+		}
+
+		accepted[name] = allowed
+	}
+
+	for _, encoding := range []string{EncodingGzip, EncodingDeflate} {
+		if allowed, ok := accepted[encoding]; ok {
+			if allowed {
+				return encoding
+			}
+			continue
+		}
+
+		if wildcardSet && wildcard {
+			return encoding
+		}
+	}
+
+	return ""
+}
+
+// compressible reports whether a response with status and header may be encoded.
+func compressible(status int, header http.Header) bool {
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusPartialContent ||
+		(status >= http.StatusMultipleChoices && status < http.StatusBadRequest) {
+		return false
+	}
+
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// encode compresses body with encoding. HTTP deflate is the zlib format (RFC 9110).
+func encode(encoding string, body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	var w io.WriteCloser
+	if encoding == EncodingGzip {
+		w = gzip.NewWriter(&buf)
+	} else {
+		w = zlib.NewWriter(&buf)
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// compressWriter holds the body back until the handler chain finishes so it can be encoded.
+type compressWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *compressWriter) Written() bool {
+	return w.body.Len() > 0 || w.ResponseWriter.Written()
+}
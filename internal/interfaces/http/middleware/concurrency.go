@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// concurrencyRetryAfterSeconds is the Retry-After sent when the limit is reached;
+// in-flight database work usually finishes well within it.
+const concurrencyRetryAfterSeconds = 1
+
+// ConcurrencyLimit bounds the number of requests in flight through the returned
+// middleware to n. Requests arriving while n are running are rejected at once with
+// 503 and Retry-After rather than queueing on the database connection pool. Routes
+// sharing one limit must share the returned handler. A non-positive n disables it.
+func ConcurrencyLimit(n int) gin.HandlerFunc {
+	if n <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	slots := make(chan struct{}, n)
+
+	return func(c *gin.Context) {
+		select {
+		case slots <- struct{}{}:
+		default:
+			c.Header("Retry-After", strconv.Itoa(concurrencyRetryAfterSeconds))
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "server_busy",
+				"message": "Too many requests in progress. Please try again shortly.",
+			})
+
+			return
+		}
+
+		defer func() { <-slots }()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireContentType rejects requests whose Content-Type is not one of allowed
+// with 415 Unsupported Media Type. Parameters such as charset are ignored, and a
+// missing Content-Type is rejected. An empty allowlist means application/json only.
+func RequireContentType(allowed ...string) gin.HandlerFunc {
+	if len(allowed) == 0 {
+		allowed = []string{gin.MIMEJSON}
+	}
+
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, mediaType := range allowed {
+		allowedSet[strings.ToLower(strings.TrimSpace(mediaType))] = true
+	}
+
+	accepted := strings.Join(allowed, ", ")
+
+	return func(c *gin.Context) {
+		mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		if err == nil && allowedSet[mediaType] {
+			c.Next()
+			return
+		}
+
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, gin.H{
+			"error":   "unsupported_media_type",
+			"message": "Content-Type must be one of: " + accepted,
+		})
+	}
+}
@@ -1,19 +1,85 @@
 package middleware
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gin-gonic/gin"
 )
 
+// CORSConfig controls the Cross-Origin Resource Sharing headers.
+type CORSConfig struct {
+	// Origins allowed to call the API; "*" allows any origin
+	AllowOrigins []string
+	// Methods and request headers advertised to preflight requests
+	AllowMethods []string
+	AllowHeaders []string
+	// How long browsers may cache a preflight response; 0 omits Access-Control-Max-Age
+	MaxAge time.Duration
+}
+
+// DefaultCORSConfig returns the configuration CORS uses: any origin and the methods
+// and headers the API accepts.
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"POST", "OPTIONS", "GET", "PUT", "DELETE"},
+		AllowHeaders: []string{
+			"Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization",
+			"accept", "origin", "Cache-Control", "X-Requested-With",
+		},
+	}
+}
+
 // CORS middleware handles Cross-Origin Resource Sharing.
 func CORS() gin.HandlerFunc {
+	return CORSWith(DefaultCORSConfig())
+}
+
+// CORSWith handles Cross-Origin Resource Sharing using cfg. OPTIONS requests are
+// answered with 204 and the chain is aborted, so preflight works for every path the
+// middleware runs on. With an origin allowlist the matching request origin is echoed
+// back; requests from other origins get no Access-Control-Allow-Origin header.
+func CORSWith(cfg CORSConfig) gin.HandlerFunc {
+	allowAny := false
+	origins := make(map[string]bool, len(cfg.AllowOrigins))
+
+	for _, origin := range cfg.AllowOrigins {
+		origin = strings.TrimSpace(origin)
+		if origin == "*" {
+			allowAny = true
+		}
+
+		origins[origin] = true
+	}
+
+	methods := strings.Join(cfg.AllowMethods, ", ")
+	headers := strings.Join(cfg.AllowHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge / time.Second))
+
 	return func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		header := c.Writer.Header()
+
+		if allowAny {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else if origin := c.GetHeader("Origin"); origins[origin] {
+			header.Set("Access-Control-Allow-Origin", origin)
+			header.Add("Vary", "Origin")
+		}
+
+		header.Set("Access-Control-Allow-Credentials", "true")
+		header.Set("Access-Control-Allow-Headers", headers)
+		header.Set("Access-Control-Allow-Methods", methods)
+
+		if c.Request.Method == http.MethodOptions {
+			if cfg.MaxAge > 0 {
+				header.Set("Access-Control-Max-Age", maxAge)
+			}
+
+			c.AbortWithStatus(http.StatusNoContent)
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
 			return
 		}
 
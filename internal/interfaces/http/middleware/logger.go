@@ -1,38 +1,39 @@
 package middleware
 
 import (
-	"time"
-
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Logger middleware logs request details.
+// Logger middleware logs one key=value line per request with its method, path, status,
+// latency, client IP and request ID, plus a line for each error handlers attached.
+// Requests that did not pass through RequestID are given an ID here, so every line
+// can be correlated with the use case logs of the same request.
 func Logger() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		query := c.Request.URL.RawQuery
+		requestID := ensureRequestID(c)
 
 		c.Next()
 
-		end := time.Now()
-		latency := end.Sub(start)
+		latency := time.Since(start)
 
-		// Log errors if any
-		if len(c.Errors) > 0 {
-			for _, e := range c.Errors {
-				log.Printf("[ERROR] %v", e.Err)
-			}
+		for _, e := range c.Errors {
+			log.Printf("[HTTP] event=request_error request_id=%s error=%q", requestID, e.Err.Error())
 		}
 
-		log.Printf("[%s] %s %s | Status: %d | Latency: %v",
+		log.Printf("[HTTP] event=request method=%s path=%q query=%q status=%d latency_ms=%.3f client_ip=%s request_id=%s",
 			c.Request.Method,
 			path,
 			query,
 			c.Writer.Status(),
-			latency,
+			float64(latency.Microseconds())/1000,
+			c.ClientIP(),
+			requestID,
 		)
 	}
 }
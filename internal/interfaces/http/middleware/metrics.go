@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners probing random
+// paths add one series rather than one per path.
+const unmatchedRoute = "unmatched"
+
+// RequestObserver records the outcome of each HTTP request.
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, elapsed time.Duration)
+}
+
+// Metrics reports every request to observer with its route template, such as
+// "/s/:shortKey", its status and how long it took. A nil observer disables it.
+func Metrics(observer RequestObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if observer == nil {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		observer.ObserveRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"log"
+	"math"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -9,12 +14,75 @@ import (
 	"golang.org/x/time/rate"
 )
 
+// throttleReportSize is how many of the most throttled visitors each periodic report lists.
+const throttleReportSize = 10
+
+// KeyFunc returns the bucket a request is counted in, such as its client IP.
+type KeyFunc func(c *gin.Context) string
+
+// ClientIPKey counts requests per client IP. It is the default KeyFunc.
+func ClientIPKey(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// apiKeyBucketPrefix marks the buckets of API clients, which get the API key tier limit.
+const apiKeyBucketPrefix = "key:"
+
+// APIKeyOrIPKey counts requests whose header carries one of keys, a map of client name
+// to API key, per API client, so it gets its own bucket instead of sharing one with
+// everyone behind its IP. Buckets are named after the client, keeping keys out of
+// throttle reports. Every other request, including one with an unknown key, is counted
+// per client IP, so rotating made-up keys never earns a fresh budget.
+func APIKeyOrIPKey(header string, keys map[string]string) KeyFunc {
+	clients := make(map[string]string, len(keys))
+	for name, apiKey := range keys {
+		if apiKey != "" {
+			clients[apiKey] = name
+		}
+	}
+
+	return func(c *gin.Context) string {
+		if name, ok := clients[c.GetHeader(header)]; ok {
+			return apiKeyBucketPrefix + name
+		}
+
+		return c.ClientIP()
+	}
+}
+
+// isAPIKeyBucket reports whether key is an API client's bucket from APIKeyOrIPKey.
+func isAPIKeyBucket(key string) bool {
+	return strings.HasPrefix(key, apiKeyBucketPrefix)
+}
+
+// ThrottleObserver records each request denied by a rate limiter.
+type ThrottleObserver interface {
+	ObserveThrottle(group string)
+}
+
 // RateLimiter middleware implements rate limiting per IP.
 type RateLimiter struct {
 	visitors map[string]*visitor
 	mu       sync.RWMutex
 	rate     rate.Limit
 	burst    int
+	// Requests per minute for named route groups; other groups use rate and burst
+	groups map[string]int
+	key    KeyFunc
+	// Factor applied to the limits of API client buckets; 1 gives them the anonymous limits
+	apiKeyMultiplier int
+	// Denied requests per visitor since the last report; reset on every cleanup pass
+	throttled map[string]int64
+	// Denied requests since the limiter was created
+	totalThrottled int64
+	// Receives every denied request; nil when throttles are only logged
+	observer ThrottleObserver
+}
+
+// ThrottleCount is the number of requests denied for one visitor.
+type ThrottleCount struct {
+	Visitor string `json:"visitor"`
+	Denied  int64  `json:"denied"`
 }
 
 type visitor struct {
@@ -25,9 +93,12 @@ type visitor struct {
 // NewRateLimiter creates a new rate limiter middleware.
 func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
 	rl := &RateLimiter{
-		visitors: make(map[string]*visitor),
-		rate:     rate.Limit(float64(requestsPerMinute) / 60.0),
-		burst:    burst,
+		visitors:         make(map[string]*visitor),
+		rate:             rate.Limit(float64(requestsPerMinute) / 60.0),
+		burst:            burst,
+		throttled:        make(map[string]int64),
+		key:              ClientIPKey,
+		apiKeyMultiplier: 1,
 	}
 
 	// Cleanup old visitors every 3 minutes
@@ -36,28 +107,94 @@ func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
 	return rl
 }
 
+// SetGroupLimits sets requests per minute, also used as the burst, for named route
+// groups. Each group counts its own requests, so a busy redirect path does not use up
+// the budget for creating URLs. Groups not in limits use the limiter's defaults.
+func (rl *RateLimiter) SetGroupLimits(limits map[string]int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.groups = limits
+}
+
+// SetKeyFunc sets how requests are assigned to buckets; nil restores ClientIPKey.
+func (rl *RateLimiter) SetKeyFunc(key KeyFunc) {
+	if key == nil {
+		key = ClientIPKey
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.key = key
+}
+
+// SetAPIKeyMultiplier gives API client buckets from APIKeyOrIPKey multiplier times the
+// rate and burst of their route group. Values below 1 give them the anonymous limits.
+func (rl *RateLimiter) SetAPIKeyMultiplier(multiplier int) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.apiKeyMultiplier = max(1, multiplier)
+}
+
+// SetThrottleObserver sets where denied requests are recorded, in addition to the
+// periodic throttle report; nil disables recording.
+func (rl *RateLimiter) SetThrottleObserver(observer ThrottleObserver) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.observer = observer
+}
+
 // Limit returns the rate limiting middleware.
 func (rl *RateLimiter) Limit() gin.HandlerFunc {
+	return rl.LimitWith(respondTooManyRequests)
+}
+
+// LimitWith returns the rate limiting middleware, using onLimit to write the response
+// for throttled requests. Retry-After is set before onLimit runs and the chain is aborted after.
+// Every response carries the X-RateLimit-* headers described at setRateLimitHeaders.
+func (rl *RateLimiter) LimitWith(onLimit gin.HandlerFunc) gin.HandlerFunc {
+	return rl.LimitGroupWith("", onLimit)
+}
+
+// LimitGroup returns the rate limiting middleware for the route group named group.
+func (rl *RateLimiter) LimitGroup(group string) gin.HandlerFunc {
+	return rl.LimitGroupWith(group, respondTooManyRequests)
+}
+
+// LimitGroupWith is LimitWith for the route group named group.
+func (rl *RateLimiter) LimitGroupWith(group string, onLimit gin.HandlerFunc) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := c.ClientIP()
+		rl.mu.RLock()
+		key := rl.key(c)
+		limit, burst := rl.limitFor(group, key)
+		rl.mu.RUnlock()
+
+		bucket := group + "\x00" + key
 
 		rl.mu.Lock()
 
-		v, exists := rl.visitors[ip]
+		v, exists := rl.visitors[bucket]
 		if !exists {
-			limiter := rate.NewLimiter(rl.rate, rl.burst)
-			rl.visitors[ip] = &visitor{limiter, time.Now()}
-			v = rl.visitors[ip]
+			limiter := rate.NewLimiter(limit, burst)
+			rl.visitors[bucket] = &visitor{limiter, time.Now()}
+			v = rl.visitors[bucket]
 		}
 
-		v.lastSeen = time.Now()
+		now := time.Now()
+		v.lastSeen = now
 		rl.mu.Unlock()
 
-		if !v.limiter.Allow() {
-			c.JSON(http.StatusTooManyRequests, gin.H{
-				"error":   "rate_limit_exceeded",
-				"message": "Too many requests. Please try again later.",
-			})
+		allowed := v.limiter.AllowN(now, 1)
+		tokens := v.limiter.TokensAt(now)
+		setRateLimitHeaders(c, burst, int(math.Max(0, math.Floor(tokens))), refillTime(limit, float64(burst)-tokens))
+
+		if !allowed {
+			rl.recordThrottle(group, key)
+			c.Header("Retry-After", strconv.Itoa(retryAfterFor(limit)))
+			onLimit(c)
 			c.Abort()
 
 			return
@@ -67,12 +204,137 @@ func (rl *RateLimiter) Limit() gin.HandlerFunc {
 	}
 }
 
-// cleanupVisitors removes old visitors from the map.
+// recordThrottle counts a denied request in group for ip.
+func (rl *RateLimiter) recordThrottle(group, ip string) {
+	rl.mu.Lock()
+	rl.throttled[ip]++
+	rl.totalThrottled++
+	observer := rl.observer
+	rl.mu.Unlock()
+
+	if observer != nil {
+		observer.ObserveThrottle(group)
+	}
+}
+
+// TopThrottled returns up to n visitors with the most denied requests since the
+// last cleanup pass, most throttled first. A non-positive n returns every visitor.
+func (rl *RateLimiter) TopThrottled(n int) []ThrottleCount {
+	rl.mu.RLock()
+	counts := make([]ThrottleCount, 0, len(rl.throttled))
+
+	for ip, denied := range rl.throttled {
+		counts = append(counts, ThrottleCount{Visitor: ip, Denied: denied})
+	}
+	rl.mu.RUnlock()
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Denied != counts[j].Denied {
+			return counts[i].Denied > counts[j].Denied
+		}
+
+		return counts[i].Visitor < counts[j].Visitor
+	})
+
+	if n > 0 && len(counts) > n {
+		counts = counts[:n]
+	}
+
+	return counts
+}
+
+// TotalThrottled returns how many requests have been denied since the limiter was created.
+func (rl *RateLimiter) TotalThrottled() int64 {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	return rl.totalThrottled
+}
+
+// reportThrottles logs the most throttled visitors as key=value pairs and resets the
+// per-visitor counters, so they only ever cover one cleanup interval.
+func (rl *RateLimiter) reportThrottles() {
+	top := rl.TopThrottled(throttleReportSize)
+
+	rl.mu.Lock()
+	visitors := len(rl.throttled)
+	rl.throttled = make(map[string]int64)
+	total := rl.totalThrottled
+	rl.mu.Unlock()
+
+	if len(top) == 0 {
+		return
+	}
+
+	log.Printf("[RateLimiter] event=throttle_report throttled_visitors=%d total_throttled=%d", visitors, total)
+
+	for rank, count := range top {
+		log.Printf("[RateLimiter] event=throttled_visitor rank=%d visitor=%s denied=%d", rank+1, count.Visitor, count.Denied)
+	}
+}
+
+// limitFor returns the token rate and burst for the bucket key in group. Callers hold rl.mu.
+func (rl *RateLimiter) limitFor(group, key string) (rate.Limit, int) {
+	limit, burst := rl.rate, rl.burst
+	if requests, ok := rl.groups[group]; ok && requests > 0 {
+		limit, burst = rate.Limit(float64(requests)/60.0), requests
+	}
+
+	if isAPIKeyBucket(key) {
+		limit, burst = limit*rate.Limit(rl.apiKeyMultiplier), burst*rl.apiKeyMultiplier
+	}
+
+	return limit, burst
+}
+
+// refillTime returns how long limit takes to add tokens to a bucket.
+func refillTime(limit rate.Limit, tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	if limit <= 0 {
+		return time.Minute
+	}
+
+	return time.Duration(tokens / float64(limit) * float64(time.Second))
+}
+
+// setRateLimitHeaders tells the client where it stands: X-RateLimit-Limit is the most
+// requests allowed at once, X-RateLimit-Remaining how many it can still send now, and
+// X-RateLimit-Reset the seconds until the full limit is available again. Reset is a
+// delay rather than a timestamp, like Retry-After, so client clock skew does not matter.
+func setRateLimitHeaders(c *gin.Context, limit, remaining int, reset time.Duration) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(limit))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(reset.Seconds()))))
+}
+
+// retryAfterFor returns how long a client should wait for the next token at limit.
+func retryAfterFor(limit rate.Limit) int {
+	if limit <= 0 {
+		return 60
+	}
+
+	return int(math.Ceil(1 / float64(limit)))
+}
+
+// respondTooManyRequests writes the default JSON response for throttled requests.
+func respondTooManyRequests(c *gin.Context) {
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":   "rate_limit_exceeded",
+		"message": "Too many requests. Please try again later.",
+	})
+}
+
+// cleanupVisitors removes old visitors from the map and reports throttled visitors.
 func (rl *RateLimiter) cleanupVisitors() {
 	ticker := time.NewTicker(3 * time.Minute)
 	defer ticker.Stop()
 
 	for range ticker.C {
+		rl.reportThrottles()
+
 		rl.mu.Lock()
 		for ip, v := range rl.visitors {
 			if time.Since(v.lastSeen) > 3*time.Minute {
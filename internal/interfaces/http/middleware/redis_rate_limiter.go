@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+// Limiter builds rate limiting middleware. RateLimiter keeps its counters in memory,
+// per instance; RedisRateLimiter shares them across every instance using one Redis.
+type Limiter interface {
+	Limit() gin.HandlerFunc
+	LimitWith(onLimit gin.HandlerFunc) gin.HandlerFunc
+	LimitGroup(group string) gin.HandlerFunc
+	LimitGroupWith(group string, onLimit gin.HandlerFunc) gin.HandlerFunc
+}
+
+// fixedWindowScript counts a request in the current window, starting the window's
+// expiry on its first request, and returns the count and the window's remaining
+// milliseconds. Running both in one script means a key can never be left without a TTL.
+var fixedWindowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 or redis.call("PTTL", KEYS[1]) < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return {count, redis.call("PTTL", KEYS[1])}
+`)
+
+// RedisRateLimiter limits each client IP to a fixed number of requests per window,
+// counted in Redis so the limit holds across every instance behind a load balancer.
+// When Redis cannot be reached the request is allowed: an outage degrades to no rate
+// limiting rather than rejecting all traffic.
+type RedisRateLimiter struct {
+	client    *redis.Client
+	keyPrefix string
+	requests  int
+	window    time.Duration
+	// Requests per window for named route groups; other groups use requests
+	groups map[string]int
+	key    KeyFunc
+	// Factor applied to the limits of API client buckets; 1 gives them the anonymous limits
+	apiKeyMultiplier int
+	// Receives every denied request; nil disables recording
+	observer ThrottleObserver
+}
+
+// NewRedisRateLimiter creates a rate limiter allowing requests per window for each
+// client IP. Counters are stored under keyPrefix + "ratelimit:".
+func NewRedisRateLimiter(client *redis.Client, keyPrefix string, requests int, window time.Duration) *RedisRateLimiter {
+	if window <= 0 {
+		window = time.Minute
+	}
+
+	return &RedisRateLimiter{
+		client:           client,
+		keyPrefix:        keyPrefix + "ratelimit:",
+		requests:         requests,
+		window:           window,
+		key:              ClientIPKey,
+		apiKeyMultiplier: 1,
+	}
+}
+
+// SetGroupLimits sets requests per window for named route groups, each counted
+// separately. Limits are read when a group's middleware is built, so set them first.
+func (rl *RedisRateLimiter) SetGroupLimits(limits map[string]int) {
+	rl.groups = limits
+}
+
+// SetKeyFunc sets how requests are assigned to buckets; nil restores ClientIPKey.
+func (rl *RedisRateLimiter) SetKeyFunc(key KeyFunc) {
+	if key == nil {
+		key = ClientIPKey
+	}
+
+	rl.key = key
+}
+
+// SetAPIKeyMultiplier gives API client buckets from APIKeyOrIPKey multiplier times the
+// requests of their route group. Values below 1 give them the anonymous limits.
+func (rl *RedisRateLimiter) SetAPIKeyMultiplier(multiplier int) {
+	rl.apiKeyMultiplier = max(1, multiplier)
+}
+
+// SetThrottleObserver sets where denied requests are recorded; nil disables recording.
+func (rl *RedisRateLimiter) SetThrottleObserver(observer ThrottleObserver) {
+	rl.observer = observer
+}
+
+// Limit returns the rate limiting middleware.
+func (rl *RedisRateLimiter) Limit() gin.HandlerFunc {
+	return rl.LimitWith(respondTooManyRequests)
+}
+
+// LimitWith returns the rate limiting middleware, using onLimit to write the response
+// for throttled requests. Retry-After is set before onLimit runs and the chain is aborted after.
+// Every response carries the X-RateLimit-* headers, with Reset counting down to the
+// end of the current window.
+func (rl *RedisRateLimiter) LimitWith(onLimit gin.HandlerFunc) gin.HandlerFunc {
+	return rl.LimitGroupWith("", onLimit)
+}
+
+// LimitGroup returns the rate limiting middleware for the route group named group.
+func (rl *RedisRateLimiter) LimitGroup(group string) gin.HandlerFunc {
+	return rl.LimitGroupWith(group, respondTooManyRequests)
+}
+
+// LimitGroupWith is LimitWith for the route group named group.
+func (rl *RedisRateLimiter) LimitGroupWith(group string, onLimit gin.HandlerFunc) gin.HandlerFunc {
+	groupRequests := rl.requests
+	if limit, ok := rl.groups[group]; ok && limit > 0 {
+		groupRequests = limit
+	}
+
+	prefix := rl.keyPrefix
+	if group != "" {
+		prefix += group + ":"
+	}
+
+	return func(c *gin.Context) {
+		key := rl.key(c)
+
+		requests := groupRequests
+		if isAPIKeyBucket(key) {
+			requests *= rl.apiKeyMultiplier
+		}
+
+		result, err := fixedWindowScript.Run(c.Request.Context(), rl.client, []string{prefix + key}, rl.window.Milliseconds()).Int64Slice()
+		if err != nil || len(result) != 2 {
+			log.Printf("[RedisRateLimiter] Allowing %s, rate limit check failed: %v", key, err)
+			c.Next()
+
+			return
+		}
+
+		count, remaining := result[0], time.Duration(result[1])*time.Millisecond
+		setRateLimitHeaders(c, requests, max(0, requests-int(count)), remaining)
+
+		if count > int64(requests) {
+			if rl.observer != nil {
+				rl.observer.ObserveThrottle(group)
+			}
+
+			c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(remaining)))
+			onLimit(c)
+			c.Abort()
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// retryAfterSeconds rounds the time left in a window up to whole seconds, at least one.
+func retryAfterSeconds(remaining time.Duration) int {
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied request IDs so they cannot bloat logs.
+const maxRequestIDLength = 128
+
+// requestIDContextKey holds the request ID in the gin context.
+const requestIDContextKey = "request_id"
+
+// RequestID reuses the caller's X-Request-ID, or generates one, echoes it in the
+// response and stores it in the request context for logs written after the response.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ensureRequestID(c)
+
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID assigned to the request by RequestID or Logger, or ""
+// before either has run. Use cases read the same ID from the request context with
+// async.RequestID.
+func GetRequestID(c *gin.Context) string {
+	return c.GetString(requestIDContextKey)
+}
+
+// ensureRequestID assigns the request its ID unless one already has been, and returns it.
+func ensureRequestID(c *gin.Context) string {
+	if requestID := GetRequestID(c); requestID != "" {
+		return requestID
+	}
+
+	requestID := c.GetHeader(RequestIDHeader)
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = newRequestID()
+	}
+
+	c.Set(requestIDContextKey, requestID)
+	c.Header(RequestIDHeader, requestID)
+	c.Request = c.Request.WithContext(async.WithRequestID(c.Request.Context(), requestID))
+
+	return requestID
+}
+
+// newRequestID returns 16 random bytes as hex.
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+
+	return hex.EncodeToString(buf)
+}
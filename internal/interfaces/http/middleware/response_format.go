@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+)
+
+// JSON field naming styles accepted by ResponseFormat.
+const (
+	// FieldNamingSnake leaves field names as the handlers write them (snake_case)
+	FieldNamingSnake = "snake"
+	// FieldNamingCamel rewrites field names to camelCase
+	FieldNamingCamel = "camel"
+)
+
+// EnvelopeMeta is the meta object of an enveloped response.
+type EnvelopeMeta struct {
+	RequestID string    `json:"request_id,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ResponseFormat rewrites JSON responses without the handlers knowing about it.
+// With envelope set, successful (2xx) JSON bodies become {"data": ..., "meta": ...};
+// errors stay flat so clients can keep reading the error field directly.
+// With naming set to FieldNamingCamel, every JSON field name is converted to camelCase.
+// Non-JSON responses (redirects, HTML pages) pass through untouched.
+func ResponseFormat(envelope bool, naming string) gin.HandlerFunc {
+	camel := naming == FieldNamingCamel
+
+	return func(c *gin.Context) {
+		if !envelope && !camel {
+			c.Next()
+			return
+		}
+
+		writer := &formatWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		c.Writer = writer.ResponseWriter
+
+		body := writer.body.Bytes()
+		if len(body) == 0 {
+			return
+		}
+
+		if strings.HasPrefix(writer.Header().Get("Content-Type"), gin.MIMEJSON) {
+			if formatted, err := formatJSON(c, body, envelope, camel); err == nil {
+				body = formatted
+			}
+		}
+
+		_, _ = writer.ResponseWriter.Write(body)
+	}
+}
+
+// formatJSON applies the envelope and field naming to a JSON body.
+func formatJSON(c *gin.Context, body []byte, envelope, camel bool) ([]byte, error) {
+	status := c.Writer.Status()
+	if envelope && status >= http.StatusOK && status < http.StatusMultipleChoices {
+		wrapped, err := json.Marshal(gin.H{
+			"data": json.RawMessage(body),
+			"meta": EnvelopeMeta{
+				RequestID: async.RequestID(c.Request.Context()),
+				Timestamp: time.Now().UTC(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		body = wrapped
+	}
+
+	if !camel {
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(camelizeKeys(value))
+}
+
+// camelizeKeys converts every object key in value to camelCase.
+func camelizeKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[toCamelCase(key)] = camelizeKeys(item)
+		}
+		return converted
+	case []interface{}:
+		for i, item := range v {
+			v[i] = camelizeKeys(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// toCamelCase converts a snake_case name to camelCase ("short_url" -> "shortUrl").
+func toCamelCase(name string) string {
+	parts := strings.Split(name, "_")
+
+	var b strings.Builder
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		if i > 0 && b.Len() > 0 {
+			runes := []rune(part)
+			runes[0] = unicode.ToUpper(runes[0])
+			part = string(runes)
+		}
+
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+// formatWriter holds the body back until the handler chain finishes so it can be rewritten.
+type formatWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *formatWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *formatWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *formatWriter) Written() bool {
+	return w.body.Len() > 0 || w.ResponseWriter.Written()
+}
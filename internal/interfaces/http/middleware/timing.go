@@ -2,19 +2,105 @@ package middleware
 
 import (
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// ProcessingTimeHeader reports how long the server spent on a request.
+const ProcessingTimeHeader = "X-Processing-Time-Micros"
+
+// Processing time header modes accepted by TimingSamplerFor.
+const (
+	// TimingAlways adds the header to every response
+	TimingAlways = "always"
+	// TimingNever never adds the header
+	TimingNever = "never"
+	// TimingSampled adds the header to one in every N requests
+	TimingSampled = "sampled"
+	// TimingDebug adds the header only when the request carries the debug query parameter
+	TimingDebug = "debug"
+)
+
+// TimingDebugParam is the query parameter that forces the header in sampled and debug modes.
+const TimingDebugParam = "debug"
+
+// TimingSampler decides whether a request gets the processing time header.
+type TimingSampler func(c *gin.Context) bool
+
+// SampleAlways times every request.
+func SampleAlways() TimingSampler {
+	return func(*gin.Context) bool { return true }
+}
+
+// SampleNever times no request.
+func SampleNever() TimingSampler {
+	return func(*gin.Context) bool { return false }
+}
+
+// SampleEveryN times the first request and every nth one after it. Sampling is
+// counter-based rather than random, so the rate holds exactly over any n requests.
+// Values of n below 2 time every request.
+func SampleEveryN(n int) TimingSampler {
+	if n < 2 {
+		return SampleAlways()
+	}
+
+	var counter atomic.Uint64
+
+	return func(*gin.Context) bool {
+		return (counter.Add(1)-1)%uint64(n) == 0
+	}
+}
+
+// SampleOnDebug times only requests carrying the debug query parameter.
+func SampleOnDebug() TimingSampler {
+	return func(c *gin.Context) bool {
+		_, ok := c.GetQuery(TimingDebugParam)
+		return ok
+	}
+}
+
+// TimingSamplerFor returns the sampler for mode. Sampled mode times one in every
+// rate requests plus any request asking for it with the debug parameter.
+// Unknown modes time every request.
+func TimingSamplerFor(mode string, rate int) TimingSampler {
+	switch mode {
+	case TimingNever:
+		return SampleNever()
+	case TimingDebug:
+		return SampleOnDebug()
+	case TimingSampled:
+		everyN, onDebug := SampleEveryN(rate), SampleOnDebug()
+
+		return func(c *gin.Context) bool {
+			return onDebug(c) || everyN(c)
+		}
+	default:
+		return SampleAlways()
+	}
+}
+
 // ProcessingTime middleware adds X-Processing-Time-Micros header to all responses.
 func ProcessingTime() gin.HandlerFunc {
+	return ProcessingTimeWith(SampleAlways())
+}
+
+// ProcessingTimeWith adds X-Processing-Time-Micros to the responses sampler selects.
+// Requests that are not sampled skip the clock reads entirely.
+func ProcessingTimeWith(sampler TimingSampler) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if !sampler(c) {
+			c.Next()
+			return
+		}
+
 		start := time.Now()
 
 		c.Next()
 
 		microseconds := time.Since(start).Microseconds()
-		c.Header("X-Processing-Time-Micros", strconv.FormatInt(microseconds, 10))
+		c.Header(ProcessingTimeHeader, strconv.FormatInt(microseconds, 10))
 	}
 }
@@ -1,17 +1,33 @@
 package router
 
 import (
+	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/Shofyan/url-shortener/internal/infrastructure/config"
 	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
 	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
 )
 
+// Rate limit route groups, each with its own limit under app.ratelimitgroups.
+const (
+	RateLimitShorten  = "shorten"
+	RateLimitRedirect = "redirect"
+)
+
 // SetupRouter configures all routes and middleware.
-func SetupRouter(cfg *config.Config, urlHandler *handler.URLHandler, webHandler *handler.WebHandler, rateLimiter *middleware.RateLimiter) *gin.Engine {
+func SetupRouter(
+	cfg *config.Config,
+	urlHandler *handler.URLHandler,
+	analyticsHandler *handler.AnalyticsHandler,
+	webHandler *handler.WebHandler,
+	rateLimiter middleware.Limiter,
+	requestObserver middleware.RequestObserver,
+) *gin.Engine {
 	// Set Gin mode - prioritize environment variable, then config, then default to release
 	if mode := os.Getenv("GIN_MODE"); mode != "" {
 		gin.SetMode(mode)
@@ -25,9 +41,25 @@ func SetupRouter(cfg *config.Config, urlHandler *handler.URLHandler, webHandler
 
 	// Global middleware
 	router.Use(middleware.Recovery())
-	router.Use(middleware.ProcessingTime())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Tracing())
+
+	// Compression holds bodies back until the chain finishes, so it sits outside the
+	// timing middleware whose header is set after the handler has written
+	if cfg.App.CompressionEnabled {
+		router.Use(middleware.Compression(cfg.App.CompressionMinSize))
+	}
+
+	router.Use(middleware.ProcessingTimeWith(
+		middleware.TimingSamplerFor(cfg.App.ProcessingTimeMode, cfg.App.ProcessingTimeSampleRate),
+	))
+	router.Use(middleware.ResponseFormat(cfg.App.ResponseEnvelope, cfg.App.JSONFieldNaming))
 	router.Use(middleware.Logger())
-	router.Use(middleware.CORS())
+	router.Use(middleware.Metrics(requestObserver))
+	router.Use(middleware.CORSWith(corsConfig(cfg)))
+
+	// Preflight requests get 204 on every path, including short keys
+	RegisterPreflight(router)
 
 	// Load HTML templates
 	router.LoadHTMLGlob("web/templates/*")
@@ -37,23 +69,94 @@ func SetupRouter(cfg *config.Config, urlHandler *handler.URLHandler, webHandler
 
 	// Health check endpoint (no rate limiting)
 	router.GET("/health", urlHandler.HealthCheck)
+	router.GET("/health/ready", urlHandler.ReadinessCheck)
 
-	// URL Creation endpoint (POST /)
-	router.POST("/", rateLimiter.Limit(), urlHandler.ShortenURL)
+	// Prometheus scrape endpoint (no rate limiting)
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
-	// Short URL redirect (GET /s/{short_code})
-	router.GET("/s/:shortKey", rateLimiter.Limit(), urlHandler.RedirectURL)
+	// Write routes share one bound on in-flight requests to protect the database pool
+	dbLimit := middleware.ConcurrencyLimit(cfg.App.DBConcurrencyLimit)
 
-	// Short URL redirect (HEAD /s/{short_code}) - for curl -I and similar tools
-	router.HEAD("/s/:shortKey", rateLimiter.Limit(), urlHandler.RedirectURL)
+	// URL Creation endpoint (POST /)
+	router.POST("/",
+		rateLimiter.LimitGroup(RateLimitShorten),
+		dbLimit,
+		middleware.RequireContentType(cfg.App.ShortenContentTypes...),
+		middleware.IdentifyAdmin(cfg.App.AdminToken),
+		urlHandler.ShortenURL,
+	)
+
+	// Batch URL creation; item count is capped per request (see BatchLimits)
+	router.POST("/api/shorten/batch",
+		rateLimiter.LimitGroup(RateLimitShorten),
+		dbLimit,
+		middleware.RequireContentType(gin.MIMEJSON),
+		middleware.IdentifyAdmin(cfg.App.AdminToken),
+		urlHandler.ShortenURLBatch,
+	)
+
+	// Repointing or deleting a short key changes where existing links lead, so both require the admin token
+	router.PUT("/api/urls/:shortKey",
+		rateLimiter.Limit(),
+		dbLimit,
+		middleware.RequireAdmin(cfg.App.AdminToken),
+		middleware.RequireContentType(gin.MIMEJSON),
+		urlHandler.UpdateURLDestination,
+	)
+
+	router.DELETE("/api/urls/:shortKey",
+		rateLimiter.Limit(),
+		dbLimit,
+		middleware.RequireAdmin(cfg.App.AdminToken),
+		urlHandler.DeleteURL,
+	)
+
+	// QR code of the short URL as a PNG, for printing
+	router.GET("/api/urls/:shortKey/qr", rateLimiter.Limit(), urlHandler.GetQRCode)
+
+	// Safe preview of a short URL's destination, without redirecting or counting a visit
+	router.GET("/api/preview/:shortKey", rateLimiter.Limit(), urlHandler.PreviewURL)
+
+	// Destination history is an audit trail, so reading it also requires the admin token
+	router.GET("/api/urls/:shortKey/history",
+		middleware.RequireAdmin(cfg.App.AdminToken),
+		urlHandler.GetDestinationHistory,
+	)
+
+	// Throttled redirects render a page for browsers instead of raw JSON
+	redirectLimit := rateLimiter.LimitGroupWith(RateLimitRedirect, handler.RedirectThrottleResponder(cfg.App.RedirectThrottleResponse))
+
+	// Short URL redirect (GET /s/{short_code}, HEAD for curl -I and similar tools);
+	// other methods get a structured 405 instead of gin's default 404
+	RegisterShortKeyRoutes(router, cfg.App.RedirectMethods, redirectLimit, urlHandler.RedirectURL)
 
 	// Stats endpoint (GET /stats/{short_code})
 	router.GET("/stats/:shortKey", urlHandler.GetStats)
 
-	// Admin routes (no rate limiting for internal monitoring)
-	admin := router.Group("/api/admin")
+	// Stats with click timeline and top referrers in one call
+	router.GET("/api/stats/:shortKey/full", analyticsHandler.GetFullStats)
+
+	// Daily click counts for charts
+	router.GET("/api/analytics/:shortKey/timeseries", analyticsHandler.GetTimeseries)
+
+	// Decode a full short URL (GET /decode?url={short_url})
+	router.GET("/decode", urlHandler.DecodeShortURL)
+
+	// Admin routes (no rate limiting for internal monitoring). They expose or change link
+	// data, runtime settings and caches, so the whole group requires the admin token.
+	admin := router.Group("/api/admin", middleware.RequireAdmin(cfg.App.AdminToken))
 	admin.GET("/cleanup/stats", urlHandler.GetCleanupStats)
-	admin.POST("/cleanup/manual", urlHandler.TriggerManualCleanup)
+	admin.POST("/cleanup/manual", dbLimit, urlHandler.TriggerManualCleanup)
+	admin.GET("/cleanup/config", urlHandler.GetCleanupConfig)
+	admin.PUT("/cleanup/config", urlHandler.UpdateCleanupConfig)
+	admin.GET("/info", urlHandler.GetRuntimeInfo)
+	admin.GET("/selftest", urlHandler.SelfTest)
+	admin.GET("/urls", urlHandler.ListURLs)
+	admin.GET("/analytics/top", analyticsHandler.GetTopURLs)
+	admin.PUT("/urls/:shortKey/enabled", dbLimit, urlHandler.SetURLEnabled)
+	admin.POST("/urls/:shortKey/disable", dbLimit, urlHandler.DisableURL)
+	admin.POST("/cache/flush", urlHandler.FlushCache)
+	admin.POST("/renew", dbLimit, urlHandler.RenewURLs)
 
 	// Web UI routes (serve after API routes to avoid conflicts)
 	router.GET("/web", webHandler.Index)
@@ -61,3 +164,66 @@ func SetupRouter(cfg *config.Config, urlHandler *handler.URLHandler, webHandler
 
 	return router
 }
+
+// corsConfig builds the CORS middleware configuration from the app settings.
+func corsConfig(cfg *config.Config) middleware.CORSConfig {
+	cors := middleware.DefaultCORSConfig()
+	if len(cfg.App.CORSAllowOrigins) > 0 {
+		cors.AllowOrigins = cfg.App.CORSAllowOrigins
+	}
+
+	cors.MaxAge = cfg.App.CORSMaxAge
+
+	return cors
+}
+
+// RegisterPreflight answers OPTIONS on every path with 204. The CORS middleware adds
+// its headers and normally responds first; the route makes preflight independent of
+// which methods a path registers.
+func RegisterPreflight(router gin.IRoutes) {
+	router.OPTIONS("/*path", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+}
+
+// shortKeyMethods are the methods routed on short-key paths; those not allowed to redirect get 405.
+var shortKeyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// RegisterShortKeyRoutes registers /s/:shortKey for every method in shortKeyMethods.
+// Methods in allowed run the redirect handlers; the rest respond 405 with an Allow header.
+// GET is always allowed, and an empty allowlist means GET and HEAD.
+func RegisterShortKeyRoutes(router gin.IRoutes, allowed []string, handlers ...gin.HandlerFunc) {
+	allowedSet := map[string]bool{http.MethodGet: true}
+	if len(allowed) == 0 {
+		allowedSet[http.MethodHead] = true
+	}
+
+	for _, method := range allowed {
+		allowedSet[strings.ToUpper(strings.TrimSpace(method))] = true
+	}
+
+	var allow []string
+
+	for _, method := range shortKeyMethods {
+		if allowedSet[method] {
+			allow = append(allow, method)
+		}
+	}
+
+	notAllowed := handler.MethodNotAllowed(allow)
+
+	for _, method := range shortKeyMethods {
+		if allowedSet[method] {
+			router.Handle(method, "/s/:shortKey", handlers...)
+		} else {
+			router.Handle(method, "/s/:shortKey", notAllowed)
+		}
+	}
+}
@@ -139,7 +139,6 @@ func TestConcurrentClickCountingWithRetries(t *testing.T) {
 
 	// Test with more concurrent requests but reasonable limits
 	concurrentRequests := 200
-	maxRetries := 3
 
 	var wg sync.WaitGroup
 
@@ -155,25 +154,15 @@ func TestConcurrentClickCountingWithRetries(t *testing.T) {
 		go func(requestID int) {
 			defer wg.Done()
 
-			// Retry logic for database contention
-			for retry := 0; retry < maxRetries; retry++ {
-				if err := repo.IncrementVisitCount(ctx, shortKey); err != nil {
-					if retry == maxRetries-1 {
-						t.Logf("Request %d failed after %d retries: %v", requestID, maxRetries, err)
-						return
-					}
-					// Brief backoff before retry
-					time.Sleep(time.Millisecond * time.Duration(retry+1))
-
-					continue
-				}
-
-				successMutex.Lock()
-				successCount++
-				successMutex.Unlock()
-
-				break
+			// The repository retries serialization failures and deadlocks itself
+			if err := repo.IncrementVisitCount(ctx, shortKey); err != nil {
+				t.Logf("Request %d failed: %v", requestID, err)
+				return
 			}
+
+			successMutex.Lock()
+			successCount++
+			successMutex.Unlock()
 		}(i)
 	}
 
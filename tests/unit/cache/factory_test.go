@@ -0,0 +1,47 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/cache"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/cache/memory"
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+func TestNewRepository_Memory(t *testing.T) {
+	repo, err := cache.NewRepository(cache.Config{Backend: cache.BackendMemory}, nil)
+	require.NoError(t, err)
+
+	assert.IsType(t, &memory.CacheRepository{}, repo)
+	assert.False(t, cache.NeedsRedis(cache.BackendMemory))
+}
+
+func TestNewRepository_Redis(t *testing.T) {
+	_, client := newMiniredisClient(t)
+
+	for _, backend := range []string{cache.BackendRedis, ""} {
+		repo, err := cache.NewRepository(cache.Config{Backend: backend, KeyPrefix: "urlshortener:"}, client)
+		require.NoError(t, err)
+
+		assert.IsType(t, &redisCache.CacheRepository{}, repo, "backend %q", backend)
+		assert.True(t, cache.NeedsRedis(backend), "backend %q", backend)
+	}
+}
+
+func TestNewRepository_RedisWithoutClient(t *testing.T) {
+	repo, err := cache.NewRepository(cache.Config{Backend: cache.BackendRedis}, nil)
+
+	assert.ErrorIs(t, err, cache.ErrRedisClientRequired)
+	assert.Nil(t, repo)
+}
+
+func TestNewRepository_UnknownBackend(t *testing.T) {
+	repo, err := cache.NewRepository(cache.Config{Backend: "memcached"}, nil)
+
+	assert.ErrorIs(t, err, cache.ErrUnknownBackend)
+	assert.Contains(t, err.Error(), "memcached")
+	assert.Nil(t, repo)
+}
@@ -0,0 +1,75 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+func newMiniredisClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	return server, client
+}
+
+func TestFlushNamespace_RemovesOnlyPrefixedKeys(t *testing.T) {
+	server, client := newMiniredisClient(t)
+	ctx := context.Background()
+
+	repo := redisCache.NewCacheRepository(client, "urlshortener:")
+
+	// Enough keys to span several SCAN pages
+	for i := 0; i < 1200; i++ {
+		require.NoError(t, repo.Set(ctx, fmt.Sprintf("key%d", i), "https://example.com", time.Hour))
+	}
+
+	require.NoError(t, server.Set("otherservice:session", "keep"))
+	require.NoError(t, server.Set("urlshortener", "keep"))
+
+	err := repo.FlushNamespace(ctx)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"otherservice:session", "urlshortener"}, server.Keys())
+
+	_, err = repo.Get(ctx, "key1")
+	assert.Error(t, err)
+}
+
+func TestFlushNamespace_PrefixIsMatchedLiterally(t *testing.T) {
+	server, client := newMiniredisClient(t)
+	ctx := context.Background()
+
+	repo := redisCache.NewCacheRepository(client, "svc*:")
+
+	require.NoError(t, repo.Set(ctx, "abc", "https://example.com", time.Hour))
+	require.NoError(t, server.Set("svcX:abc", "keep"))
+
+	require.NoError(t, repo.FlushNamespace(ctx))
+
+	assert.Equal(t, []string{"svcX:abc"}, server.Keys())
+}
+
+func TestFlushNamespace_RequiresPrefix(t *testing.T) {
+	server, client := newMiniredisClient(t)
+
+	require.NoError(t, server.Set("anything", "keep"))
+
+	repo := redisCache.NewCacheRepository(client, "")
+
+	err := repo.FlushNamespace(context.Background())
+	assert.ErrorIs(t, err, redisCache.ErrNoNamespace)
+	assert.Equal(t, []string{"anything"}, server.Keys())
+}
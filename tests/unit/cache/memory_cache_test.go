@@ -0,0 +1,96 @@
+package cache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/cache/memory"
+)
+
+func TestMemoryCache_SetGetExpire(t *testing.T) {
+	repo := memory.NewCacheRepository()
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrCacheMiss)
+
+	require.NoError(t, repo.Set(ctx, "forever", "value", 0))
+	require.NoError(t, repo.Set(ctx, "brief", "value", 20*time.Millisecond))
+
+	exists, err := repo.Exists(ctx, "brief")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, err = repo.Get(ctx, "brief")
+	assert.ErrorIs(t, err, repository.ErrCacheMiss)
+
+	exists, err = repo.Exists(ctx, "brief")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	val, err := repo.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	require.NoError(t, repo.Delete(ctx, "forever"))
+	_, err = repo.Get(ctx, "forever")
+	assert.ErrorIs(t, err, repository.ErrCacheMiss)
+}
+
+func TestMemoryCache_EntriesAndTombstones(t *testing.T) {
+	repo := memory.NewCacheRepository()
+	ctx := context.Background()
+
+	require.NoError(t, repo.SetCacheEntry(ctx, "docs", &repository.CacheEntry{LongURL: "https://example.com/docs"}, time.Hour))
+	require.NoError(t, repo.SetTombstone(ctx, "gone", "expired", time.Hour))
+	require.NoError(t, repo.Set(ctx, "legacy", "https://example.com/legacy", time.Hour))
+
+	entry, err := repo.GetCacheEntry(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs", entry.LongURL)
+	assert.False(t, entry.IsTombstone)
+
+	// Entries are stored encoded, so mutating a returned entry leaves the cache untouched
+	entry.LongURL = "https://example.com/changed"
+	again, err := repo.GetCacheEntry(ctx, "docs")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/docs", again.LongURL)
+
+	tombstone, err := repo.GetCacheEntry(ctx, "gone")
+	require.NoError(t, err)
+	assert.True(t, tombstone.IsTombstone)
+	assert.Equal(t, "expired", tombstone.Reason)
+
+	legacy, err := repo.GetCacheEntry(ctx, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/legacy", legacy.LongURL)
+
+	require.NoError(t, repo.FlushNamespace(ctx))
+	_, err = repo.GetCacheEntry(ctx, "docs")
+	assert.ErrorIs(t, err, repository.ErrCacheMiss)
+}
+
+func TestMemoryCache_CountsVisitors(t *testing.T) {
+	repo := memory.NewCacheRepository()
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, repo.AddVisitor(ctx, "abc123", "192.168.1.1", time.Hour))
+		require.NoError(t, repo.AddVisitor(ctx, "abc123", "192.168.1.2", time.Hour))
+	}
+
+	count, err := repo.EstimateVisitors(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	other, err := repo.EstimateVisitors(ctx, "other")
+	require.NoError(t, err)
+	assert.Zero(t, other)
+}
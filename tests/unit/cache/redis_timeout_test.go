@@ -0,0 +1,85 @@
+package cache_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+// startSlowRedis starts a TCP server that accepts connections but never replies,
+// simulating a Redis instance that has stalled.
+func startSlowRedis(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	t.Cleanup(func() {
+		close(done)
+		listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				<-done
+				conn.Close()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestNewClient_AppliesTimeouts(t *testing.T) {
+	client := redisCache.NewClient(redisCache.ClientConfig{
+		Addr:         "localhost:6379",
+		PoolSize:     10,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  150 * time.Millisecond,
+		WriteTimeout: 100 * time.Millisecond,
+	})
+	defer client.Close()
+
+	opts := client.Options()
+	assert.Equal(t, 2*time.Second, opts.DialTimeout)
+	assert.Equal(t, 150*time.Millisecond, opts.ReadTimeout)
+	assert.Equal(t, 100*time.Millisecond, opts.WriteTimeout)
+	assert.Equal(t, 10, opts.PoolSize)
+}
+
+func TestCacheRepository_SlowRedisIsCacheMiss(t *testing.T) {
+	client := redisCache.NewClient(redisCache.ClientConfig{
+		Addr:         startSlowRedis(t),
+		DialTimeout:  time.Second,
+		ReadTimeout:  50 * time.Millisecond,
+		WriteTimeout: 50 * time.Millisecond,
+	})
+	defer client.Close()
+
+	repo := redisCache.NewCacheRepository(client, "test:")
+
+	start := time.Now()
+	entry, err := repo.GetCacheEntry(context.Background(), "abc123")
+	elapsed := time.Since(start)
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, redisCache.ErrCacheMiss)
+	assert.Less(t, elapsed, time.Second, "lookup should be bounded by the command timeout")
+
+	_, err = repo.Get(context.Background(), "abc123")
+	assert.ErrorIs(t, err, redisCache.ErrCacheMiss)
+}
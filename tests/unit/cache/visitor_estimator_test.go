@@ -0,0 +1,59 @@
+package cache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+func TestEstimateVisitors_WithinErrorBounds(t *testing.T) {
+	server, client := newMiniredisClient(t)
+	ctx := context.Background()
+
+	repo := redisCache.NewCacheRepository(client, "urlshortener:")
+
+	const distinct = 5000
+
+	// Every visitor comes back three times; repeats must not inflate the estimate
+	for round := 0; round < 3; round++ {
+		for i := 0; i < distinct; i++ {
+			ip := fmt.Sprintf("10.%d.%d.%d", i>>16&0xff, i>>8&0xff, i&0xff)
+			require.NoError(t, repo.AddVisitor(ctx, "abc123", ip, time.Hour))
+		}
+	}
+
+	estimate, err := repo.EstimateVisitors(ctx, "abc123")
+	require.NoError(t, err)
+
+	// HyperLogLog's standard error is 0.81%; allow three standard errors
+	assert.InEpsilon(t, distinct, estimate, 3*0.0081)
+
+	// Estimates are per short key and expire with the configured TTL
+	other, err := repo.EstimateVisitors(ctx, "other")
+	require.NoError(t, err)
+	assert.Zero(t, other)
+
+	assert.Equal(t, time.Hour, server.TTL("urlshortener:visitors:abc123"))
+}
+
+func TestEstimateVisitors_CountsEachVisitorOnce(t *testing.T) {
+	_, client := newMiniredisClient(t)
+	ctx := context.Background()
+
+	repo := redisCache.NewCacheRepository(client, "urlshortener:")
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, repo.AddVisitor(ctx, "abc123", "192.168.1.1", time.Hour))
+		require.NoError(t, repo.AddVisitor(ctx, "abc123", "192.168.1.2", time.Hour))
+	}
+
+	estimate, err := repo.EstimateVisitors(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), estimate)
+}
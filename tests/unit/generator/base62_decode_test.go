@@ -0,0 +1,111 @@
+package generator_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+)
+
+func TestBase62Decode_RejectsOverLengthKey(t *testing.T) {
+	gen := base62.NewGenerator()
+	require.Equal(t, 11, gen.MaxKeyLength())
+
+	// A valid 12-character short key, but longer than any encoded int64
+	shortKey, err := valueobject.NewShortKey(strings.Repeat("3", 12))
+	require.NoError(t, err)
+
+	_, err = gen.DecodeToID(shortKey)
+	assert.ErrorIs(t, err, base62.ErrKeyTooLong)
+}
+
+func TestBase62Decode_RejectsValueBeyondInt64(t *testing.T) {
+	gen := base62.NewGenerator()
+
+	// Same length as the encoding of MaxInt64, but larger
+	shortKey, err := valueobject.NewShortKey(strings.Repeat("z", gen.MaxKeyLength()))
+	require.NoError(t, err)
+
+	_, err = gen.DecodeToID(shortKey)
+	assert.ErrorIs(t, err, base62.ErrIDOutOfRange)
+}
+
+func TestBase62Decode_MaxInt64RoundTrips(t *testing.T) {
+	gen := base62.NewGenerator()
+
+	shortKey, err := gen.GenerateFromID(math.MaxInt64)
+	require.NoError(t, err)
+
+	id, err := gen.DecodeToID(shortKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), id)
+}
+
+func TestBase62Decode_SmallAlphabetUsesShortKeyLimit(t *testing.T) {
+	// Digits need 19 characters for MaxInt64, so the short key limit bounds the length instead
+	gen, err := base62.NewGeneratorWithAlphabet("0123456789")
+	require.NoError(t, err)
+
+	shortKey, err := valueobject.NewShortKey("999999999999")
+	require.NoError(t, err)
+
+	id, err := gen.DecodeToID(shortKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(999999999999), id)
+}
+
+func TestBase62Decode_RejectsCharacterOutsideAlphabet(t *testing.T) {
+	gen := base62.NewGenerator()
+
+	// '0' is excluded from the default alphabet for readability
+	shortKey, err := valueobject.NewShortKey("abc0")
+	require.NoError(t, err)
+
+	_, err = gen.DecodeToID(shortKey)
+	assert.ErrorIs(t, err, valueobject.ErrInvalidShortKey)
+}
+
+func TestBase62Decode_RoundTripsExactly(t *testing.T) {
+	gen := base62.NewGenerator()
+	base := int64(len(gen.Alphabet()))
+
+	ids := []int64{0, 1, base - 1}
+
+	// Both sides of every power of the base that fits in int64, where key length changes
+	for power := base; power > 0 && power <= math.MaxInt64/base; power *= base {
+		ids = append(ids, power-1, power, power+1)
+	}
+
+	// Snowflake IDs are well beyond 2^53, where float64 arithmetic loses the low bits
+	ids = append(ids,
+		1541815603606036480,
+		1800000000000000001,
+		1<<53+1,
+		1<<62-1,
+		math.MaxInt64-1,
+	)
+
+	snowflakeGen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		id, err := snowflakeGen.Generate()
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		shortKey, err := gen.GenerateFromID(id)
+		require.NoError(t, err, id)
+
+		decoded, err := gen.DecodeToID(shortKey)
+		require.NoError(t, err, id)
+		assert.Equal(t, id, decoded, "key %s", shortKey.Value())
+	}
+}
@@ -0,0 +1,78 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+)
+
+func TestBase62MinLength_PadsShortKeys(t *testing.T) {
+	for _, minLength := range []int{1, 6, 8} {
+		gen, err := base62.NewGeneratorWithMinLength(minLength)
+		require.NoError(t, err)
+
+		for _, id := range []int64{0, 5, 53, 54, 12345} {
+			shortKey, err := gen.GenerateFromID(id)
+			require.NoError(t, err)
+
+			assert.GreaterOrEqual(t, len(shortKey.Value()), minLength, "id %d", id)
+
+			// Padded keys are still valid short keys
+			_, err = valueobject.NewShortKey(shortKey.Value())
+			require.NoError(t, err)
+
+			decoded, err := gen.DecodeToID(shortKey)
+			require.NoError(t, err)
+			assert.Equal(t, id, decoded, "min %d, key %s", minLength, shortKey.Value())
+		}
+	}
+}
+
+func TestBase62MinLength_PadsWithZeroCharacter(t *testing.T) {
+	gen, err := base62.NewGeneratorWithMinLength(6)
+	require.NoError(t, err)
+
+	shortKey, err := gen.GenerateFromID(5)
+	require.NoError(t, err)
+
+	// 5 encodes as the alphabet's sixth character, "7"
+	assert.Equal(t, "222227", shortKey.Value())
+
+	// Keys already longer than the minimum are unchanged
+	unpadded, err := base62.NewGenerator().GenerateFromID(1541815603606036480)
+	require.NoError(t, err)
+
+	padded, err := gen.GenerateFromID(1541815603606036480)
+	require.NoError(t, err)
+	assert.Equal(t, unpadded.Value(), padded.Value())
+}
+
+func TestBase62MinLength_CustomPadCharacter(t *testing.T) {
+	gen, err := base62.NewGeneratorWithMinLength(8)
+	require.NoError(t, err)
+	require.NoError(t, gen.SetPadChar('_'))
+
+	for _, id := range []int64{0, 5, 12345} {
+		shortKey, err := gen.GenerateFromID(id)
+		require.NoError(t, err)
+		assert.Len(t, shortKey.Value(), 8)
+		assert.Equal(t, byte('_'), shortKey.Value()[0])
+
+		decoded, err := gen.DecodeToID(shortKey)
+		require.NoError(t, err)
+		assert.Equal(t, id, decoded)
+	}
+
+	// A non-zero alphabet character would change the decoded value
+	assert.ErrorIs(t, gen.SetPadChar('A'), base62.ErrInvalidPadChar)
+	assert.ErrorIs(t, gen.SetPadChar('!'), base62.ErrInvalidPadChar)
+}
+
+func TestBase62MinLength_RejectsLengthBeyondKeyLimit(t *testing.T) {
+	_, err := base62.NewGeneratorWithMinLength(valueobject.MaxShortKeyLength + 1)
+	assert.ErrorIs(t, err, base62.ErrInvalidMinLength)
+}
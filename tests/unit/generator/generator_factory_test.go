@@ -0,0 +1,149 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+)
+
+const alphanumerics = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+func customConfig(alphabet string) generator.Config {
+	return generator.Config{
+		Strategy:    generator.StrategyBase62,
+		KeyProfile:  base62.ProfileCustom,
+		KeyAlphabet: alphabet,
+		Random:      testConfig(),
+	}
+}
+
+func TestGeneratorFactory_AcceptsCustomAlphabets(t *testing.T) {
+	testCases := []struct {
+		name     string
+		alphabet string
+	}{
+		{"61 characters", alphanumerics[:61]},
+		{"63 characters", alphanumerics + "_"},
+	}
+
+	idGen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genService, err := generator.NewGeneratorServiceFromConfig(customConfig(tc.alphabet), idGen, newFakeKeyspace(nil))
+			require.NoError(t, err)
+
+			shortKey, id, err := genService.GenerateShortKey()
+			require.NoError(t, err)
+			assertWithinAlphabet(t, tc.alphabet, shortKey.Value())
+
+			gen, err := base62.NewGeneratorWithAlphabet(tc.alphabet)
+			require.NoError(t, err)
+
+			decoded, err := gen.DecodeToID(shortKey)
+			require.NoError(t, err)
+			assert.Equal(t, id, decoded)
+		})
+	}
+}
+
+func TestGeneratorFactory_RejectsDuplicateCharacter(t *testing.T) {
+	// 'a' repeated in place of the final 'z'
+	alphabet := alphanumerics[:61] + "a"
+
+	_, err := generator.NewShortKeyGenerator(customConfig(alphabet), newFakeKeyspace(nil))
+	require.ErrorIs(t, err, base62.ErrInvalidAlphabet)
+	assert.Contains(t, err.Error(), "duplicate character 'a' at index 61 (first seen at index 36)")
+}
+
+func TestGeneratorFactory_RejectsDisallowedCharacter(t *testing.T) {
+	_, err := generator.NewShortKeyGenerator(customConfig("abc.def"), newFakeKeyspace(nil))
+	require.ErrorIs(t, err, base62.ErrInvalidAlphabet)
+	assert.Contains(t, err.Error(), "character '.' at index 3")
+}
+
+func TestGeneratorFactory_RejectsAlphabetTooSmallForBase62(t *testing.T) {
+	cfg := customConfig("")
+	cfg.KeyProfile = base62.ProfileDigits
+
+	_, err := generator.NewShortKeyGenerator(cfg, newFakeKeyspace(nil))
+	require.ErrorIs(t, err, generator.ErrAlphabetTooSmall)
+
+	// Random keys have a fixed length, so any valid alphabet works
+	cfg.Strategy = generator.StrategyRandom
+
+	_, err = generator.NewShortKeyGenerator(cfg, newFakeKeyspace(nil))
+	assert.NoError(t, err)
+}
+
+func TestGeneratorFactory_BuildsGeneratorPerStrategy(t *testing.T) {
+	testCases := []struct {
+		strategy string
+		random   bool
+	}{
+		{"", false},
+		{generator.StrategyBase62, false},
+		{generator.StrategySnowflakeBase62, false},
+		{" Snowflake-Base62 ", false},
+		{generator.StrategyRandom, true},
+	}
+
+	idGen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.strategy, func(t *testing.T) {
+			cfg := generator.Config{Strategy: tc.strategy, Random: testConfig()}
+
+			gen, err := generator.NewShortKeyGenerator(cfg, newFakeKeyspace(nil))
+			require.NoError(t, err)
+
+			if tc.random {
+				assert.IsType(t, &random.Generator{}, gen)
+			} else {
+				assert.IsType(t, &base62.Generator{}, gen)
+			}
+
+			// Only random keys are checked against the database by the generator itself
+			genService, err := generator.NewGeneratorServiceFromConfig(cfg, idGen, newFakeKeyspace(nil))
+			require.NoError(t, err)
+			assert.Equal(t, tc.random, genService.ChecksExistence())
+		})
+	}
+}
+
+func TestGeneratorFactory_RandomStrategyDrawsSevenCharacterKeys(t *testing.T) {
+	cfg := generator.Config{Strategy: generator.StrategyRandom, Random: random.DefaultConfig()}
+
+	gen, err := generator.NewShortKeyGenerator(cfg, newFakeKeyspace(nil))
+	require.NoError(t, err)
+
+	alphabet, err := base62.AlphabetForProfile(base62.ProfileDefault, "")
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		key, err := gen.GenerateFromID(int64(i))
+		require.NoError(t, err)
+
+		assert.Len(t, key.Value(), 7)
+
+		for _, char := range key.Value() {
+			assert.Contains(t, alphabet, string(char))
+		}
+	}
+}
+
+func TestGeneratorFactory_RejectsUnknownStrategy(t *testing.T) {
+	cfg := customConfig(alphanumerics)
+	cfg.Strategy = "sequential"
+
+	_, err := generator.NewShortKeyGenerator(cfg, newFakeKeyspace(nil))
+	assert.ErrorIs(t, err, generator.ErrUnknownStrategy)
+}
@@ -0,0 +1,98 @@
+package generator_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/blocklist"
+)
+
+// idSequence is an IDGenerator that returns ids in order, then repeats the last one.
+type idSequence struct {
+	ids  []int64
+	next int
+}
+
+func (s *idSequence) Generate() (int64, error) {
+	id := s.ids[s.next]
+	if s.next < len(s.ids)-1 {
+		s.next++
+	}
+
+	return id, nil
+}
+
+// idForKey returns the ID the default Base62 generator encodes as key.
+func idForKey(t *testing.T, key string) int64 {
+	t.Helper()
+
+	shortKey, err := valueobject.NewShortKey(key)
+	require.NoError(t, err)
+
+	id, err := base62.NewGenerator().DecodeToID(shortKey)
+	require.NoError(t, err)
+
+	return id
+}
+
+func TestBlocklist_MatchesWordsAndLookAlikes(t *testing.T) {
+	b := blocklist.New("Zurb")
+
+	assert.Greater(t, b.Len(), 1)
+	assert.True(t, b.Blocks("xxCRAPxx"))
+	assert.True(t, b.Blocks("cr4p22"))
+	assert.True(t, b.Blocks("22zurb"), "words added from configuration are blocked")
+	assert.False(t, b.Blocks("Ab3xK9"))
+	assert.False(t, blocklist.New("  ", "").Blocks("Ab3xK9"), "blank additions block nothing")
+}
+
+func TestGeneratorService_RegeneratesBlockedKeys(t *testing.T) {
+	// The first ID drawn encodes to a blocked word
+	blockedID := idForKey(t, "crap22")
+	cleanID := idForKey(t, "Ab3xK9")
+
+	genService := service.NewGeneratorService(&idSequence{ids: []int64{blockedID, cleanID}}, base62.NewGenerator())
+
+	// Without a blocklist the key is handed out as generated
+	shortKey, id, err := genService.GenerateShortKey()
+	require.NoError(t, err)
+	assert.Equal(t, "crap22", shortKey.Value())
+	assert.Equal(t, blockedID, id)
+
+	genService = service.NewGeneratorService(&idSequence{ids: []int64{blockedID, cleanID}}, base62.NewGenerator())
+	genService.SetBlocklist(blocklist.New())
+
+	shortKey, id, err = genService.GenerateShortKey()
+	require.NoError(t, err)
+	assert.Equal(t, "Ab3xK9", shortKey.Value())
+	assert.Equal(t, cleanID, id)
+}
+
+func TestGeneratorService_GivesUpWhenEveryKeyIsBlocked(t *testing.T) {
+	genService := service.NewGeneratorService(&idSequence{ids: []int64{idForKey(t, "crap22")}}, base62.NewGenerator())
+	genService.SetBlocklist(blocklist.New())
+
+	_, _, err := genService.GenerateShortKey()
+	assert.ErrorIs(t, err, service.ErrKeyspaceExhausted)
+}
+
+func TestGeneratorServiceFromConfig_AppliesBlocklist(t *testing.T) {
+	ids := &idSequence{ids: []int64{idForKey(t, "22zurb"), idForKey(t, "Ab3xK9")}}
+
+	genService, err := generator.NewGeneratorServiceFromConfig(generator.Config{
+		Blocklist:    true,
+		Random:       testConfig(),
+		BlockedWords: []string{"zurb"},
+	}, ids, newFakeKeyspace(nil))
+	require.NoError(t, err)
+
+	shortKey, _, err := genService.GenerateShortKey()
+	require.NoError(t, err)
+	assert.Equal(t, "Ab3xK9", shortKey.Value())
+}
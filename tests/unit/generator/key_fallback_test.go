@@ -0,0 +1,67 @@
+package generator_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+)
+
+// fixedID is an IDGenerator that always returns the same ID.
+type fixedID int64
+
+func (id fixedID) Generate() (int64, error) {
+	return int64(id), nil
+}
+
+func TestGeneratorService_FallsBackWhenKeyTooLong(t *testing.T) {
+	// Decimal keys for IDs near the int64 limit need 19 characters
+	digits, err := base62.NewGeneratorWithAlphabet("0123456789")
+	require.NoError(t, err)
+
+	_, err = digits.GenerateFromID(math.MaxInt64)
+	require.ErrorIs(t, err, base62.ErrEncodedTooLong)
+
+	fallback, err := random.NewGenerator(newFakeKeyspace(nil), testConfig())
+	require.NoError(t, err)
+
+	genService := service.NewGeneratorService(fixedID(math.MaxInt64), digits)
+	genService.SetFallback(fallback)
+
+	shortKey, id, err := genService.GenerateShortKey()
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), id)
+	assert.LessOrEqual(t, len(shortKey.Value()), valueobject.MaxShortKeyLength)
+
+	_, err = valueobject.NewShortKey(shortKey.Value())
+	assert.NoError(t, err)
+}
+
+func TestGeneratorService_KeepsPrimaryKeyWithinLimit(t *testing.T) {
+	digits, err := base62.NewGeneratorWithAlphabet("0123456789")
+	require.NoError(t, err)
+
+	fallback, err := random.NewGenerator(newFakeKeyspace(nil), testConfig())
+	require.NoError(t, err)
+
+	genService := service.NewGeneratorService(fixedID(12345), digits)
+	genService.SetFallback(fallback)
+
+	shortKey, _, err := genService.GenerateShortKey()
+	require.NoError(t, err)
+	assert.Equal(t, "12345", shortKey.Value())
+}
+
+func TestGeneratorService_KeyTooLongWithoutFallback(t *testing.T) {
+	digits, err := base62.NewGeneratorWithAlphabet("0123456789")
+	require.NoError(t, err)
+
+	_, _, err = service.NewGeneratorService(fixedID(math.MaxInt64), digits).GenerateShortKey()
+	assert.ErrorIs(t, err, service.ErrShortKeyTooLong)
+}
@@ -0,0 +1,125 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+)
+
+func assertWithinAlphabet(t *testing.T, alphabet, key string) {
+	t.Helper()
+
+	for _, char := range key {
+		assert.True(t, strings.ContainsRune(alphabet, char), "key %q has %q outside %q", key, char, alphabet)
+	}
+}
+
+func TestKeyProfiles_Base62KeysStayWithinAlphabet(t *testing.T) {
+	testCases := []struct {
+		profile string
+		custom  string
+		allowed string
+	}{
+		{base62.ProfileDefault, "", "23456789ABCDEFGHJKMNPQRSTUVWXYZabcdefghjkmnpqrstuvwxyz"},
+		{base62.ProfileLowercase, "", "23456789abcdefghjkmnpqrstuvwxyz"},
+		{base62.ProfileNoVowels, "", "23456789BCDFGHJKMNPQRSTVWXYZbcdfghjkmnpqrstvwxyz"},
+		{base62.ProfileDigits, "", "0123456789"},
+		{base62.ProfileCustom, "xyz-_89", "xyz-_89"},
+	}
+
+	ids := []int64{0, 1, 61, 62, 12345, 987654321, 1 << 30}
+
+	for _, tc := range testCases {
+		t.Run(tc.profile, func(t *testing.T) {
+			alphabet, err := base62.AlphabetForProfile(tc.profile, tc.custom)
+			require.NoError(t, err)
+			assert.Equal(t, tc.allowed, alphabet)
+
+			gen, err := base62.NewGeneratorWithAlphabet(alphabet)
+			require.NoError(t, err)
+
+			for _, id := range ids {
+				key, err := gen.GenerateFromID(id)
+				require.NoError(t, err)
+				assertWithinAlphabet(t, tc.allowed, key.Value())
+
+				// Bijective: every key decodes back to its ID
+				decoded, err := gen.DecodeToID(key)
+				require.NoError(t, err)
+				assert.Equal(t, id, decoded)
+			}
+		})
+	}
+}
+
+func TestKeyProfiles_NoVowelsHasNoVowels(t *testing.T) {
+	alphabet, err := base62.AlphabetForProfile(base62.ProfileNoVowels, "")
+	require.NoError(t, err)
+	assert.False(t, strings.ContainsAny(alphabet, "aeiouAEIOU"))
+}
+
+func TestKeyProfiles_RandomKeysStayWithinAlphabet(t *testing.T) {
+	for _, profile := range []string{base62.ProfileLowercase, base62.ProfileNoVowels, base62.ProfileDigits} {
+		t.Run(profile, func(t *testing.T) {
+			alphabet, err := base62.AlphabetForProfile(profile, "")
+			require.NoError(t, err)
+
+			cfg := testConfig()
+			cfg.Alphabet = alphabet
+
+			gen, err := random.NewGenerator(newFakeKeyspace(nil), cfg)
+			require.NoError(t, err)
+
+			for i := 0; i < 50; i++ {
+				key, err := gen.GenerateFromID(0)
+				require.NoError(t, err)
+				assertWithinAlphabet(t, alphabet, key.Value())
+			}
+		})
+	}
+}
+
+func TestKeyProfiles_EmptyProfileIsDefault(t *testing.T) {
+	alphabet, err := base62.AlphabetForProfile("", "")
+	require.NoError(t, err)
+	assert.Equal(t, base62.NewGenerator().Alphabet(), alphabet)
+}
+
+func TestKeyProfiles_RejectsInvalidAlphabets(t *testing.T) {
+	testCases := []struct {
+		name    string
+		profile string
+		custom  string
+		err     error
+	}{
+		{"Unknown profile", "emoji", "", base62.ErrUnknownProfile},
+		{"Empty custom alphabet", base62.ProfileCustom, "", base62.ErrInvalidAlphabet},
+		{"Single character", base62.ProfileCustom, "a", base62.ErrInvalidAlphabet},
+		{"Duplicate characters", base62.ProfileCustom, "abca", base62.ErrInvalidAlphabet},
+		{"Character not allowed in keys", base62.ProfileCustom, "ab/", base62.ErrInvalidAlphabet},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := base62.AlphabetForProfile(tc.profile, tc.custom)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	_, err := base62.NewGeneratorWithAlphabet("z")
+	assert.ErrorIs(t, err, base62.ErrInvalidAlphabet)
+}
+
+func TestKeyProfiles_MaxKeyLength(t *testing.T) {
+	// Snowflake IDs span the int64 range: the default alphabet fits a short key, digits do not
+	assert.LessOrEqual(t, base62.NewGenerator().MaxKeyLength(), 12)
+
+	digits, err := base62.NewGeneratorWithAlphabet("0123456789")
+	require.NoError(t, err)
+	assert.Equal(t, 19, digits.MaxKeyLength())
+}
@@ -0,0 +1,160 @@
+package generator_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+)
+
+// fakeKeyspace simulates storage where a fixed fraction of keys at each length is taken.
+// Collisions are spread evenly and deterministically: at 0.5 occupancy every
+// second check at that length reports the key as taken, at 0.05 every twentieth.
+type fakeKeyspace struct {
+	mu        sync.Mutex
+	occupancy map[int]float64
+	checks    map[int]int
+}
+
+func newFakeKeyspace(occupancy map[int]float64) *fakeKeyspace {
+	return &fakeKeyspace{occupancy: occupancy, checks: make(map[int]int)}
+}
+
+func (f *fakeKeyspace) ExistsByShortKey(_ context.Context, shortKey *valueobject.ShortKey) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	length := len(shortKey.Value())
+	n := f.checks[length]
+	f.checks[length]++
+
+	occupancy := f.occupancy[length]
+
+	return int(float64(n+1)*occupancy) > int(float64(n)*occupancy), nil
+}
+
+// testConfig starts keys at 6 characters so growth is observed at 7.
+func testConfig() random.Config {
+	cfg := random.DefaultConfig()
+	cfg.MinLength = 6
+	cfg.WindowSize = 20
+
+	return cfg
+}
+
+func TestRandomGenerator_StartsAtMinLength(t *testing.T) {
+	gen, err := random.NewGenerator(newFakeKeyspace(nil), testConfig())
+	require.NoError(t, err)
+
+	for i := 0; i < 50; i++ {
+		key, err := gen.GenerateFromID(int64(i))
+		require.NoError(t, err)
+		assert.Len(t, key.Value(), 6)
+		assert.NotContains(t, key.Value(), "0")
+		assert.NotContains(t, key.Value(), "O")
+	}
+
+	assert.Equal(t, 6, gen.Length())
+}
+
+func TestRandomGenerator_GrowsAsKeyspaceFills(t *testing.T) {
+	// Half of all 6-character keys are taken, 7-character keys are free
+	keyspace := newFakeKeyspace(map[int]float64{6: 0.5})
+
+	gen, err := random.NewGenerator(keyspace, testConfig())
+	require.NoError(t, err)
+
+	lengths := make(map[int]int)
+
+	for i := 0; i < 100; i++ {
+		key, err := gen.GenerateFromID(int64(i))
+		require.NoError(t, err)
+
+		lengths[len(key.Value())]++
+	}
+
+	assert.Equal(t, 7, gen.Length(), "length should grow once collisions exceed the threshold")
+	assert.Greater(t, lengths[6], 0, "early keys stay short")
+	assert.Greater(t, lengths[7], lengths[6], "later keys use the longer length")
+}
+
+func TestRandomGenerator_LowCollisionRateKeepsLength(t *testing.T) {
+	// 5% collisions is below the 10% threshold
+	keyspace := newFakeKeyspace(map[int]float64{6: 0.05})
+
+	gen, err := random.NewGenerator(keyspace, testConfig())
+	require.NoError(t, err)
+
+	for i := 0; i < 200; i++ {
+		_, err := gen.GenerateFromID(int64(i))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 6, gen.Length())
+}
+
+func TestRandomGenerator_StopsGrowingAtMaxLength(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxLength = 7
+	cfg.WindowSize = 5
+
+	// Every key is taken at every length
+	keyspace := newFakeKeyspace(map[int]float64{6: 1, 7: 1})
+
+	gen, err := random.NewGenerator(keyspace, cfg)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := gen.GenerateFromID(int64(i))
+		assert.ErrorIs(t, err, random.ErrKeyspaceExhausted)
+	}
+
+	assert.Equal(t, 7, gen.Length())
+}
+
+func TestRandomGenerator_AttemptsCappedWithBackoff(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxAttempts = 4
+	cfg.CollisionBackoff = 10 * time.Millisecond
+
+	keyspace := newFakeKeyspace(map[int]float64{6: 1})
+
+	gen, err := random.NewGenerator(keyspace, cfg)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = gen.GenerateFromID(1)
+
+	assert.ErrorIs(t, err, service.ErrKeyspaceExhausted)
+	assert.Equal(t, 4, keyspace.checks[6], "should stop after MaxAttempts checks")
+	// Backoff runs between attempts, not after the last one
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestRandomGenerator_InvalidConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.MinLength = 8
+	cfg.MaxLength = 6
+
+	_, err := random.NewGenerator(newFakeKeyspace(nil), cfg)
+	assert.ErrorIs(t, err, random.ErrInvalidConfig)
+
+	_, err = random.NewGenerator(nil, testConfig())
+	assert.ErrorIs(t, err, random.ErrInvalidConfig)
+}
+
+func TestRandomGenerator_DecodeNotSupported(t *testing.T) {
+	gen, err := random.NewGenerator(newFakeKeyspace(nil), testConfig())
+	require.NoError(t, err)
+
+	key, _ := valueobject.NewShortKey("abc123")
+	_, err = gen.DecodeToID(key)
+	assert.ErrorIs(t, err, random.ErrNotDecodable)
+}
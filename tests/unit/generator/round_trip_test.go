@@ -0,0 +1,69 @@
+package generator_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/random"
+)
+
+// skewedKeys wraps a ShortKeyGenerator and decodes every key to the wrong ID, like an
+// alphabet that changed between encoding and decoding.
+type skewedKeys struct {
+	service.ShortKeyGenerator
+}
+
+func (g skewedKeys) DecodeToID(shortKey *valueobject.ShortKey) (int64, error) {
+	id, err := g.ShortKeyGenerator.DecodeToID(shortKey)
+	return id + 1, err
+}
+
+// undecodableKeys wraps a ShortKeyGenerator and fails every decode.
+type undecodableKeys struct {
+	service.ShortKeyGenerator
+}
+
+func (undecodableKeys) DecodeToID(*valueobject.ShortKey) (int64, error) {
+	return 0, errors.New("corrupt alphabet")
+}
+
+func TestGeneratorService_VerifyRoundTripBase62(t *testing.T) {
+	genService := service.NewGeneratorService(fixedID(0), base62.NewGenerator())
+
+	for _, id := range []int64{0, 1, 61, 62, 12345, 1 << 40, 1541815603606036480, math.MaxInt64} {
+		ok, err := genService.VerifyRoundTrip(id)
+		require.NoError(t, err, "id %d", id)
+		assert.True(t, ok, "id %d", id)
+	}
+}
+
+func TestGeneratorService_VerifyRoundTripDetectsMismatch(t *testing.T) {
+	genService := service.NewGeneratorService(fixedID(0), skewedKeys{base62.NewGenerator()})
+
+	ok, err := genService.VerifyRoundTrip(12345)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGeneratorService_VerifyRoundTripSurfacesDecodeErrors(t *testing.T) {
+	genService := service.NewGeneratorService(fixedID(0), undecodableKeys{base62.NewGenerator()})
+
+	ok, err := genService.VerifyRoundTrip(12345)
+	assert.False(t, ok)
+	assert.ErrorContains(t, err, "corrupt alphabet")
+
+	// Random keys carry no ID, which callers can tell apart from a broken generator
+	randomKeys, err := random.NewGenerator(newFakeKeyspace(nil), testConfig())
+	require.NoError(t, err)
+
+	ok, err = service.NewGeneratorService(fixedID(0), randomKeys).VerifyRoundTrip(12345)
+	assert.False(t, ok)
+	assert.ErrorIs(t, err, service.ErrNotDecodable)
+}
@@ -0,0 +1,117 @@
+package generator_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+)
+
+// fakeClock is a settable clock for the Snowflake generator.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newClockedGenerator(t *testing.T) (*snowflake.Generator, *fakeClock) {
+	t.Helper()
+
+	gen, err := snowflake.NewGenerator(5)
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Now().Round(0).Truncate(time.Millisecond)}
+	gen.SetClock(clock.Now)
+
+	return gen, clock
+}
+
+func TestSnowflakeClock_MovedBackwardsFails(t *testing.T) {
+	gen, clock := newClockedGenerator(t)
+
+	first, err := gen.Generate()
+	require.NoError(t, err)
+
+	clock.Add(-5 * time.Millisecond)
+
+	_, err = gen.Generate()
+	assert.ErrorIs(t, err, snowflake.ErrClockMovedBackwards)
+
+	// Once the clock catches up, IDs resume and stay ordered
+	clock.Add(6 * time.Millisecond)
+
+	next, err := gen.Generate()
+	require.NoError(t, err)
+	assert.Greater(t, next, first)
+}
+
+func TestSnowflakeClock_SequenceResetsWhenMillisecondAdvances(t *testing.T) {
+	gen, clock := newClockedGenerator(t)
+
+	for i := int64(0); i < 3; i++ {
+		id, err := gen.Generate()
+		require.NoError(t, err)
+
+		parts, err := gen.Parse(id)
+		require.NoError(t, err)
+		assert.Equal(t, i, parts.Sequence)
+	}
+
+	clock.Add(time.Millisecond)
+
+	id, err := gen.Generate()
+	require.NoError(t, err)
+
+	parts, err := gen.Parse(id)
+	require.NoError(t, err)
+	assert.Zero(t, parts.Sequence)
+}
+
+func TestSnowflakeClock_SequenceOverflowRollsIntoNextMillisecond(t *testing.T) {
+	gen, clock := newClockedGenerator(t)
+
+	seen := make(map[int64]bool)
+
+	var last int64
+
+	// The clock is frozen: 4096 IDs fill the millisecond, the next ones borrow the following one
+	for i := 0; i < 4096+10; i++ {
+		id, err := gen.Generate()
+		require.NoError(t, err)
+		require.False(t, seen[id], "duplicate ID %d", id)
+		require.Greater(t, id, last)
+
+		seen[id] = true
+		last = id
+	}
+
+	parts, err := gen.Parse(last)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), parts.Sequence)
+	assert.Equal(t, clock.Now().Add(time.Millisecond).UnixMilli(), parts.Timestamp.UnixMilli())
+
+	// When the clock reaches the borrowed millisecond, the sequence continues rather than repeating
+	clock.Add(time.Millisecond)
+
+	id, err := gen.Generate()
+	require.NoError(t, err)
+	assert.False(t, seen[id])
+	assert.Greater(t, id, last)
+}
@@ -0,0 +1,148 @@
+package generator_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+)
+
+// frozenGenerators returns generators for nodeIDs that all read the same stopped clock.
+func frozenGenerators(t *testing.T, now time.Time, nodeIDs ...int64) []*snowflake.Generator {
+	t.Helper()
+
+	generators := make([]*snowflake.Generator, len(nodeIDs))
+
+	for i, nodeID := range nodeIDs {
+		gen, err := snowflake.NewGenerator(nodeID)
+		require.NoError(t, err)
+
+		gen.SetClock(func() time.Time { return now })
+		generators[i] = gen
+	}
+
+	return generators
+}
+
+func TestMultiNodeGenerator_UniqueAcrossNodes(t *testing.T) {
+	multi, err := snowflake.NewMultiNodeGenerator(frozenGenerators(t, time.Now(), 1, 2, 3, 4)...)
+	require.NoError(t, err)
+
+	const workers, perWorker = 8, 5000
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		ids = make(map[int64]bool, workers*perWorker)
+	)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < perWorker; i++ {
+				id, err := multi.Generate()
+				assert.NoError(t, err)
+
+				mu.Lock()
+				ids[id] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Len(t, ids, workers*perWorker)
+}
+
+func TestMultiNodeGenerator_HigherThroughputThanSingleNodeUnderExhaustion(t *testing.T) {
+	now := time.Now().Round(0).Truncate(time.Millisecond)
+	const batch = 3 * 4096
+
+	// With the clock stopped, one node exhausts its sequence and borrows future milliseconds
+	single := frozenGenerators(t, now, 1)[0]
+
+	var lastSingle int64
+	for i := 0; i < batch; i++ {
+		id, err := single.Generate()
+		require.NoError(t, err)
+
+		lastSingle = id
+	}
+
+	singleParts, err := single.Parse(lastSingle)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Millisecond).UnixMilli(), singleParts.Timestamp.UnixMilli())
+
+	// Three nodes absorb the same batch within the current millisecond
+	generators := frozenGenerators(t, now, 1, 2, 3)
+	multi, err := snowflake.NewMultiNodeGenerator(generators...)
+	require.NoError(t, err)
+
+	perNode := make(map[int64]int)
+
+	for i := 0; i < batch; i++ {
+		id, err := multi.Generate()
+		require.NoError(t, err)
+
+		parts, err := generators[0].Parse(id)
+		require.NoError(t, err)
+		assert.Equal(t, now.UnixMilli(), parts.Timestamp.UnixMilli())
+
+		perNode[parts.NodeID]++
+	}
+
+	// Round-robin spreads the batch evenly
+	assert.Equal(t, map[int64]int{1: 4096, 2: 4096, 3: 4096}, perNode)
+}
+
+func TestMultiNodeGenerator_WeightedSelection(t *testing.T) {
+	generators := frozenGenerators(t, time.Now(), 1, 2)
+	multi, err := snowflake.NewMultiNodeGenerator(generators...)
+	require.NoError(t, err)
+	require.NoError(t, multi.SetWeights(3, 1))
+
+	perNode := make(map[int64]int)
+
+	for i := 0; i < 4000; i++ {
+		id, err := multi.Generate()
+		require.NoError(t, err)
+
+		parts, err := generators[0].Parse(id)
+		require.NoError(t, err)
+
+		perNode[parts.NodeID]++
+	}
+
+	// Node 1 should get about three quarters of the IDs
+	assert.InDelta(t, 3000, perNode[1], 200)
+	assert.Equal(t, 4000, perNode[1]+perNode[2])
+
+	assert.ErrorIs(t, multi.SetWeights(1), snowflake.ErrInvalidWeights)
+	assert.ErrorIs(t, multi.SetWeights(1, 0), snowflake.ErrInvalidWeights)
+}
+
+func TestMultiNodeGenerator_Validation(t *testing.T) {
+	_, err := snowflake.NewMultiNodeGenerator()
+	assert.ErrorIs(t, err, snowflake.ErrNoGenerators)
+
+	_, err = snowflake.NewMultiNodeGenerator(frozenGenerators(t, time.Now(), 1, 2, 1)...)
+	assert.ErrorIs(t, err, snowflake.ErrDuplicateNodeID)
+
+	other, err := snowflake.NewGeneratorWithEpoch(2, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	_, err = snowflake.NewMultiNodeGenerator(frozenGenerators(t, time.Now(), 1)[0], other)
+	assert.ErrorIs(t, err, snowflake.ErrMixedEpochs)
+
+	multi, err := snowflake.NewMultiNodeGenerator(frozenGenerators(t, time.Now(), 7, 3)...)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{7, 3}, multi.NodeIDs())
+}
@@ -0,0 +1,103 @@
+package generator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+)
+
+func TestSnowflakeParse_RecoversNodeAndTimestamp(t *testing.T) {
+	for _, nodeID := range []int64{0, 1, 7, 1023} {
+		gen, err := snowflake.NewGenerator(nodeID)
+		require.NoError(t, err)
+
+		id, err := gen.Generate()
+		require.NoError(t, err)
+
+		parts, err := gen.Parse(id)
+		require.NoError(t, err)
+
+		assert.Equal(t, gen.NodeID(), parts.NodeID)
+		assert.WithinDuration(t, time.Now(), parts.Timestamp, 50*time.Millisecond)
+	}
+}
+
+func TestSnowflakeParse_SequenceIncrementsWithinMillisecond(t *testing.T) {
+	gen, err := snowflake.NewGenerator(3)
+	require.NoError(t, err)
+
+	first, err := gen.Generate()
+	require.NoError(t, err)
+
+	second, err := gen.Generate()
+	require.NoError(t, err)
+
+	firstParts, err := gen.Parse(first)
+	require.NoError(t, err)
+
+	secondParts, err := gen.Parse(second)
+	require.NoError(t, err)
+
+	if firstParts.Timestamp.Equal(secondParts.Timestamp) {
+		assert.Equal(t, firstParts.Sequence+1, secondParts.Sequence)
+	} else {
+		assert.Zero(t, secondParts.Sequence)
+	}
+}
+
+func TestSnowflakeParse_RejectsNegativeID(t *testing.T) {
+	gen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	_, err = gen.Parse(-1)
+	assert.ErrorIs(t, err, snowflake.ErrInvalidID)
+}
+
+func TestSnowflakeEpoch_ShiftsTimestampBits(t *testing.T) {
+	customEpoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	defaultGen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	customGen, err := snowflake.NewGeneratorWithEpoch(1, customEpoch)
+	require.NoError(t, err)
+
+	defaultID, err := defaultGen.Generate()
+	require.NoError(t, err)
+
+	customID, err := customGen.Generate()
+	require.NoError(t, err)
+
+	// The same moment is further from the older epoch, so its timestamp bits are larger
+	defaultMillis, customMillis := defaultID>>22, customID>>22
+	assert.InDelta(t, customEpoch.Sub(snowflake.DefaultEpoch).Milliseconds(), defaultMillis-customMillis, 50)
+
+	// Each generator parses its own IDs back to the same wall-clock time
+	defaultParts, err := defaultGen.Parse(defaultID)
+	require.NoError(t, err)
+
+	customParts, err := customGen.Parse(customID)
+	require.NoError(t, err)
+
+	assert.WithinDuration(t, defaultParts.Timestamp, customParts.Timestamp, 50*time.Millisecond)
+	assert.Equal(t, customEpoch, customGen.Epoch())
+}
+
+func TestSnowflakeEpoch_Validation(t *testing.T) {
+	_, err := snowflake.NewGeneratorWithEpoch(1, time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, snowflake.ErrInvalidEpoch)
+
+	_, err = snowflake.NewGeneratorWithEpoch(snowflake.MaxNodeID+1, snowflake.DefaultEpoch)
+	assert.ErrorIs(t, err, snowflake.ErrInvalidNodeID)
+
+	// 41 bits of milliseconds cover about 69 years
+	ancient, err := snowflake.NewGeneratorWithEpoch(1, time.Now().AddDate(-70, 0, 0))
+	require.NoError(t, err)
+
+	_, err = ancient.Generate()
+	assert.ErrorIs(t, err, snowflake.ErrTimestampOverflow)
+}
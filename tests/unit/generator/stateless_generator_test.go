@@ -0,0 +1,120 @@
+package generator_test
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/stateless"
+)
+
+var testStatelessSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func newStatelessGenerator(t *testing.T, maxURLLength int) *stateless.Generator {
+	t.Helper()
+
+	gen, err := stateless.NewGenerator(stateless.Config{Secret: testStatelessSecret, MaxURLLength: maxURLLength})
+	require.NoError(t, err)
+
+	return gen
+}
+
+func TestStatelessGenerator_RoundTrip(t *testing.T) {
+	gen := newStatelessGenerator(t, 0)
+
+	for _, rawURL := range []string{
+		"https://example.com",
+		"https://example.com/campaigns/2024/spring?utm_source=newsletter&utm_medium=email&utm_campaign=spring",
+		"http://192.168.1.1:8080/path#fragment",
+	} {
+		t.Run(rawURL, func(t *testing.T) {
+			longURL, err := valueobject.NewLongURL(rawURL)
+			require.NoError(t, err)
+
+			token, err := gen.Generate(longURL)
+			require.NoError(t, err)
+			assert.NotContains(t, token, "=", "tokens are unpadded base64url")
+			assert.NotContains(t, token, "/")
+			assert.NotContains(t, token, "+")
+
+			resolved, err := gen.Resolve(token)
+			require.NoError(t, err)
+			assert.Equal(t, rawURL, resolved.Value())
+		})
+	}
+}
+
+func TestStatelessGenerator_DetectsTampering(t *testing.T) {
+	gen := newStatelessGenerator(t, 0)
+
+	longURL, _ := valueobject.NewLongURL("https://example.com/original")
+	token, err := gen.Generate(longURL)
+	require.NoError(t, err)
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	require.NoError(t, err)
+
+	t.Run("Flipped payload byte", func(t *testing.T) {
+		tampered := append([]byte(nil), raw...)
+		tampered[len(tampered)-1] ^= 0x01
+
+		_, err := gen.Resolve(base64.RawURLEncoding.EncodeToString(tampered))
+		assert.ErrorIs(t, err, stateless.ErrInvalidSignature)
+	})
+
+	t.Run("Flipped signature byte", func(t *testing.T) {
+		tampered := append([]byte(nil), raw...)
+		tampered[0] ^= 0x01
+
+		_, err := gen.Resolve(base64.RawURLEncoding.EncodeToString(tampered))
+		assert.ErrorIs(t, err, stateless.ErrInvalidSignature)
+	})
+
+	t.Run("Different secret", func(t *testing.T) {
+		other, err := stateless.NewGenerator(stateless.Config{Secret: []byte(strings.Repeat("z", stateless.MinSecretLength))})
+		require.NoError(t, err)
+
+		_, err = other.Resolve(token)
+		assert.ErrorIs(t, err, stateless.ErrInvalidSignature)
+	})
+
+	t.Run("Malformed tokens", func(t *testing.T) {
+		for _, token := range []string{"", "not*base64", "c2hvcnQ"} {
+			_, err := gen.Resolve(token)
+			assert.ErrorIs(t, err, stateless.ErrInvalidToken, token)
+		}
+	})
+}
+
+func TestStatelessGenerator_LengthLimits(t *testing.T) {
+	gen := newStatelessGenerator(t, 64)
+
+	atLimit, _ := valueobject.NewLongURL("https://example.com/" + strings.Repeat("a", 64-len("https://example.com/")))
+	token, err := gen.Generate(atLimit)
+	require.NoError(t, err)
+
+	resolved, err := gen.Resolve(token)
+	require.NoError(t, err)
+	assert.Equal(t, atLimit.Value(), resolved.Value())
+
+	overLimit, _ := valueobject.NewLongURL(atLimit.Value() + "b")
+	_, err = gen.Generate(overLimit)
+	assert.ErrorIs(t, err, stateless.ErrDestinationTooLong)
+
+	// A validly signed token from a more permissive instance is still bounded on resolve
+	permissive := newStatelessGenerator(t, 0)
+	longToken, err := permissive.Generate(overLimit)
+	require.NoError(t, err)
+
+	_, err = gen.Resolve(longToken)
+	assert.ErrorIs(t, err, stateless.ErrDestinationTooLong)
+}
+
+func TestStatelessGenerator_RejectsShortSecret(t *testing.T) {
+	_, err := stateless.NewGenerator(stateless.Config{Secret: []byte("too-short")})
+	assert.ErrorIs(t, err, stateless.ErrSecretTooShort)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+const testBatchLimit = 3
+
+func newBatchRouter(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.urlHandler.SetBatchLimits(handler.BatchLimits{
+		Default:   50,
+		Endpoints: map[string]int{handler.BatchShorten: testBatchLimit},
+	})
+	f.router.POST("/api/shorten/batch", f.urlHandler.ShortenURLBatch)
+
+	return f.router
+}
+
+func postBatch(router *gin.Engine, items []dto.ShortenURLRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(items)
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func batchItems(n int) []dto.ShortenURLRequest {
+	items := make([]dto.ShortenURLRequest, n)
+	for i := range items {
+		items[i] = dto.ShortenURLRequest{
+			LongURL:   fmt.Sprintf("https://example.com/page-%d", i),
+			CustomKey: fmt.Sprintf("page%d", i),
+		}
+	}
+
+	return items
+}
+
+func TestShortenURLBatch_AcceptsExactlyTheLimit(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("BatchSave", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	w := postBatch(newBatchRouter(t, urlRepo, cacheRepo), batchItems(testBatchLimit))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.BatchShortenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, testBatchLimit)
+
+	for i, result := range resp.Results {
+		assert.Equal(t, i, result.Index)
+		require.NotNil(t, result.Result)
+		assert.Equal(t, fmt.Sprintf("page%d", i), result.Result.ShortKey)
+		assert.Nil(t, result.Error)
+	}
+
+	urlRepo.AssertNumberOfCalls(t, "BatchSave", 1)
+}
+
+func TestShortenURLBatch_RejectsOverLimitBeforeProcessing(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	w := postBatch(newBatchRouter(t, urlRepo, cacheRepo), batchItems(testBatchLimit+1))
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "too_many_items", resp.Error)
+	assert.Contains(t, resp.Message, "at most 3 items")
+
+	urlRepo.AssertNotCalled(t, "ExistsByShortKey", mock.Anything, mock.Anything)
+	urlRepo.AssertNotCalled(t, "BatchSave", mock.Anything, mock.Anything)
+}
+
+// unreadBody fails the test if the handler reads past the items it was given.
+type unreadBody struct {
+	t *testing.T
+}
+
+func (b unreadBody) Read([]byte) (int, error) {
+	b.t.Error("body read past the item limit")
+	return 0, io.ErrUnexpectedEOF
+}
+
+func TestShortenURLBatch_StopsReadingAtTheLimit(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	items, err := json.Marshal(batchItems(testBatchLimit))
+	require.NoError(t, err)
+
+	// The limit's worth of items and the start of one more; the rest must never be read
+	head := bytes.TrimSuffix(items, []byte("]"))
+	head = append(head, []byte(`,{"long_url":`)...)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", io.MultiReader(bytes.NewReader(head), unreadBody{t}))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	newBatchRouter(t, urlRepo, cacheRepo).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	urlRepo.AssertNotCalled(t, "BatchSave", mock.Anything, mock.Anything)
+}
+
+func TestShortenURLBatch_RejectsBodiesThatAreNotArrays(t *testing.T) {
+	for _, body := range []string{`{"long_url": "https://example.com"}`, `[{"long_url": "https://example.com"}`, ``} {
+		req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		newBatchRouter(t, &MockURLRepository{}, &MockCacheRepository{}).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "body %q", body)
+	}
+}
+
+func TestBatchLimits_FallBackToDefault(t *testing.T) {
+	limits := handler.BatchLimits{Default: 25, Endpoints: map[string]int{handler.BatchShorten: 10}}
+
+	assert.Equal(t, 10, limits.For(handler.BatchShorten))
+	assert.Equal(t, 25, limits.For(handler.BatchStats))
+	assert.Equal(t, handler.DefaultBatchMaxItems, handler.BatchLimits{}.For(handler.BatchResolve))
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+func newCacheStatusRouter(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository, enabled bool) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.urlHandler.SetCacheStatusHeader(enabled)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.GET("/stats/:shortKey", f.urlHandler.GetStats)
+
+	return f.router
+}
+
+func warmCache() (*MockURLRepository, *MockCacheRepository) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "docs").Return(&repository.CacheEntry{
+		LongURL:   "https://example.com/docs",
+		CreatedAt: time.Now(),
+	}, nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	return urlRepo, cacheRepo
+}
+
+func TestCacheStatusHeader_HitOnWarmCache(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "HIT", w.Header().Get(handler.CacheStatusHeader))
+	urlRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+}
+
+func TestCacheStatusHeader_MissOnColdCache(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "docs").Return(nil, errors.New("cache miss"))
+	cacheRepo.On("Get", mock.Anything, "docs").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, "docs", mock.Anything, mock.Anything).Return(nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, true)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "MISS", w.Header().Get(handler.CacheStatusHeader))
+
+	// Stats bypass the cache entirely
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "MISS", w.Header().Get(handler.CacheStatusHeader))
+}
+
+func TestCacheStatusHeader_TombstoneOnCachedNegative(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "gone").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneExpired,
+	}, nil)
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, true)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/s/gone", nil)
+	req.Header.Set("Accept", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Equal(t, "TOMBSTONE", w.Header().Get(handler.CacheStatusHeader))
+}
+
+func TestCacheStatusHeader_AbsentWhenDisabled(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Empty(t, w.Header().Get(handler.CacheStatusHeader))
+}
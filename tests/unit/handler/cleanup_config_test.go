@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func newCleanupConfigRouter(t *testing.T) (*gin.Engine, *service.BackgroundURLCleanupService) {
+	t.Helper()
+
+	f := newHandlerFixture(t, handlerRepos{}, nil)
+	cleanupService := service.NewBackgroundURLCleanupService(&MockURLRepository{}, &MockCacheRepository{}, nil)
+	urlHandler := handler.NewURLHandler(f.useCase, cleanupService)
+
+	f.router.GET("/api/admin/cleanup/config", urlHandler.GetCleanupConfig)
+	f.router.PUT("/api/admin/cleanup/config", middleware.RequireAdmin(testAdminToken), urlHandler.UpdateCleanupConfig)
+
+	return f.router, cleanupService
+}
+
+func putCleanupConfig(router *gin.Engine, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/cleanup/config", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestUpdateCleanupConfig_AppliesValues(t *testing.T) {
+	router, cleanupService := newCleanupConfigRouter(t)
+
+	w := putCleanupConfig(router, `{"cleanup_interval":"5m","batch_size":200,"buffer_time":"30m"}`, testAdminToken)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.CleanupConfigResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "5m0s", resp.CleanupInterval)
+	assert.Equal(t, 200, resp.BatchSize)
+	assert.Equal(t, "30m0s", resp.BufferTime)
+
+	current := cleanupService.GetConfig()
+	assert.Equal(t, 5*time.Minute, current.CleanupInterval)
+	assert.Equal(t, 200, current.BatchSize)
+	assert.Equal(t, 30*time.Minute, current.BufferTime)
+
+	// The GET endpoint reports what is now in effect
+	getW := httptest.NewRecorder()
+	router.ServeHTTP(getW, httptest.NewRequest(http.MethodGet, "/api/admin/cleanup/config", nil))
+	require.Equal(t, http.StatusOK, getW.Code)
+	assert.JSONEq(t, w.Body.String(), getW.Body.String())
+}
+
+func TestUpdateCleanupConfig_RejectsInvalidValues(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{"Unparseable interval", `{"cleanup_interval":"soon"}`},
+		{"Interval below minimum", `{"cleanup_interval":"10ms"}`},
+		{"Batch size over limit", `{"batch_size":50000}`},
+		{"Negative buffer time", `{"buffer_time":"-1h"}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, cleanupService := newCleanupConfigRouter(t)
+			before := cleanupService.GetConfig()
+
+			w := putCleanupConfig(router, tc.body, testAdminToken)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, before, cleanupService.GetConfig())
+		})
+	}
+}
+
+func TestUpdateCleanupConfig_RequiresAdminToken(t *testing.T) {
+	for name, token := range map[string]string{"No token": "", "Wrong token": "guess"} {
+		t.Run(name, func(t *testing.T) {
+			router, cleanupService := newCleanupConfigRouter(t)
+			before := cleanupService.GetConfig()
+
+			w := putCleanupConfig(router, `{"batch_size":200}`, token)
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Equal(t, before, cleanupService.GetConfig())
+		})
+	}
+}
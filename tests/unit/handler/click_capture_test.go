@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// newClickCaptureRouter serves redirects for the warm "docs" key. Its click recorder is
+// not started, so clicks stay queued until flushedClicks writes them.
+func newClickCaptureRouter(t *testing.T, config *usecase.ShortenURLConfig) (*gin.Engine, *usecase.ClickRecorder, *MockClickRepository) {
+	urlRepo, cacheRepo := warmCache()
+	clickRepo := &MockClickRepository{}
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo, clicks: clickRepo}, config)
+	recorder := usecase.NewClickRecorder(clickRepo, usecase.ClickRecorderConfig{QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+	f.useCase.SetClickRecorder(recorder)
+
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.HEAD("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	return f.router, recorder, clickRepo
+}
+
+// flushedClicks stops recorder and returns every click it wrote to clickRepo.
+func flushedClicks(t *testing.T, recorder *usecase.ClickRecorder, clickRepo *MockClickRepository) []repository.Click {
+	t.Helper()
+
+	var clicks []repository.Click
+
+	clickRepo.On("RecordClicks", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		clicks = append(clicks, args.Get(1).([]repository.Click)...)
+	}).Return(nil)
+
+	recorder.Start()
+	recorder.Stop()
+
+	return clicks
+}
+
+func TestRedirect_EnqueuesClickWithRequestContext(t *testing.T) {
+	router, recorder, clickRepo := newClickCaptureRouter(t, usecase.DefaultShortenURLConfig())
+
+	req := httptest.NewRequest(http.MethodGet, "/s/docs", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+	req.Header.Set("Referer", "https://news.example/article")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (X11; Linux x86_64)")
+
+	before := time.Now()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	// HEAD requests are not visits and record nothing
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/s/docs", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+
+	clicks := flushedClicks(t, recorder, clickRepo)
+
+	require.Len(t, clicks, 1)
+	assert.Equal(t, "docs", clicks[0].ShortKey)
+	assert.Equal(t, "https://news.example/article", clicks[0].Referrer)
+	assert.Equal(t, "Mozilla/5.0 (X11; Linux x86_64)", clicks[0].UserAgent)
+	assert.Equal(t, "203.0.113.7", clicks[0].ClientIP)
+	assert.WithinDuration(t, before, clicks[0].ClickedAt, time.Second)
+}
+
+func TestRedirect_AnonymizesClickIPWhenHashingIdentifiers(t *testing.T) {
+	config := usecase.DefaultShortenURLConfig()
+	config.HashAnalyticsIdentifiers = true
+
+	router, recorder, clickRepo := newClickCaptureRouter(t, config)
+
+	req := httptest.NewRequest(http.MethodGet, "/s/docs", nil)
+	req.RemoteAddr = "203.0.113.7:51234"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusFound, w.Code)
+
+	clicks := flushedClicks(t, recorder, clickRepo)
+
+	require.Len(t, clicks, 1)
+	assert.Equal(t, "203.0.113.0", clicks[0].ClientIP)
+	assert.Empty(t, clicks[0].Referrer)
+}
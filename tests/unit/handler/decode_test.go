@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestDecodeShortURL(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	longURL, _ := valueobject.NewLongURL("https://example.com/destination")
+	stored := entity.NewURL(shortKey, longURL)
+	stored.VisitCount = 7
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.MatchedBy(func(k *valueobject.ShortKey) bool {
+		return k.Value() == "abc123"
+	})).Return(stored, nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/decode", f.urlHandler.DecodeShortURL)
+
+	tests := []struct {
+		name        string
+		input       string
+		expectCode  int
+		expectError string
+	}{
+		{"Full short URL", "https://short.ly/abc123", http.StatusOK, ""},
+		{"Full short URL with redirect path", "https://short.ly/s/abc123", http.StatusOK, ""},
+		{"Host match is case-insensitive and scheme optional", "SHORT.LY/abc123", http.StatusOK, ""},
+		{"Bare key", "abc123", http.StatusOK, ""},
+		{"Foreign domain rejected", "https://evil.example/abc123", http.StatusBadRequest, "foreign_short_url"},
+		{"Missing key in path", "https://short.ly/", http.StatusBadRequest, "invalid_request"},
+		{"Missing parameter", "", http.StatusBadRequest, "invalid_request"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/decode?url="+url.QueryEscape(tt.input), nil)
+			w := httptest.NewRecorder()
+			f.router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectCode, w.Code, w.Body.String())
+
+			if tt.expectError != "" {
+				var errResp dto.ErrorResponse
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+				assert.Equal(t, tt.expectError, errResp.Error)
+
+				return
+			}
+
+			var stats dto.URLStatsResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+			assert.Equal(t, "abc123", stats.ShortKey)
+			assert.Equal(t, "https://example.com/destination", stats.LongURL)
+			assert.Equal(t, int64(7), stats.VisitCount)
+		})
+	}
+}
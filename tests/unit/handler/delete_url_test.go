@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func TestDeleteURL_Endpoint(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("Delete", mock.Anything, mock.MatchedBy(func(key *valueobject.ShortKey) bool {
+		return key.Value() == "docs"
+	})).Return(nil)
+	urlRepo.On("Delete", mock.Anything, mock.Anything).Return(repository.ErrNotFound)
+	cacheRepo.On("SetTombstone", mock.Anything, "docs", repository.TombstoneDeleted, time.Hour).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.DELETE("/api/urls/:shortKey", middleware.RequireAdmin(testAdminToken), f.urlHandler.DeleteURL)
+
+	deleteKey := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/api/urls/"+key, nil)
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+		w := httptest.NewRecorder()
+		f.router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	w := deleteKey("docs")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = deleteKey("missing")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	cacheRepo.AssertExpectations(t)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func TestDisableURL_RedirectGoneAndStatsKept(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abuse1")
+	longURL, _ := valueobject.NewLongURL("https://example.com/phish")
+	url := entity.NewURL(shortKey, longURL)
+	url.VisitCount = 42
+
+	// The stored row and cache entry reflect the takedown
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(url, nil)
+	urlRepo.On("Disable", mock.Anything, mock.Anything, "phishing").Run(func(args mock.Arguments) {
+		url.Disabled = true
+		url.DisabledReason = args.String(2)
+	}).Return(nil)
+
+	var tombstone *repository.CacheEntry
+
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("SetCacheEntry", mock.Anything, "abuse1", mock.Anything, time.Hour).Run(func(args mock.Arguments) {
+		tombstone = args.Get(2).(*repository.CacheEntry)
+	}).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.GET("/stats/:shortKey", f.urlHandler.GetStats)
+	f.router.POST("/api/admin/urls/:shortKey/disable", middleware.RequireAdmin(testAdminToken), f.urlHandler.DisableURL)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/urls/abuse1/disable", strings.NewReader(`{"reason": "phishing"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, tombstone)
+
+	// Redirects are answered from the disabled tombstone
+	cacheRepo.On("GetCacheEntry", mock.Anything, "abuse1").Return(tombstone, nil)
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/abuse1", nil))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+
+	var errResp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "url_disabled", errResp.Error)
+	assert.Equal(t, "phishing", errResp.Reason)
+	urlRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+
+	// Stats stay available, reporting the takedown
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/abuse1", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats dto.URLStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+	assert.Equal(t, int64(42), stats.VisitCount)
+	assert.Equal(t, "phishing", stats.DisabledReason)
+}
+
+func TestDisableURL_RequiresReason(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.POST("/api/admin/urls/:shortKey/disable", f.urlHandler.DisableURL)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/urls/abuse1/disable", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	urlRepo.AssertNotCalled(t, "Disable", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDisableURL_CannotBeUndoneByEnabling(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abuse2")
+	longURL, _ := valueobject.NewLongURL("https://example.com/phish")
+	url := entity.NewURL(shortKey, longURL)
+
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(url, nil)
+	urlRepo.On("Disable", mock.Anything, mock.Anything, "phishing").Run(func(args mock.Arguments) {
+		url.Disabled = true
+		url.DisabledReason = args.String(2)
+	}).Return(nil)
+
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("SetCacheEntry", mock.Anything, "abuse2", mock.Anything, time.Hour).Return(nil)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "abuse2").Return(nil, repository.ErrCacheMiss)
+	cacheRepo.On("Get", mock.Anything, "abuse2").Return("", repository.ErrCacheMiss)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.PUT("/api/admin/urls/:shortKey/enabled", middleware.RequireAdmin(testAdminToken), f.urlHandler.SetURLEnabled)
+	f.router.POST("/api/admin/urls/:shortKey/disable", middleware.RequireAdmin(testAdminToken), f.urlHandler.DisableURL)
+
+	send := func(method, target, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+		w := httptest.NewRecorder()
+		f.router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/api/admin/urls/abuse2/disable", `{"reason": "phishing"}`).Code)
+
+	w := send(http.MethodPut, "/api/admin/urls/abuse2/enabled", `{"enabled": true}`)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "url_taken_down")
+	urlRepo.AssertNotCalled(t, "SetEnabled", mock.Anything, mock.Anything, mock.Anything)
+
+	// The link is still taken down
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/abuse2", nil))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Contains(t, w.Body.String(), "phishing")
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+)
+
+// setupRedirectRouter wires a real URLHandler whose database lookups fail with findErr.
+// A nil config uses the defaults.
+func setupRedirectRouter(t *testing.T, findErr error, config *usecase.ShortenURLConfig) *gin.Engine {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	cacheRepo.On("GetCacheEntry", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	cacheRepo.On("Get", mock.Anything, mock.Anything).Return("", assert.AnError)
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, findErr)
+	cacheRepo.On("SetTombstone", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, config)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	return f.router
+}
+
+func TestRedirectErrorPage_Localized(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expectLang     string
+		expectText     string
+	}{
+		{"Spanish", "es-ES,es;q=0.9", "es", "Este enlace corto no existe"},
+		{"French", "fr-CA,fr;q=0.8,en;q=0.5", "fr", "Ce lien court n&#39;existe pas"},
+		{"Unknown language falls back to English", "de-DE,ja;q=0.7", "en", "This short link does not exist"},
+		{"Missing header falls back to English", "", "en", "This short link does not exist"},
+	}
+
+	router := setupRedirectRouter(t, usecase.ErrURLNotFound, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/s/missing1", nil)
+			req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+			assert.Equal(t, tt.expectLang, w.Header().Get("Content-Language"))
+			assert.Contains(t, w.Body.String(), `<html lang="`+tt.expectLang+`">`)
+			assert.Contains(t, w.Body.String(), tt.expectText)
+		})
+	}
+}
+
+func TestRedirectErrorPage_JSONForAPIClients(t *testing.T) {
+	router := setupRedirectRouter(t, usecase.ErrURLNotFound, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/s/missing1", nil)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Language", "es")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.Contains(t, w.Body.String(), `"error":"not_found"`)
+}
+
+func TestRedirectErrorPage_UnknownCodeShowsGenericError(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expectText     string
+	}{
+		{"English", "en", "We could not open this short link"},
+		{"Spanish", "es", "No pudimos abrir este enlace corto"},
+		{"French", "fr", "Impossible d&#39;ouvrir ce lien court"},
+	}
+
+	// With stale-while-error a failed lookup with nothing cached is an internal error, not a 404
+	config := usecase.DefaultShortenURLConfig()
+	config.StaleWhileError = true
+	router := setupRedirectRouter(t, assert.AnError, config)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/s/broken1", nil)
+			req.Header.Set("Accept", "text/html")
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Equal(t, tt.acceptLanguage, w.Header().Get("Content-Language"))
+			assert.Contains(t, w.Body.String(), tt.expectText)
+			assert.NotContains(t, w.Body.String(), "does not exist")
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/snowflake"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+// testBaseURL is the base of every short URL built by handlerFixture.
+const testBaseURL = "https://short.ly"
+
+// handlerRepos are the repositories a handlerFixture is wired to; nil fields get empty mocks.
+type handlerRepos struct {
+	urls   repository.URLRepository
+	cache  repository.CacheRepository
+	clicks repository.ClickRepository
+}
+
+// handlerFixture holds the URL and analytics handlers built over test repositories,
+// and an empty router in test mode for the routes a test exercises.
+type handlerFixture struct {
+	handlerRepos
+	useCase          *usecase.ShortenURLUseCase
+	urlHandler       *handler.URLHandler
+	analyticsHandler *handler.AnalyticsHandler
+	router           *gin.Engine
+}
+
+// newHandlerFixture wires the handlers over repos with the use case config (nil for
+// the defaults) and a Snowflake/Base62 generator service.
+func newHandlerFixture(t *testing.T, repos handlerRepos, config *usecase.ShortenURLConfig) *handlerFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	if repos.urls == nil {
+		repos.urls = &MockURLRepository{}
+	}
+
+	if repos.cache == nil {
+		repos.cache = &MockCacheRepository{}
+	}
+
+	if repos.clicks == nil {
+		repos.clicks = &MockClickRepository{}
+	}
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(repos.urls, repos.cache, newTestGeneratorService(t), testBaseURL, time.Hour, config)
+
+	return &handlerFixture{
+		handlerRepos:     repos,
+		useCase:          uc,
+		urlHandler:       handler.NewURLHandler(uc, nil),
+		analyticsHandler: handler.NewAnalyticsHandler(usecase.NewAnalyticsUseCase(repos.urls, repos.clicks)),
+		router:           gin.New(),
+	}
+}
+
+// newTestGeneratorService returns a generator service issuing Snowflake IDs from node 1.
+func newTestGeneratorService(t *testing.T) *service.GeneratorService {
+	t.Helper()
+
+	idGen, err := snowflake.NewGenerator(1)
+	require.NoError(t, err)
+
+	return service.NewGeneratorService(idGen, base62.NewGenerator())
+}
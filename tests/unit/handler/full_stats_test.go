@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func setupFullStatsRouter(t *testing.T, urlRepo *MockURLRepository, clickRepo *MockClickRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, clicks: clickRepo}, nil)
+	f.router.GET("/api/stats/:shortKey/full", f.analyticsHandler.GetFullStats)
+
+	return f.router
+}
+
+func TestGetFullStats_AllSectionsPopulated(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	longURL, _ := valueobject.NewLongURL("https://example.com/landing")
+	stored := entity.NewURL(shortKey, longURL)
+	stored.VisitCount = 12
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC) // day after the inclusive "to"
+
+	urlRepo := &MockURLRepository{}
+	clickRepo := &MockClickRepository{}
+
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(stored, nil)
+	clickRepo.On("GetClicksByDay", mock.Anything, "abc123", from, end).Return([]repository.DailyClicks{
+		{Day: from, Clicks: 5},
+		{Day: from.AddDate(0, 0, 2), Clicks: 7},
+	}, nil)
+	clickRepo.On("GetTopReferrers", mock.Anything, "abc123", from, end, 2).Return([]repository.ReferrerCount{
+		{Referrer: "https://news.example", Clicks: 8},
+		{Referrer: "direct", Clicks: 4},
+	}, nil)
+
+	router := setupFullStatsRouter(t, urlRepo, clickRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats/abc123/full?from=2024-03-01&to=2024-03-03&referrers=2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp dto.FullStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	// Base stats
+	require.NotNil(t, resp.Stats)
+	assert.Equal(t, "abc123", resp.Stats.ShortKey)
+	assert.Equal(t, "https://example.com/landing", resp.Stats.LongURL)
+	assert.Equal(t, int64(12), resp.Stats.VisitCount)
+
+	// Timeline covers every day in the range, including days without clicks
+	assert.Equal(t, "2024-03-01", resp.From)
+	assert.Equal(t, "2024-03-03", resp.To)
+	assert.Equal(t, []dto.DailyClicks{
+		{Date: "2024-03-01", Clicks: 5},
+		{Date: "2024-03-02", Clicks: 0},
+		{Date: "2024-03-03", Clicks: 7},
+	}, resp.Timeline)
+
+	// Top referrers
+	assert.Equal(t, []dto.ReferrerClicks{
+		{Referrer: "https://news.example", Clicks: 8},
+		{Referrer: "direct", Clicks: 4},
+	}, resp.TopReferrers)
+
+	clickRepo.AssertExpectations(t)
+}
+
+func TestGetFullStats_DefaultRange(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	longURL, _ := valueobject.NewLongURL("https://example.com")
+
+	urlRepo := &MockURLRepository{}
+	clickRepo := &MockClickRepository{}
+
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	clickRepo.On("GetClicksByDay", mock.Anything, "abc123", mock.Anything, mock.Anything).Return(nil, nil)
+	clickRepo.On("GetTopReferrers", mock.Anything, "abc123", mock.Anything, mock.Anything, usecase.DefaultTopReferrers).Return(nil, nil)
+
+	router := setupFullStatsRouter(t, urlRepo, clickRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/stats/abc123/full", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.FullStatsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Len(t, resp.Timeline, usecase.DefaultTimelineDays)
+	assert.Equal(t, time.Now().UTC().Format("2006-01-02"), resp.To)
+	assert.NotNil(t, resp.TopReferrers)
+}
+
+func TestGetFullStats_InvalidRange(t *testing.T) {
+	router := setupFullStatsRouter(t, &MockURLRepository{}, &MockClickRepository{})
+
+	for _, query := range []string{"from=2024-03-05&to=2024-03-01", "from=yesterday", "days=0", "from=2020-01-01&to=2024-01-01"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/stats/abc123/full?"+query, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
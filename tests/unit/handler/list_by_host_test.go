@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// hostURLs returns n URLs on phishy.example, newest first.
+func hostURLs(n int) []*entity.URL {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	urls := make([]*entity.URL, n)
+
+	for i := range urls {
+		shortKey, _ := valueobject.NewShortKey(fmt.Sprintf("key%d", i))
+		longURL, _ := valueobject.NewLongURL(fmt.Sprintf("https://phishy.example/%d", i))
+
+		urls[i] = entity.NewURL(shortKey, longURL)
+		urls[i].ID = int64(n - i)
+		urls[i].CreatedAt = base.Add(-time.Duration(i) * time.Minute)
+	}
+
+	return urls
+}
+
+func setupListByHost(t *testing.T, urlRepo *MockURLRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.GET("/api/admin/urls", f.urlHandler.ListURLsByHost)
+
+	return f.router
+}
+
+func TestListURLsByHost_PaginatesWithCursor(t *testing.T) {
+	urls := hostURLs(5)
+	urlRepo := &MockURLRepository{}
+
+	// Host is normalized before it reaches the repository; limit+1 rows are requested
+	urlRepo.On("FindByHost", mock.Anything, "phishy.example", (*repository.KeysetCursor)(nil), 3).
+		Return(urls[:3], nil).Once()
+	urlRepo.On("FindByHost", mock.Anything, "phishy.example", &repository.KeysetCursor{
+		CreatedAt: urls[1].CreatedAt,
+		ID:        urls[1].ID,
+	}, 3).Return(urls[2:5], nil).Once()
+
+	router := setupListByHost(t, urlRepo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?host=PHISHY.example:443&limit=2", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var first dto.PageResponse[dto.URLStatsResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &first))
+	assert.True(t, first.HasMore)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, "key0", first.Items[0].ShortKey)
+	assert.Equal(t, "key1", first.Items[1].ShortKey)
+	assert.Contains(t, w.Header().Get("Link"), `rel="next"`)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/api/admin/urls?host=PHISHY.example:443&limit=2&cursor="+url.QueryEscape(first.NextCursor), nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var second dto.PageResponse[dto.URLStatsResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &second))
+	require.Len(t, second.Items, 2)
+	assert.Equal(t, "key2", second.Items[0].ShortKey)
+	assert.True(t, second.HasMore)
+
+	urlRepo.AssertExpectations(t)
+}
+
+func TestListURLsByHost_LastPage(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByHost", mock.Anything, "phishy.example", (*repository.KeysetCursor)(nil), usecase.DefaultHostListLimit+1).
+		Return(hostURLs(2), nil)
+
+	w := httptest.NewRecorder()
+	setupListByHost(t, urlRepo).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?host=phishy.example", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page dto.PageResponse[dto.URLStatsResponse]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Len(t, page.Items, 2)
+	assert.False(t, page.HasMore)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, w.Header().Get("Link"))
+}
+
+func TestListURLsByHost_RejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		expectError string
+	}{
+		{"Missing host", "", "invalid_request"},
+		{"Host with path", "host=phishy.example/login", "invalid_request"},
+		{"Invalid limit", "host=phishy.example&limit=abc", "invalid_request"},
+		{"Invalid cursor", "host=phishy.example&cursor=not-a-cursor", "invalid_cursor"},
+	}
+
+	router := setupListByHost(t, &MockURLRepository{})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectError, resp.Error)
+		})
+	}
+}
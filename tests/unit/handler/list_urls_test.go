@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func setupListURLs(t *testing.T, urlRepo *MockURLRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.GET("/api/admin/urls", f.urlHandler.ListURLs)
+
+	return f.router
+}
+
+func TestListURLs_ReturnsItemsAndPagination(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("List", mock.Anything, 2, 2, repository.ListOrderVisitCount).Return(hostURLs(2), int64(7), nil)
+
+	w := httptest.NewRecorder()
+	setupListURLs(t, urlRepo).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?limit=2&offset=2&sort=visit_count", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.URLListResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, dto.OffsetPagination{Limit: 2, Offset: 2, Total: 7, HasMore: true}, resp.Pagination)
+}
+
+func TestListURLs_RejectsBadParameters(t *testing.T) {
+	tests := []struct {
+		query string
+		code  string
+	}{
+		{"limit=ten", "invalid_request"},
+		{"offset=1.5", "invalid_request"},
+		{"offset=-3", "invalid_request"},
+		{"sort=long_url", "invalid_sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+
+			w := httptest.NewRecorder()
+			setupListURLs(t, urlRepo).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.code, resp.Error)
+
+			urlRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListURLs_HostFilterUsesHostListing(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByHost", mock.Anything, "phishy.example", (*repository.KeysetCursor)(nil), usecase.DefaultHostListLimit+1).
+		Return(hostURLs(1), nil)
+
+	w := httptest.NewRecorder()
+	setupListURLs(t, urlRepo).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/urls?host=phishy.example", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	urlRepo.AssertExpectations(t)
+	urlRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/router"
+)
+
+func setupShortKeyRouter(t *testing.T, allowed []string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	router.RegisterShortKeyRoutes(engine, allowed, newShortKeyHandler(t).RedirectURL)
+
+	return engine
+}
+
+// newShortKeyHandler returns a handler that redirects abc123 from the cache.
+func newShortKeyHandler(t *testing.T) *handler.URLHandler {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(&repository.CacheEntry{
+		LongURL:   "https://example.com/destination",
+		CreatedAt: time.Now(),
+	}, nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	return newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil).urlHandler
+}
+
+func TestShortKeyRoutes_GetRedirects(t *testing.T) {
+	engine := setupShortKeyRouter(t, []string{"GET", "HEAD"})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/abc123", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/destination", w.Header().Get("Location"))
+}
+
+func TestShortKeyRoutes_HeadRedirectsWithoutBody(t *testing.T) {
+	engine := setupShortKeyRouter(t, []string{"GET", "HEAD"})
+
+	// Use a real server so the HEAD response goes through net/http's body suppression
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Head(server.URL + "/s/abc123")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	assert.Equal(t, "https://example.com/destination", resp.Header.Get("Location"))
+
+	var buf [1]byte
+	n, _ := resp.Body.Read(buf[:])
+	assert.Zero(t, n, "HEAD responses must not carry a body")
+}
+
+func TestShortKeyRoutes_OtherMethodsReturn405(t *testing.T) {
+	engine := setupShortKeyRouter(t, []string{"GET", "HEAD"})
+
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		t.Run(method, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			engine.ServeHTTP(w, httptest.NewRequest(method, "/s/abc123", nil))
+
+			assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+			assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, "method_not_allowed", resp.Error)
+		})
+	}
+}
+
+func TestShortKeyRoutes_AllowlistIsConfigurable(t *testing.T) {
+	engine := setupShortKeyRouter(t, []string{"get"})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/s/abc123", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET", w.Header().Get("Allow"))
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/metrics"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// newMetricsRouter wires every collector onto a fresh registry exposed at /metrics,
+// with a warm cache for "docs".
+func newMetricsRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	reg := prometheus.NewRegistry()
+
+	redirectMetrics, err := metrics.NewRedirectMetrics(reg)
+	require.NoError(t, err)
+
+	useCaseMetrics, err := metrics.NewUseCaseMetrics(reg)
+	require.NoError(t, err)
+
+	httpMetrics, err := metrics.NewHTTPMetrics(reg)
+	require.NoError(t, err)
+
+	cleanupStats := &service.CleanupStats{SuccessfulRuns: 4, FailedRuns: 1, TotalCleaned: 250, LastCleanupTime: time.Unix(1700000000, 0)}
+	require.NoError(t, metrics.RegisterCleanupMetrics(reg, func() *service.CleanupStats { return cleanupStats }))
+
+	urlRepo, cacheRepo := warmCache()
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.useCase.SetMetricsObserver(useCaseMetrics)
+	f.urlHandler.SetRedirectObserver(redirectMetrics)
+
+	f.router.Use(middleware.Metrics(httpMetrics))
+	f.router.POST("/", f.urlHandler.ShortenURL)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(reg, promhttp.HandlerOpts{})))
+
+	return f.router
+}
+
+// scrape returns the /metrics exposition.
+func scrape(t *testing.T, router *gin.Engine) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
+
+func TestMetricsEndpoint_ExposesServiceMetrics(t *testing.T) {
+	router := newMetricsRouter(t)
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/s/docs", nil),
+		httptest.NewRequest(http.MethodGet, "/no/such/route", nil),
+		httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"long_url": "not a url"}`)),
+	} {
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	exposition := scrape(t, router)
+
+	for _, line := range []string{
+		`url_shortener_redirects_total{cache="hit"} 1`,
+		`url_shortener_cache_lookups_total{cache="hit"} 1`,
+		`url_shortener_shorten_requests_total{outcome="failed"} 1`,
+		`url_shortener_http_requests_total{method="GET",route="/s/:shortKey",status="302"} 1`,
+		`url_shortener_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`url_shortener_http_requests_total{method="POST",route="/",status="400"} 1`,
+		`url_shortener_http_request_duration_seconds_count{method="GET",route="/s/:shortKey"} 1`,
+		`url_shortener_cleanup_runs_total{result="success"} 4`,
+		`url_shortener_cleanup_runs_total{result="failure"} 1`,
+		`url_shortener_cleanup_records_total 250`,
+		`url_shortener_cleanup_last_run_timestamp_seconds 1.7e+09`,
+	} {
+		assert.Contains(t, exposition, line)
+	}
+}
+
+func TestMetricsEndpoint_CacheHitCounterIncrements(t *testing.T) {
+	router := newMetricsRouter(t)
+
+	for i := 1; i <= 3; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+		require.Equal(t, http.StatusFound, w.Code)
+
+		assert.Contains(t, scrape(t, router), `url_shortener_cache_lookups_total{cache="hit"} `+strconv.Itoa(i)+"\n")
+	}
+}
@@ -0,0 +1,232 @@
+// Package handler contains unit tests for the HTTP handlers.
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// MockURLRepository is a mock implementation of URLRepository for handler testing.
+type MockURLRepository struct {
+	mock.Mock
+}
+
+func (m *MockURLRepository) Save(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) BatchSave(ctx context.Context, urls []*entity.URL) error {
+	args := m.Called(ctx, urls)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error) {
+	args := m.Called(ctx, shortKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByLongURL(ctx context.Context, longURL *valueobject.LongURL) (*entity.URL, error) {
+	args := m.Called(ctx, longURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByLongURLAndOwner(ctx context.Context, longURL *valueobject.LongURL, owner string) (*entity.URL, error) {
+	args := m.Called(ctx, longURL, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) Update(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Replace(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Delete(ctx context.Context, shortKey *valueobject.ShortKey) error {
+	args := m.Called(ctx, shortKey)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) ExistsByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (bool, error) {
+	args := m.Called(ctx, shortKey)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockURLRepository) IncrementVisitCount(ctx context.Context, shortKey *valueobject.ShortKey) error {
+	args := m.Called(ctx, shortKey)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) FindExpiredURLs(ctx context.Context, before time.Time, maxResults int) ([]*entity.URL, error) {
+	args := m.Called(ctx, before, maxResults)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) DeleteExpiredBatch(ctx context.Context, shortKeys []*valueobject.ShortKey) error {
+	args := m.Called(ctx, shortKeys)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) GetExpiredCount(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockURLRepository) Upsert(ctx context.Context, url *entity.URL) (repository.UpsertResult, error) {
+	args := m.Called(ctx, url)
+	return args.Get(0).(repository.UpsertResult), args.Error(1)
+}
+
+func (m *MockURLRepository) SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error {
+	args := m.Called(ctx, shortKey, enabled)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error {
+	args := m.Called(ctx, shortKey, reason)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) RenewByCriteria(ctx context.Context, criteria repository.RenewCriteria, expiresAt time.Time) ([]string, error) {
+	args := m.Called(ctx, criteria, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByHost(ctx context.Context, host string, cursor *repository.KeysetCursor, limit int) ([]*entity.URL, error) {
+	args := m.Called(ctx, host, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error) {
+	args := m.Called(ctx, limit, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error) {
+	args := m.Called(ctx, limit, offset, orderBy)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Get(1).(int64), args.Error(2)
+}
+
+// MockCacheRepository is a mock implementation of CacheRepository for handler testing.
+type MockCacheRepository struct {
+	mock.Mock
+}
+
+func (m *MockCacheRepository) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
+	args := m.Called(ctx, key, value, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCacheRepository) Get(ctx context.Context, key string) (string, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCacheRepository) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockCacheRepository) Exists(ctx context.Context, key string) (bool, error) {
+	args := m.Called(ctx, key)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCacheRepository) SetCacheEntry(ctx context.Context, key string, entry *repository.CacheEntry, ttl time.Duration) error {
+	args := m.Called(ctx, key, entry, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCacheRepository) GetCacheEntry(ctx context.Context, key string) (*repository.CacheEntry, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*repository.CacheEntry), args.Error(1)
+}
+
+func (m *MockCacheRepository) SetTombstone(ctx context.Context, key string, reason string, ttl time.Duration) error {
+	args := m.Called(ctx, key, reason, ttl)
+	return args.Error(0)
+}
+
+func (m *MockCacheRepository) FlushNamespace(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// MockClickRepository is a mock implementation of ClickRepository for handler testing.
+type MockClickRepository struct {
+	mock.Mock
+}
+
+func (m *MockClickRepository) RecordClicks(ctx context.Context, clicks []repository.Click) error {
+	args := m.Called(ctx, clicks)
+	return args.Error(0)
+}
+
+func (m *MockClickRepository) GetClicksByDay(ctx context.Context, shortKey string, from, to time.Time) ([]repository.DailyClicks, error) {
+	args := m.Called(ctx, shortKey, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]repository.DailyClicks), args.Error(1)
+}
+
+func (m *MockClickRepository) GetTopReferrers(
+	ctx context.Context,
+	shortKey string,
+	from, to time.Time,
+	limit int,
+) ([]repository.ReferrerCount, error) {
+	args := m.Called(ctx, shortKey, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]repository.ReferrerCount), args.Error(1)
+}
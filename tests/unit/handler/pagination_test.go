@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+type pageItem struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func itemCursor(item pageItem) dto.PageCursor {
+	return dto.PageCursor{ID: item.ID, CreatedAt: item.CreatedAt}
+}
+
+func makeItems(n int) []pageItem {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := make([]pageItem, n)
+
+	for i := range items {
+		items[i] = pageItem{ID: int64(i + 1), CreatedAt: base.Add(time.Duration(i) * time.Minute)}
+	}
+
+	return items
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	original := dto.PageCursor{
+		ID:        987654321,
+		CreatedAt: time.Date(2024, 5, 17, 12, 30, 45, 123000000, time.UTC),
+	}
+
+	token := dto.EncodeCursor(original)
+	assert.NotContains(t, token, "=", "cursor should be URL-safe without padding")
+
+	decoded, err := dto.DecodeCursor(token)
+	require.NoError(t, err)
+	assert.Equal(t, original.ID, decoded.ID)
+	assert.True(t, original.CreatedAt.Equal(decoded.CreatedAt))
+
+	// Empty cursor means first page
+	first, err := dto.DecodeCursor("")
+	assert.NoError(t, err)
+	assert.Nil(t, first)
+
+	_, err = dto.DecodeCursor("not a cursor!")
+	assert.ErrorIs(t, err, dto.ErrInvalidCursor)
+}
+
+func TestNewPageResponse(t *testing.T) {
+	// limit+1 rows fetched: another page exists
+	page := dto.NewPageResponse(makeItems(4), 3, itemCursor)
+	assert.Len(t, page.Items, 3)
+	assert.True(t, page.HasMore)
+
+	cursor, err := dto.DecodeCursor(page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), cursor.ID)
+
+	// Last page
+	last := dto.NewPageResponse(makeItems(2), 3, itemCursor)
+	assert.Len(t, last.Items, 2)
+	assert.False(t, last.HasMore)
+	assert.Empty(t, last.NextCursor)
+
+	// Empty lists serialize as [] rather than null
+	empty := dto.NewPageResponse[pageItem](nil, 3, itemCursor)
+	body, err := json.Marshal(empty)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"items":[]`)
+}
+
+func TestRespondWithPage_LinkHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.GET("/items", func(c *gin.Context) {
+		handler.RespondWithPage(c, dto.NewPageResponse(makeItems(3), 2, itemCursor))
+	})
+	router.GET("/last", func(c *gin.Context) {
+		handler.RespondWithPage(c, dto.NewPageResponse(makeItems(1), 2, itemCursor))
+	})
+
+	t.Run("Link header present when more pages exist", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items?limit=2&sort=asc", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var page dto.PageResponse[pageItem]
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+		assert.True(t, page.HasMore)
+		require.NotEmpty(t, page.NextCursor)
+
+		link := w.Header().Get("Link")
+		require.True(t, strings.HasSuffix(link, `>; rel="next"`), "unexpected Link header: %s", link)
+
+		target, err := url.Parse(strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`))
+		require.NoError(t, err)
+		assert.Equal(t, "/items", target.Path)
+		assert.Equal(t, page.NextCursor, target.Query().Get(handler.CursorQueryParam))
+		assert.Equal(t, "2", target.Query().Get("limit"))
+		assert.Equal(t, "asc", target.Query().Get("sort"))
+	})
+
+	t.Run("No Link header on the last page", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/last", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Link"))
+		assert.Contains(t, w.Body.String(), `"has_more":false`)
+	})
+}
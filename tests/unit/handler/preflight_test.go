@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/router"
+)
+
+func setupPreflightRouter(t *testing.T, cors middleware.CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(middleware.CORSWith(cors))
+	router.RegisterPreflight(engine)
+	router.RegisterShortKeyRoutes(engine, []string{"GET", "HEAD"}, newShortKeyHandler(t).RedirectURL)
+
+	created := func(c *gin.Context) { c.Status(http.StatusCreated) }
+	engine.POST("/", created)
+	engine.POST("/api/shorten/batch", created)
+
+	return engine
+}
+
+func preflight(engine *gin.Engine, path, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestPreflight_RespondsOnEveryRoute(t *testing.T) {
+	cors := middleware.DefaultCORSConfig()
+	cors.MaxAge = 10 * time.Minute
+
+	engine := setupPreflightRouter(t, cors)
+
+	for _, path := range []string{"/", "/api/shorten/batch", "/s/abc123", "/unknown/path"} {
+		t.Run(path, func(t *testing.T) {
+			w := preflight(engine, path, "https://app.example.com")
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Empty(t, w.Body.String())
+			assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Contains(t, w.Header().Get("Access-Control-Allow-Methods"), "POST")
+			assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Content-Type")
+			assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+		})
+	}
+}
+
+func TestPreflight_EchoesAllowedOrigin(t *testing.T) {
+	cors := middleware.DefaultCORSConfig()
+	cors.AllowOrigins = []string{"https://app.example.com"}
+
+	engine := setupPreflightRouter(t, cors)
+
+	w := preflight(engine, "/s/abc123", "https://app.example.com")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "Origin", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Access-Control-Max-Age"))
+
+	// Other origins are not granted access
+	w = preflight(engine, "/s/abc123", "https://evil.example.com")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestPreflight_DoesNotAffectOtherMethods(t *testing.T) {
+	engine := setupPreflightRouter(t, middleware.DefaultCORSConfig())
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/abc123", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+}
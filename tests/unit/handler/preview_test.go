@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// substringBlocklist blocks destinations containing any of its entries.
+type substringBlocklist []string
+
+func (b substringBlocklist) Blocks(longURL string) bool {
+	for _, entry := range b {
+		if strings.Contains(longURL, entry) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stubFetcher returns a fixed title and records the URLs it was asked for.
+type stubFetcher struct {
+	title   string
+	fetched []string
+}
+
+func (f *stubFetcher) FetchTitle(_ context.Context, longURL string) (string, error) {
+	f.fetched = append(f.fetched, longURL)
+	return f.title, nil
+}
+
+// newPreviewFixture serves GET /api/preview/:shortKey over urlRepo and cacheRepo.
+func newPreviewFixture(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) *handlerFixture {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/api/preview/:shortKey", f.urlHandler.PreviewURL)
+
+	return f
+}
+
+func TestPreviewURL_DescribesDestinationWithoutCountingVisit(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	f := newPreviewFixture(t, urlRepo, cacheRepo)
+
+	fetcher := &stubFetcher{title: "  Example Docs \n"}
+	f.useCase.SetDestinationBlocklist(substringBlocklist{"malware.test"})
+	f.useCase.SetPreviewFetcher(fetcher)
+
+	hookCalled := make(chan struct{}, 1)
+	f.useCase.AddRedirectHook(func(context.Context, usecase.RedirectEvent) { hookCalled <- struct{}{} })
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/preview/docs", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+
+	var resp dto.URLPreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "docs", resp.ShortKey)
+	assert.Equal(t, "https://example.com/docs", resp.LongURL)
+	assert.Equal(t, "example.com", resp.Host)
+	require.NotNil(t, resp.Blocked)
+	assert.False(t, *resp.Blocked)
+	assert.Equal(t, "Example Docs", resp.Title)
+	assert.Equal(t, []string{"https://example.com/docs"}, fetcher.fetched)
+
+	urlRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+
+	select {
+	case <-hookCalled:
+		t.Fatal("preview notified redirect hooks")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPreviewURL_BlockedDestinationIsNotFetched(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "bad").Return(&repository.CacheEntry{
+		LongURL:   "https://MALWARE.test/payload",
+		CreatedAt: time.Now(),
+	}, nil)
+
+	f := newPreviewFixture(t, urlRepo, cacheRepo)
+
+	fetcher := &stubFetcher{title: "Free prizes"}
+	f.useCase.SetDestinationBlocklist(substringBlocklist{"MALWARE.test"})
+	f.useCase.SetPreviewFetcher(fetcher)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/preview/bad", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.URLPreviewResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "malware.test", resp.Host)
+	require.NotNil(t, resp.Blocked)
+	assert.True(t, *resp.Blocked)
+	assert.Empty(t, resp.Title)
+	assert.Empty(t, fetcher.fetched)
+}
+
+func TestPreviewURL_OmitsUnconfiguredChecks(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	f := newPreviewFixture(t, urlRepo, cacheRepo)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/preview/docs", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"blocked"`)
+	assert.NotContains(t, w.Body.String(), `"title"`)
+}
+
+func TestPreviewURL_UnavailableKeys(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "gone").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneExpired,
+		CreatedAt:   time.Now(),
+	}, nil)
+
+	f := newPreviewFixture(t, urlRepo, cacheRepo)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/preview/gone", nil))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+func newQRRouter(t *testing.T, urlRepo *MockURLRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.GET("/api/urls/:shortKey/qr", f.urlHandler.GetQRCode)
+
+	return f.router
+}
+
+func storedQRURL(expiresIn time.Duration) *MockURLRepository {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+
+	url := entity.NewURL(shortKey, longURL)
+	url.SetExpiration(expiresIn)
+
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(url, nil)
+
+	return urlRepo
+}
+
+func TestGetQRCode_ReturnsPNGOfRequestedSize(t *testing.T) {
+	tests := []struct {
+		query    string
+		wantSize int
+	}{
+		{"", handler.DefaultQRSize},
+		{"?size=300", 300},
+		{"?size=10", handler.MinQRSize},
+		{"?size=99999", handler.MaxQRSize},
+	}
+
+	for _, tt := range tests {
+		t.Run("size"+tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			newQRRouter(t, storedQRURL(time.Hour)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/urls/docs/qr"+tt.query, nil))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+			img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSize, img.Bounds().Dx())
+			assert.Equal(t, tt.wantSize, img.Bounds().Dy())
+		})
+	}
+}
+
+func TestGetQRCode_MissingAndExpiredKeys(t *testing.T) {
+	missing := &MockURLRepository{}
+	missing.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	w := httptest.NewRecorder()
+	newQRRouter(t, missing).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/urls/nothere/qr", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	newQRRouter(t, storedQRURL(-time.Hour)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/urls/docs/qr", nil))
+	assert.Equal(t, http.StatusGone, w.Code)
+}
+
+func TestGetQRCode_RejectsNonNumericSize(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+
+	w := httptest.NewRecorder()
+	newQRRouter(t, urlRepo).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/urls/docs/qr?size=big", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	urlRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+}
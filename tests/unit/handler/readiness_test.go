@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+func TestReadinessCheck_CleanupBacklog(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int64
+		expectCode   int
+		expectStatus string
+	}{
+		{"Healthy backlog", 10, http.StatusOK, `"status":"ready"`},
+		{"Degraded backlog stays ready with warning", 150, http.StatusOK, `"status":"degraded"`},
+		{"Backlog above hard limit fails readiness", 600, http.StatusServiceUnavailable, `"status":"not_ready"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			cacheRepo := &MockCacheRepository{}
+			urlRepo.On("GetExpiredCount", mock.Anything, mock.AnythingOfType("time.Time")).Return(tt.count, nil)
+
+			config := service.DefaultCleanupConfig()
+			config.BacklogWarnThreshold = 100
+			config.BacklogFailThreshold = 500
+			cleanupService := service.NewBackgroundURLCleanupService(urlRepo, cacheRepo, config)
+
+			f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+			f.router.GET("/health/ready", handler.NewURLHandler(f.useCase, cleanupService).ReadinessCheck)
+
+			w := httptest.NewRecorder()
+			f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			assert.Equal(t, tt.expectCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectStatus)
+			assert.Contains(t, w.Body.String(), `"cleanup_backlog"`)
+		})
+	}
+}
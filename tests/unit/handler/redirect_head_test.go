@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func newHeadRouter(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+	f.router.HEAD("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	return f.router
+}
+
+func TestRedirectHead_ReturnsLocationWithoutCountingVisit(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	router := newHeadRouter(t, urlRepo, cacheRepo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/docs", w.Header().Get("Location"))
+	urlRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+
+	// GET on the same key still counts the visit
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	urlRepo.AssertNumberOfCalls(t, "IncrementVisitCount", 1)
+}
+
+func TestRedirectHead_MatchesGetStatusForUnavailableKeys(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "gone").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneExpired,
+		CreatedAt:   time.Now(),
+	}, nil)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "nothere").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneNotFound,
+		CreatedAt:   time.Now(),
+	}, nil)
+
+	router := newHeadRouter(t, urlRepo, cacheRepo)
+
+	for path, status := range map[string]int{"/s/gone": http.StatusGone, "/s/nothere": http.StatusNotFound} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodHead, path, nil))
+
+		assert.Equal(t, status, w.Code, path)
+		assert.Empty(t, w.Header().Get("Location"), path)
+	}
+
+	urlRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func TestRedirectHook_PanicIsIsolated(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+
+	events := make(chan usecase.RedirectEvent, 1)
+	requestIDs := make(chan string, 1)
+
+	f.useCase.AddRedirectHook(func(ctx context.Context, event usecase.RedirectEvent) {
+		panic("analytics sink exploded")
+	})
+	f.useCase.AddRedirectHook(func(ctx context.Context, event usecase.RedirectEvent) {
+		requestIDs <- async.RequestID(ctx)
+		events <- event
+	})
+
+	f.router.Use(middleware.RequestID())
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	panicsBefore := async.PanicCount()
+
+	req := httptest.NewRequest(http.MethodGet, "/s/docs", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-123")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	// The redirect is unaffected by the panicking hook
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/docs", w.Header().Get("Location"))
+	assert.Equal(t, "req-123", w.Header().Get(middleware.RequestIDHeader))
+
+	assert.Eventually(t, func() bool {
+		return async.PanicCount() == panicsBefore+1
+	}, time.Second, 5*time.Millisecond)
+
+	// Other hooks still run, with the request ID in their context
+	select {
+	case event := <-events:
+		assert.Equal(t, "docs", event.ShortKey)
+		assert.Equal(t, "https://example.com/docs", event.LongURL)
+		assert.Equal(t, usecase.CacheHit, event.CacheStatus)
+		assert.Equal(t, "req-123", <-requestIDs)
+	case <-time.After(time.Second):
+		require.Fail(t, "healthy hook was not called")
+	}
+}
+
+func TestRequestID_GeneratedWhenMissing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.RequestID())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, async.RequestID(c.Request.Context()))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Len(t, w.Header().Get(middleware.RequestIDHeader), 32)
+	assert.Equal(t, w.Header().Get(middleware.RequestIDHeader), w.Body.String())
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func TestRedirectURL_FormatJSON(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "docs").Return(&repository.CacheEntry{
+		LongURL:   "https://example.com/docs",
+		ExpiresAt: &expiresAt,
+		CreatedAt: time.Now(),
+	}, nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs?format=json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+
+	var resp dto.ResolveURLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "https://example.com/docs", resp.LongURL)
+	assert.Equal(t, expiresAt.Format(time.RFC3339), resp.ExpiresAt)
+
+	urlRepo.AssertNumberOfCalls(t, "IncrementVisitCount", 1)
+}
+
+func TestRedirectURL_FormatJSONWithoutCounting(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs?format=json&count=false", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"long_url":"https://example.com/docs"}`, w.Body.String())
+	urlRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+}
+
+func TestRedirectURL_FormatJSONNotFound(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "gone").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneNotFound,
+	}, nil)
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/gone?format=json", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
+
+func TestRedirectURL_DefaultStillRedirects(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://example.com/docs", w.Header().Get("Location"))
+}
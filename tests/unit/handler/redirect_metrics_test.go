@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clientmodel "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/metrics"
+)
+
+// redirectHistogram returns the redirect latency histogram for one cache status.
+func redirectHistogram(t *testing.T, reg *prometheus.Registry, cache string) *clientmodel.Histogram {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != metrics.Namespace+"_redirect_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "cache" && label.GetValue() == cache {
+					return metric.GetHistogram()
+				}
+			}
+		}
+	}
+
+	t.Fatalf("no redirect histogram for cache=%s", cache)
+
+	return nil
+}
+
+func TestRedirectMetrics_CacheHitObservedWithinSLO(t *testing.T) {
+	urlRepo, cacheRepo := warmCache()
+	reg := prometheus.NewRegistry()
+
+	redirectMetrics, err := metrics.NewRedirectMetrics(reg)
+	require.NoError(t, err)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.urlHandler.SetRedirectObserver(redirectMetrics)
+
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+
+	histogram := redirectHistogram(t, reg, "hit")
+	assert.Equal(t, uint64(1), histogram.GetSampleCount())
+
+	// A mocked cache hit answers well inside the SLO, so the sample lands in its bucket
+	for _, bucket := range histogram.GetBucket() {
+		if bucket.GetUpperBound() == metrics.RedirectLatencySLO.Seconds() {
+			assert.Equal(t, uint64(1), bucket.GetCumulativeCount())
+		}
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, metrics.Namespace+"_redirects_total"))
+}
+
+func TestRedirectMetrics_BucketsCoverSLO(t *testing.T) {
+	assert.Contains(t, metrics.RedirectLatencyBuckets, metrics.RedirectLatencySLO.Seconds())
+	assert.IsIncreasing(t, metrics.RedirectLatencyBuckets)
+}
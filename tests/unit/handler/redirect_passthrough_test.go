@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func TestRedirectPassthrough_MergesQueryAndFragment(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	cacheRepo := &MockCacheRepository{}
+	destinations := map[string]string{
+		"plain":    "https://example.com/docs",
+		"tagged":   "https://example.com/docs?ref=orig&lang=en",
+		"anchored": "https://example.com/docs#intro",
+	}
+
+	for key, dest := range destinations {
+		cacheRepo.On("GetCacheEntry", mock.Anything, key).Return(&repository.CacheEntry{
+			LongURL:   dest,
+			CreatedAt: time.Now(),
+		}, nil)
+	}
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	tests := []struct {
+		name     string
+		target   string
+		location string
+	}{
+		{"No incoming query", "/s/plain", "https://example.com/docs"},
+		{"Destination without query", "/s/plain?utm_source=news", "https://example.com/docs?utm_source=news"},
+		{"Values are encoded", "/s/plain?utm_campaign=spring%20sale&q=a%26b", "https://example.com/docs?q=a%26b&utm_campaign=spring+sale"},
+		{"Destination with query keeps its parameters", "/s/tagged?ref=spam&utm_source=news", "https://example.com/docs?ref=orig&lang=en&utm_source=news"},
+		{"Query and fragment", "/s/plain?utm_source=news#pricing", "https://example.com/docs?utm_source=news#pricing"},
+		{"Destination fragment wins", "/s/anchored?utm_source=news#pricing", "https://example.com/docs?utm_source=news#intro"},
+		{"Route parameters are not forwarded", "/s/plain?count=false&utm_source=news", "https://example.com/docs?utm_source=news"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set the URL directly: a request line never carries a fragment, but the
+			// handler must still cope with one from clients that build requests themselves
+			target, err := url.Parse(tt.target)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL = target
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusFound, w.Code)
+			assert.Equal(t, tt.location, w.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/apperror"
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+func respondWith(t *testing.T, err error) (*httptest.ResponseRecorder, *gin.Context) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+	handler.RespondError(c, err)
+
+	return w, c
+}
+
+func TestRespondError_MapsSentinels(t *testing.T) {
+	tests := []struct {
+		err          error
+		expectedCode int
+		expectedErr  string
+	}{
+		{usecase.ErrURLNotFound, http.StatusNotFound, "not_found"},
+		{usecase.ErrURLDeleted, http.StatusGone, "url_deleted"},
+		{usecase.ErrURLExpired, http.StatusGone, "url_expired"},
+		{usecase.ErrURLDisabled, http.StatusGone, "url_disabled"},
+		{usecase.ErrCustomKeyExists, http.StatusConflict, "custom_key_exists"},
+		{usecase.ErrForeignShortURL, http.StatusBadRequest, "foreign_short_url"},
+		{usecase.ErrInvalidTimeRange, http.StatusBadRequest, "invalid_request"},
+		{usecase.ErrInternalError, http.StatusInternalServerError, "internal_error"},
+		{valueobject.ErrInvalidURL, http.StatusBadRequest, "invalid_request"},
+		{valueobject.ErrInvalidShortKey, http.StatusBadRequest, "invalid_request"},
+		{valueobject.ErrDisallowedScheme, http.StatusBadRequest, "disallowed_scheme"},
+		{valueobject.ErrReservedShortKey, http.StatusBadRequest, "reserved_short_key"},
+		{errors.New("connection refused"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			w, _ := respondWith(t, tt.err)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedErr, resp.Error)
+			assert.Equal(t, tt.err.Error(), resp.Message)
+		})
+	}
+}
+
+func TestRespondError_UnwrapsApplicationErrors(t *testing.T) {
+	w, _ := respondWith(t, fmt.Errorf("loading stats: %w", usecase.ErrURLExpired))
+
+	assert.Equal(t, http.StatusGone, w.Code)
+	assert.Contains(t, w.Body.String(), `"error":"url_expired"`)
+}
+
+func TestRespondError_RecordsOnlyServerErrors(t *testing.T) {
+	_, c := respondWith(t, apperror.New("teapot", http.StatusTeapot, "short and stout"))
+	assert.Empty(t, c.Errors)
+
+	_, c = respondWith(t, errors.New("boom"))
+	assert.Len(t, c.Errors, 1)
+}
+
+func TestRespondError_IncludesCustomKeySuggestions(t *testing.T) {
+	w, _ := respondWith(t, &usecase.CustomKeyConflictError{Suggestions: []string{"promo2", "promo3"}})
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var resp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "custom_key_exists", resp.Error)
+	assert.Equal(t, []string{"promo2", "promo3"}, resp.Suggestions)
+}
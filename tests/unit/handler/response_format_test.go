@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func newResponseFormatRouter(t *testing.T, envelope bool, naming string, existingKey bool) *gin.Engine {
+	t.Helper()
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(existingKey, nil)
+	urlRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, "docs", mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.Use(middleware.RequestID())
+	f.router.Use(middleware.ResponseFormat(envelope, naming))
+	f.router.POST("/", f.urlHandler.ShortenURL)
+
+	return f.router
+}
+
+func TestResponseFormat_FlatByDefault(t *testing.T) {
+	router := newResponseFormatRouter(t, false, middleware.FieldNamingSnake, false)
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"}, "")
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "docs", resp["short_key"])
+	assert.NotContains(t, resp, "data")
+}
+
+func TestResponseFormat_EnvelopesSuccess(t *testing.T) {
+	router := newResponseFormatRouter(t, true, middleware.FieldNamingSnake, false)
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"}, "")
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "https://short.ly/docs", w.Header().Get("Location"), "headers survive the rewrite")
+
+	var resp struct {
+		Data dto.ShortenURLResponse  `json:"data"`
+		Meta middleware.EnvelopeMeta `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "docs", resp.Data.ShortKey)
+	assert.Equal(t, "https://example.com/docs", resp.Data.LongURL)
+	assert.Equal(t, w.Header().Get(middleware.RequestIDHeader), resp.Meta.RequestID)
+	assert.WithinDuration(t, time.Now(), resp.Meta.Timestamp, time.Minute)
+}
+
+func TestResponseFormat_ErrorsStayFlat(t *testing.T) {
+	router := newResponseFormatRouter(t, true, middleware.FieldNamingSnake, true)
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"}, "")
+
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	var resp map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Contains(t, resp, "error")
+	assert.NotContains(t, resp, "data")
+}
+
+func TestResponseFormat_CamelCaseEnvelope(t *testing.T) {
+	router := newResponseFormatRouter(t, true, middleware.FieldNamingCamel, false)
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"}, "")
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var resp map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "docs", resp["data"]["shortKey"])
+	assert.Equal(t, "https://short.ly/docs", resp["data"]["shortUrl"])
+	assert.NotContains(t, resp["data"], "short_key")
+	assert.Contains(t, resp["meta"], "requestId")
+}
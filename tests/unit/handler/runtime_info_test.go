@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/config"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+)
+
+func TestGetRuntimeInfo_PopulatedFromConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	appConfig := config.AppConfig{
+		BaseURL:            "https://short.ly",
+		SnowflakeNodeID:    7,
+		ShortKeyStrategy:   "random",
+		KeyProfile:         "custom",
+		KeyAlphabet:        "abcdefghjkmnpqrstuvwxyz23456789",
+		CleanupEnabled:     true,
+		CleanupInterval:    10 * time.Minute,
+		CleanupBatchSize:   250,
+		CleanupBufferTime:  30 * time.Minute,
+		CleanupMaxDuration: 2 * time.Minute,
+	}
+
+	cleanupService := service.NewBackgroundURLCleanupService(&MockURLRepository{}, &MockCacheRepository{}, appConfig.GetCleanupConfig())
+
+	urlHandler := handler.NewURLHandler(nil, cleanupService)
+	urlHandler.SetRuntimeInfo(appConfig.GetRuntimeInfo())
+
+	router := gin.New()
+	router.GET("/api/admin/info", urlHandler.GetRuntimeInfo)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/info", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info dto.RuntimeInfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+
+	assert.Equal(t, config.Version, info.Version)
+	assert.Equal(t, "https://short.ly", info.BaseURL)
+	assert.Equal(t, "random", info.IDStrategy)
+	assert.Equal(t, "custom", info.KeyProfile)
+	assert.Equal(t, "ab"+strings.Repeat("*", 27)+"89", info.KeyAlphabet)
+	assert.Equal(t, 31, info.KeyAlphabetLength)
+	assert.Equal(t, int64(7), info.Snowflake.NodeID)
+	assert.NotEmpty(t, info.Snowflake.Epoch)
+
+	require.NotNil(t, info.Cleanup)
+	assert.Equal(t, dto.CleanupConfigResponse{
+		Enabled:            true,
+		CleanupInterval:    "10m0s",
+		BatchSize:          250,
+		BufferTime:         "30m0s",
+		MaxCleanupDuration: "2m0s",
+	}, *info.Cleanup)
+}
+
+func TestGetRuntimeInfo_DefaultsToBase62(t *testing.T) {
+	info := (&config.AppConfig{KeyProfile: "default", SnowflakeNodeID: 1}).GetRuntimeInfo()
+
+	assert.Equal(t, "base62", info.IDStrategy)
+	assert.Equal(t, 54, info.KeyAlphabetLength)
+	assert.Equal(t, "23"+strings.Repeat("*", 50)+"yz", info.KeyAlphabet)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	redisCache "github.com/Shofyan/url-shortener/internal/infrastructure/cache/redis"
+)
+
+// pingableURLRepository is a URL repository whose database ping returns pingErr.
+type pingableURLRepository struct {
+	MockURLRepository
+	pingErr error
+}
+
+func (r *pingableURLRepository) Ping(context.Context) error {
+	return r.pingErr
+}
+
+func runSelfTest(t *testing.T, urlRepo repository.URLRepository, cacheRepo repository.CacheRepository) (int, dto.SelfTestResponse) {
+	t.Helper()
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.GET("/api/admin/selftest", f.urlHandler.SelfTest)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/selftest", nil))
+
+	var resp dto.SelfTestResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	return w.Code, resp
+}
+
+func checkStatuses(resp dto.SelfTestResponse) map[string]string {
+	statuses := make(map[string]string, len(resp.Checks))
+	for _, check := range resp.Checks {
+		statuses[check.Name] = check.Status
+	}
+
+	return statuses
+}
+
+func TestSelfTest_AllChecksPass(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	code, resp := runSelfTest(t, &pingableURLRepository{}, redisCache.NewCacheRepository(client, "urlshortener:"))
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, usecase.SelfTestPass, resp.Status)
+	assert.Equal(t, map[string]string{
+		usecase.SelfTestKeyRoundTrip:   usecase.SelfTestPass,
+		usecase.SelfTestCacheRoundTrip: usecase.SelfTestPass,
+		usecase.SelfTestDatabasePing:   usecase.SelfTestPass,
+	}, checkStatuses(resp))
+
+	// The temporary cache entry is cleaned up
+	assert.Empty(t, server.Keys())
+}
+
+func TestSelfTest_ReportsBrokenCache(t *testing.T) {
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	// The entry comes back mangled, as with a serialization mismatch
+	cacheRepo.On("GetCacheEntry", mock.Anything, mock.Anything).Return(&repository.CacheEntry{LongURL: "{"}, nil)
+
+	code, resp := runSelfTest(t, &pingableURLRepository{pingErr: errors.New("connection refused")}, cacheRepo)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, usecase.SelfTestFail, resp.Status)
+	assert.Equal(t, map[string]string{
+		usecase.SelfTestKeyRoundTrip:   usecase.SelfTestPass,
+		usecase.SelfTestCacheRoundTrip: usecase.SelfTestFail,
+		usecase.SelfTestDatabasePing:   usecase.SelfTestFail,
+	}, checkStatuses(resp))
+
+	for _, check := range resp.Checks {
+		if check.Status == usecase.SelfTestFail {
+			assert.NotEmpty(t, check.Message, check.Name)
+		}
+	}
+}
+
+func TestSelfTest_SkipsPingWithoutPinger(t *testing.T) {
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+	code, resp := runSelfTest(t, &MockURLRepository{}, cacheRepo)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, usecase.SelfTestSkip, checkStatuses(resp)[usecase.SelfTestDatabasePing])
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+func TestShortenURLBatch_ReportsEachItem(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("BatchSave", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	router := newBatchRouter(t, urlRepo, cacheRepo)
+
+	body := `[
+		{"long_url": "https://example.com/docs", "custom_key": "docs"},
+		{"long_url": ""},
+		"not an object"
+	]`
+	req := httptest.NewRequest(http.MethodPost, "/api/shorten/batch", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.BatchShortenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, 3)
+
+	require.NotNil(t, resp.Results[0].Result)
+	assert.Equal(t, "docs", resp.Results[0].Result.ShortKey)
+
+	for _, i := range []int{1, 2} {
+		assert.Equal(t, i, resp.Results[i].Index)
+		assert.Nil(t, resp.Results[i].Result)
+		require.NotNil(t, resp.Results[i].Error)
+		assert.Equal(t, "invalid_request", resp.Results[i].Error.Error)
+	}
+}
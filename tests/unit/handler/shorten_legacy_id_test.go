@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/generator/base62"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+const (
+	testAdminToken = "s3cret"
+	legacyID       = int64(9876543210)
+)
+
+func newLegacyIDRouter(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) *gin.Engine {
+	t.Helper()
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.POST("/", middleware.IdentifyAdmin(testAdminToken), f.urlHandler.ShortenURL)
+
+	return f.router
+}
+
+func postShorten(router *gin.Engine, req dto.ShortenURLRequest, token string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httpReq)
+
+	return w
+}
+
+func TestShortenURL_AdminSuppliedIDProducesLegacyKey(t *testing.T) {
+	expectedKey, err := base62.NewGenerator().GenerateFromID(legacyID)
+	require.NoError(t, err)
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, expectedKey).Return(false, nil)
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.ID == legacyID && url.ShortKey.Value() == expectedKey.Value()
+	})).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, expectedKey.Value(), mock.Anything, mock.Anything).Return(nil)
+
+	router := newLegacyIDRouter(t, urlRepo, cacheRepo)
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/legacy", ID: legacyID}, testAdminToken)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var resp dto.ShortenURLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, expectedKey.Value(), resp.ShortKey)
+	urlRepo.AssertExpectations(t)
+}
+
+func TestShortenURL_SuppliedIDIgnoredForNonAdmin(t *testing.T) {
+	legacyKey, err := base62.NewGenerator().GenerateFromID(legacyID)
+	require.NoError(t, err)
+
+	for name, token := range map[string]string{"No token": "", "Wrong token": "guess"} {
+		t.Run(name, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			cacheRepo := &MockCacheRepository{}
+			urlRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+			urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+			urlRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+				return url.ID != legacyID && url.ShortKey.Value() != legacyKey.Value()
+			})).Return(nil)
+			cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			router := newLegacyIDRouter(t, urlRepo, cacheRepo)
+			w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/legacy", ID: legacyID}, token)
+
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			var resp dto.ShortenURLResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.NotEqual(t, legacyKey.Value(), resp.ShortKey)
+			urlRepo.AssertNotCalled(t, "ExistsByShortKey", mock.Anything, legacyKey)
+		})
+	}
+}
+
+func TestShortenURL_AdminSuppliedIDCollision(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(true, nil)
+
+	router := newLegacyIDRouter(t, urlRepo, &MockCacheRepository{})
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/legacy", ID: legacyID}, testAdminToken)
+
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	var errResp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "id_exists", errResp.Error)
+	urlRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShortenURL_AdminSuppliedIDWithCustomKeyRejected(t *testing.T) {
+	router := newLegacyIDRouter(t, &MockURLRepository{}, &MockCacheRepository{})
+	w := postShorten(router, dto.ShortenURLRequest{LongURL: "https://example.com/legacy", ID: legacyID, CustomKey: "docs"}, testAdminToken)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
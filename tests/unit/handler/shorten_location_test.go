@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+)
+
+func TestShortenURL_SetsLocationHeader(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, "docs", mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.POST("/", f.urlHandler.ShortenURL)
+
+	body, _ := json.Marshal(dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "https://short.ly/docs", w.Header().Get("Location"))
+
+	var resp dto.ShortenURLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, resp.ShortURL, w.Header().Get("Location"))
+}
+
+func TestShortenURL_NoLocationOnError(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(true, nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.POST("/", f.urlHandler.ShortenURL)
+
+	body, _ := json.Marshal(dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Empty(t, w.Header().Get("Location"))
+}
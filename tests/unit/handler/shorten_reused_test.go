@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func TestShortenURL_ReusedDestinationReturns200(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	// The first request finds nothing and saves; the second finds what was saved
+	var saved *entity.URL
+
+	urlRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound).Once()
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("Save", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.URL) }).
+		Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.POST("/", f.urlHandler.ShortenURL)
+
+	shorten := func() (*httptest.ResponseRecorder, dto.ShortenURLResponse) {
+		body, _ := json.Marshal(dto.ShortenURLRequest{LongURL: "https://example.com/pricing"})
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		f.router.ServeHTTP(w, req)
+
+		var resp dto.ShortenURLResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+		return w, resp
+	}
+
+	first, created := shorten()
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.False(t, created.Reused)
+	require.NotNil(t, saved)
+
+	urlRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(saved, nil)
+
+	second, reused := shorten()
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.True(t, reused.Reused)
+	assert.Equal(t, created.ShortKey, reused.ShortKey)
+	assert.Equal(t, created.ShortURL, second.Header().Get("Location"))
+
+	urlRepo.AssertNumberOfCalls(t, "Save", 1)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func newStatsCacheRouter(t *testing.T, urlRepo *MockURLRepository, maxAge time.Duration) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.urlHandler.SetStatsCacheMaxAge(maxAge)
+	f.router.GET("/stats/:shortKey", f.urlHandler.GetStats)
+
+	return f.router
+}
+
+func TestStatsCacheControl_PrivateMaxAgeOnSuccess(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+
+	w := httptest.NewRecorder()
+	newStatsCacheRouter(t, urlRepo, 5*time.Second).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "private, max-age=5", w.Header().Get("Cache-Control"))
+
+	// A zero max age leaves caching to the client
+	w = httptest.NewRecorder()
+	newStatsCacheRouter(t, urlRepo, 0).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/docs", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
+
+func TestStatsCacheControl_NoStoreOnErrors(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+
+	expired := entity.NewURL(shortKey, longURL)
+	expired.SetExpiration(-time.Hour)
+
+	tests := []struct {
+		name    string
+		found   *entity.URL
+		findErr error
+		status  int
+	}{
+		{name: "Not found", findErr: repository.ErrNotFound, status: http.StatusNotFound},
+		{name: "Expired", found: expired, status: http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(tt.found, tt.findErr)
+
+			w := httptest.NewRecorder()
+			newStatsCacheRouter(t, urlRepo, 5*time.Second).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/docs", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			assert.NotContains(t, w.Header().Get("Cache-Control"), "max-age")
+		})
+	}
+}
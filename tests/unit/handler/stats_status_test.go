@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestGetStats_StatusDistinguishesMissingFromExpired(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+
+	expired := entity.NewURL(shortKey, longURL)
+	expired.SetExpiration(-time.Hour)
+
+	tests := []struct {
+		name       string
+		found      *entity.URL
+		findErr    error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "Never existed", findErr: repository.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: "not_found"},
+		{name: "Expired", found: expired, wantStatus: http.StatusGone, wantCode: "url_expired"},
+		{name: "Lookup failure", findErr: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantCode: "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			if tt.found != nil {
+				urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(tt.found, nil)
+			} else {
+				urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, tt.findErr)
+			}
+
+			router := newCacheStatusRouter(t, urlRepo, &MockCacheRepository{}, false)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stats/docs", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			var resp dto.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// setupThrottledRouter returns a redirect route that allows a single request per client.
+func setupThrottledRouter(mode string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	rateLimiter := middleware.NewRateLimiter(1, 1)
+
+	router := gin.New()
+	router.GET("/s/:shortKey", rateLimiter.LimitWith(handler.RedirectThrottleResponder(mode)), func(c *gin.Context) {
+		c.Redirect(http.StatusFound, "https://example.com")
+	})
+
+	return router
+}
+
+// throttledRequest exhausts the limiter and returns the response to the next request.
+func throttledRequest(router *gin.Engine, accept string) *httptest.ResponseRecorder {
+	first := httptest.NewRecorder()
+	router.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/s/abc123", nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/s/abc123", nil)
+	req.Header.Set("Accept", accept)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestThrottledRedirect_BrowserGetsHTML(t *testing.T) {
+	w := throttledRequest(setupThrottledRouter(handler.ThrottleResponseHTML), "text/html,application/xhtml+xml")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "Too many requests")
+}
+
+func TestThrottledRedirect_APIClientGetsJSON(t *testing.T) {
+	w := throttledRequest(setupThrottledRouter(handler.ThrottleResponseHTML), "application/json")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+
+	var errResp dto.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, "rate_limit_exceeded", errResp.Error)
+}
+
+func TestThrottledRedirect_UnavailableMode(t *testing.T) {
+	router := setupThrottledRouter(handler.ThrottleResponseUnavailable)
+	w := throttledRequest(router, "text/html")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+}
+
+func TestThrottledRedirect_JSONMode(t *testing.T) {
+	w := throttledRequest(setupThrottledRouter(handler.ThrottleResponseJSON), "text/html")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func newTimeseriesRouter(t *testing.T, urlRepo *MockURLRepository, clickRepo *MockClickRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, clicks: clickRepo}, nil)
+	f.router.GET("/api/analytics/:shortKey/timeseries", f.analyticsHandler.GetTimeseries)
+
+	return f.router
+}
+
+func TestGetTimeseries_BucketsClicksByDay(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	longURL, _ := valueobject.NewLongURL("https://example.com/landing")
+
+	from := time.Date(2024, 2, 27, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC) // day after the inclusive "to"
+
+	// Buckets may come back in another zone; each is placed on its UTC day
+	jakarta := time.FixedZone("WIB", 7*60*60)
+
+	urlRepo := &MockURLRepository{}
+	clickRepo := &MockClickRepository{}
+
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	clickRepo.On("GetClicksByDay", mock.Anything, "abc123", from, end).Return([]repository.DailyClicks{
+		{Day: from, Clicks: 3},
+		{Day: time.Date(2024, 2, 29, 7, 0, 0, 0, jakarta), Clicks: 11},
+		{Day: time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), Clicks: 1},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/analytics/abc123/timeseries?from=2024-02-27&to=2024-03-02", nil)
+	newTimeseriesRouter(t, urlRepo, clickRepo).ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp dto.TimeseriesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	assert.Equal(t, "abc123", resp.ShortKey)
+	assert.Equal(t, "2024-02-27", resp.From)
+	assert.Equal(t, "2024-03-02", resp.To)
+	assert.Equal(t, int64(15), resp.TotalClicks)
+	assert.Equal(t, []dto.DailyClicks{
+		{Date: "2024-02-27", Clicks: 3},
+		{Date: "2024-02-28", Clicks: 0},
+		{Date: "2024-02-29", Clicks: 11},
+		{Date: "2024-03-01", Clicks: 0},
+		{Date: "2024-03-02", Clicks: 1},
+	}, resp.Timeline)
+
+	clickRepo.AssertExpectations(t)
+}
+
+func TestGetTimeseries_Errors(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	router := newTimeseriesRouter(t, urlRepo, &MockClickRepository{})
+
+	testCases := []struct {
+		path   string
+		status int
+	}{
+		{"/api/analytics/abc123/timeseries?from=2024-03-05&to=2024-03-01", http.StatusBadRequest},
+		{"/api/analytics/abc123/timeseries?days=0", http.StatusBadRequest},
+		{"/api/analytics/missing/timeseries", http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		assert.Equal(t, tc.status, w.Code, tc.path)
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func TestRedirect_TombstoneReasonsMapToStatus(t *testing.T) {
+	tests := []struct {
+		reason    string
+		status    int
+		errorCode string
+	}{
+		{repository.TombstoneNotFound, http.StatusNotFound, "not_found"},
+		{repository.TombstoneDeleted, http.StatusGone, "url_deleted"},
+		{repository.TombstoneDisabled, http.StatusGone, "url_disabled"},
+		{repository.TombstoneExpired, http.StatusGone, "url_expired"},
+		{"unknown", http.StatusNotFound, "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			cacheRepo := &MockCacheRepository{}
+			cacheRepo.On("GetCacheEntry", mock.Anything, "gone").Return(&repository.CacheEntry{
+				IsTombstone: true,
+				Reason:      tt.reason,
+				CreatedAt:   time.Now(),
+			}, nil)
+
+			router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/gone", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Contains(t, w.Body.String(), `"error":"`+tt.errorCode+`"`)
+			urlRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestRedirect_NeverExistedIsNotFoundAndTombstonedAsSuch(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "nothere").Return(nil, assert.AnError)
+	cacheRepo.On("Get", mock.Anything, "nothere").Return("", assert.AnError)
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	cacheRepo.On("SetTombstone", mock.Anything, "nothere", repository.TombstoneNotFound, time.Hour).Return(nil)
+
+	router := newCacheStatusRouter(t, urlRepo, cacheRepo, false)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/nothere", nil))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	cacheRepo.AssertCalled(t, "SetTombstone", mock.Anything, "nothere", repository.TombstoneNotFound, time.Hour)
+}
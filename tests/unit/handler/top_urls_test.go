@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func newTopURLsRouter(t *testing.T, urlRepo *MockURLRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo}, nil)
+	f.router.GET("/api/admin/analytics/top", f.analyticsHandler.GetTopURLs)
+
+	return f.router
+}
+
+func visitedURL(key string, visits int64) *entity.URL {
+	shortKey, _ := valueobject.NewShortKey(key)
+	longURL, _ := valueobject.NewLongURL("https://example.com/" + key)
+
+	url := entity.NewURL(shortKey, longURL)
+	url.VisitCount = visits
+
+	return url
+}
+
+func getTopURLs(t *testing.T, router *gin.Engine, query string) dto.TopURLsResponse {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/analytics/top"+query, nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp dto.TopURLsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+
+	return resp
+}
+
+func TestGetTopURLs_KeepsRepositoryRanking(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	urlRepo.On("FindTopByVisits", mock.Anything, 3, (*time.Time)(nil)).
+		Return([]*entity.URL{visitedURL("hot", 900), visitedURL("warm", 120), visitedURL("mild", 7)}, nil)
+
+	resp := getTopURLs(t, newTopURLsRouter(t, urlRepo), "?limit=3")
+
+	require.Len(t, resp.Items, 3)
+	assert.Equal(t, 3, resp.Limit)
+	assert.Empty(t, resp.Since)
+
+	for i, want := range []struct {
+		key    string
+		visits int64
+	}{{"hot", 900}, {"warm", 120}, {"mild", 7}} {
+		assert.Equal(t, want.key, resp.Items[i].ShortKey)
+		assert.Equal(t, want.visits, resp.Items[i].VisitCount)
+	}
+}
+
+func TestGetTopURLs_EnforcesLimit(t *testing.T) {
+	testCases := []struct {
+		query string
+		limit int
+	}{
+		{"", usecase.DefaultTopURLs},
+		{"?limit=0", usecase.DefaultTopURLs},
+		{"?limit=5", 5},
+		{"?limit=5000", usecase.MaxTopURLs},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			urlRepo.On("FindTopByVisits", mock.Anything, tc.limit, (*time.Time)(nil)).Return([]*entity.URL{}, nil)
+
+			resp := getTopURLs(t, newTopURLsRouter(t, urlRepo), tc.query)
+
+			assert.Equal(t, tc.limit, resp.Limit)
+			assert.NotNil(t, resp.Items)
+			urlRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTopURLs_FiltersBySince(t *testing.T) {
+	testCases := []struct {
+		since string
+		want  time.Time
+	}{
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-03-01T12:30:00%2B02:00", time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.since, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			urlRepo.On("FindTopByVisits", mock.Anything, usecase.DefaultTopURLs, mock.MatchedBy(func(since *time.Time) bool {
+				return since != nil && since.Equal(tc.want)
+			})).Return([]*entity.URL{visitedURL("fresh", 3)}, nil)
+
+			resp := getTopURLs(t, newTopURLsRouter(t, urlRepo), "?since="+tc.since)
+
+			assert.Equal(t, tc.want.Format(time.RFC3339), resp.Since)
+			require.Len(t, resp.Items, 1)
+			urlRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetTopURLs_RejectsBadParameters(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	router := newTopURLsRouter(t, urlRepo)
+
+	for _, query := range []string{"?limit=ten", "?since=yesterday"} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/admin/analytics/top"+query, nil))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	urlRepo.AssertNotCalled(t, "FindTopByVisits", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// installSpanRecorder registers an in-memory exporter as the global tracer
+// provider for the duration of the test.
+func installSpanRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	otel.SetTracerProvider(provider)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func spansByName(spans tracetest.SpanStubs) map[string]tracetest.SpanStub {
+	byName := make(map[string]tracetest.SpanStub, len(spans))
+	for _, span := range spans {
+		byName[span.Name] = span
+	}
+
+	return byName
+}
+
+func attributeValue(span tracetest.SpanStub, key string) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if string(attr.Key) == key {
+			return attr.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestTracing_RedirectEmitsServerAndUseCaseSpans(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := installSpanRecorder(t)
+
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/docs")
+	url := entity.NewURL(shortKey, longURL)
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	cacheRepo.On("GetCacheEntry", mock.Anything, "docs").Return(nil, errors.New("cache miss"))
+	cacheRepo.On("Get", mock.Anything, "docs").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(url, nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, "docs", mock.Anything, mock.Anything).Return(nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.Use(middleware.Tracing())
+	f.router.GET("/s/:shortKey", f.urlHandler.RedirectURL)
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s/docs", nil))
+	require.Equal(t, http.StatusFound, w.Code)
+
+	spans := spansByName(exporter.GetSpans())
+	require.Contains(t, spans, "GET /s/:shortKey")
+	require.Contains(t, spans, "GetLongURL")
+	require.Contains(t, spans, "GetLongURL.cache.get")
+	require.Contains(t, spans, "GetLongURL.db.find")
+
+	server := spans["GET /s/:shortKey"]
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+
+	status, ok := attributeValue(server, "http.status_code")
+	require.True(t, ok)
+	assert.Equal(t, int64(http.StatusFound), status.AsInt64())
+
+	// Use case spans hang off the server span and carry the short key
+	getLongURL := spans["GetLongURL"]
+	assert.Equal(t, server.SpanContext.SpanID(), getLongURL.Parent.SpanID())
+
+	for _, name := range []string{"GetLongURL", "GetLongURL.cache.get", "GetLongURL.db.find"} {
+		value, ok := attributeValue(spans[name], "short_key")
+		require.True(t, ok, "span %s should carry short_key", name)
+		assert.Equal(t, "docs", value.AsString())
+	}
+
+	hit, ok := attributeValue(spans["GetLongURL.cache.get"], "cache.hit")
+	require.True(t, ok)
+	assert.False(t, hit.AsBool())
+}
+
+func TestTracing_ShortenEmitsDBAndCacheSpans(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := installSpanRecorder(t)
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	urlRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("SetCacheEntry", mock.Anything, "docs", mock.Anything, mock.Anything).Return(nil)
+
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.Use(middleware.Tracing())
+	f.router.POST("/", f.urlHandler.ShortenURL)
+
+	body, _ := json.Marshal(dto.ShortenURLRequest{LongURL: "https://example.com/docs", CustomKey: "docs"})
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	spans := spansByName(exporter.GetSpans())
+	require.Contains(t, spans, "POST /")
+	require.Contains(t, spans, "Shorten")
+	require.Contains(t, spans, "Shorten.db.save")
+	require.Contains(t, spans, "Shorten.cache.set")
+
+	for _, name := range []string{"Shorten", "Shorten.db.save", "Shorten.cache.set"} {
+		value, ok := attributeValue(spans[name], "short_key")
+		require.True(t, ok, "span %s should carry short_key", name)
+		assert.Equal(t, "docs", value.AsString())
+	}
+}
+
+func TestTracing_NoProviderIsNoop(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Tracing())
+	router.GET("/ping", func(c *gin.Context) {
+		// Without an SDK provider installed the spans are non-recording
+		assert.False(t, trace.SpanFromContext(c.Request.Context()).IsRecording())
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
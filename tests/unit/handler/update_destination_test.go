@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func newUpdateDestinationRouter(t *testing.T, urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) *gin.Engine {
+	f := newHandlerFixture(t, handlerRepos{urls: urlRepo, cache: cacheRepo}, nil)
+	f.router.PUT("/api/urls/:shortKey", middleware.RequireAdmin(testAdminToken), f.urlHandler.UpdateURLDestination)
+
+	return f.router
+}
+
+func putDestination(router *gin.Engine, key, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/urls/"+key, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestUpdateURLDestination_RepointsAndInvalidatesCache(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("docs")
+	longURL, _ := valueobject.NewLongURL("https://example.com/old")
+
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	urlRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	cacheRepo.On("Delete", mock.Anything, "docs").Return(nil)
+
+	w := putDestination(newUpdateDestinationRouter(t, urlRepo, cacheRepo), "docs", `{"long_url": "https://example.com/new"}`, testAdminToken)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp dto.ShortenURLResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "https://short.ly/docs", resp.ShortURL)
+	assert.Equal(t, "https://example.com/new", resp.LongURL)
+
+	cacheRepo.AssertCalled(t, "Delete", mock.Anything, "docs")
+}
+
+func TestUpdateURLDestination_Errors(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	router := newUpdateDestinationRouter(t, urlRepo, cacheRepo)
+
+	w := putDestination(router, "docs", `{"long_url": "https://example.com/new"}`, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = putDestination(router, "docs", `{}`, testAdminToken)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = putDestination(router, "missing", `{"long_url": "https://example.com/new"}`, testAdminToken)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	urlRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	cacheRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
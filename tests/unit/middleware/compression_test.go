@@ -0,0 +1,168 @@
+package middleware_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// largeStats is a full stats response with a year of daily clicks, well above the minimum size.
+func largeStats() dto.FullStatsResponse {
+	stats := dto.FullStatsResponse{
+		Stats: &dto.URLStatsResponse{ShortKey: "docs", LongURL: "https://example.com/docs", VisitCount: 365},
+		From:  "2025-01-01",
+		To:    "2025-12-31",
+	}
+
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 365; i++ {
+		stats.Timeline = append(stats.Timeline, dto.DailyClicks{Date: day.AddDate(0, 0, i).Format("2006-01-02"), Clicks: 1})
+	}
+
+	return stats
+}
+
+// newCompressionRouter serves the large stats response, a small body, a redirect and a PNG.
+func newCompressionRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.Compression(middleware.DefaultCompressionMinSize))
+	router.Use(middleware.ProcessingTime())
+	router.GET("/api/stats/docs/full", func(c *gin.Context) {
+		c.JSON(http.StatusOK, largeStats())
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/s/docs", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, "https://example.com/"+string(bytes.Repeat([]byte("a"), 2048)))
+	})
+	router.GET("/qr", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", bytes.Repeat([]byte{0}, 4096))
+	})
+
+	return router
+}
+
+func serveWithEncoding(router http.Handler, target, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestCompression_GzipsLargeStatsWhenAccepted(t *testing.T) {
+	router := newCompressionRouter()
+	expected, err := json.Marshal(largeStats())
+	require.NoError(t, err)
+
+	w := serveWithEncoding(router, "/api/stats/docs/full", "gzip, deflate, br")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+	assert.Equal(t, fmt.Sprint(w.Body.Len()), w.Header().Get("Content-Length"))
+	assert.Less(t, w.Body.Len(), len(expected))
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(decoded))
+}
+
+func TestCompression_LeavesStatsAloneWithoutAcceptEncoding(t *testing.T) {
+	router := newCompressionRouter()
+	expected, err := json.Marshal(largeStats())
+	require.NoError(t, err)
+
+	for _, acceptEncoding := range []string{"", "identity", "br", "gzip;q=0, deflate;q=0"} {
+		w := serveWithEncoding(router, "/api/stats/docs/full", acceptEncoding)
+
+		assert.Equal(t, http.StatusOK, w.Code, acceptEncoding)
+		assert.Empty(t, w.Header().Get("Content-Encoding"), acceptEncoding)
+		assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding", acceptEncoding)
+		assert.JSONEq(t, string(expected), w.Body.String(), acceptEncoding)
+	}
+}
+
+func TestCompression_DeflateWhenGzipRefused(t *testing.T) {
+	router := newCompressionRouter()
+
+	w := serveWithEncoding(router, "/api/stats/docs/full", "gzip;q=0, deflate")
+
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+	reader, err := zlib.NewReader(w.Body)
+	require.NoError(t, err)
+
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Contains(t, string(decoded), `"short_key":"docs"`)
+}
+
+func TestCompression_SkipsSmallRedirectAndImageResponses(t *testing.T) {
+	router := newCompressionRouter()
+
+	tests := []struct {
+		name   string
+		target string
+		status int
+	}{
+		{"Below minimum size", "/small", http.StatusOK},
+		{"Redirect", "/s/docs", http.StatusFound},
+		{"Image", "/qr", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveWithEncoding(router, tt.target, "gzip")
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Encoding"))
+		})
+	}
+
+	redirect := serveWithEncoding(router, "/s/docs", "gzip")
+	assert.Contains(t, redirect.Header().Get("Location"), "https://example.com/")
+}
+
+func TestCompression_KeepsProcessingTimeHeader(t *testing.T) {
+	// A real server drops headers set after the body is written, unlike the recorder
+	server := httptest.NewServer(newCompressionRouter())
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/stats/docs/full", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+	assert.NotEmpty(t, resp.Header.Get(middleware.ProcessingTimeHeader))
+}
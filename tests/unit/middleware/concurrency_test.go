@@ -0,0 +1,87 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func TestConcurrencyLimit_RejectsExcessRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const (
+		limit = 3
+		total = 8
+	)
+
+	// Handlers block until released so every admitted request stays in flight
+	entered := make(chan struct{}, total)
+	release := make(chan struct{})
+
+	router := gin.New()
+	router.POST("/", middleware.ConcurrencyLimit(limit), func(c *gin.Context) {
+		entered <- struct{}{}
+		<-release
+		c.Status(http.StatusCreated)
+	})
+
+	codes := make(chan *httptest.ResponseRecorder, total)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+			codes <- w
+		}()
+	}
+
+	// Wait for the admitted requests to occupy every slot, and for the rest to be turned away
+	for i := 0; i < limit; i++ {
+		<-entered
+	}
+
+	for i := 0; i < total-limit; i++ {
+		w := <-codes
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "1", w.Header().Get("Retry-After"))
+		assert.Contains(t, w.Body.String(), "server_busy")
+	}
+
+	close(release)
+	wg.Wait()
+	close(codes)
+
+	for w := range codes {
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	// Slots are released once requests finish
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestConcurrencyLimit_DisabledWhenNotPositive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/", middleware.ConcurrencyLimit(0), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func setupContentTypeRouter(allowed ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.POST("/", middleware.RequireContentType(allowed...), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	return router
+}
+
+func TestRequireContentType(t *testing.T) {
+	router := setupContentTypeRouter("application/json")
+
+	tests := []struct {
+		name        string
+		contentType string
+		expectCode  int
+	}{
+		{"JSON accepted", "application/json", http.StatusCreated},
+		{"JSON with charset accepted", "application/json; charset=utf-8", http.StatusCreated},
+		{"Media type is case-insensitive", "Application/JSON", http.StatusCreated},
+		{"Form-encoded rejected", "application/x-www-form-urlencoded", http.StatusUnsupportedMediaType},
+		{"Plain text rejected", "text/plain", http.StatusUnsupportedMediaType},
+		{"Missing content type rejected", "", http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"long_url":"https://example.com"}`))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectCode, w.Code)
+
+			if tt.expectCode == http.StatusUnsupportedMediaType {
+				assert.Contains(t, w.Body.String(), `"error":"unsupported_media_type"`)
+			}
+		})
+	}
+}
+
+func TestRequireContentType_Allowlist(t *testing.T) {
+	router := setupContentTypeRouter("application/json", "application/x-www-form-urlencoded")
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("long_url=https://example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestRequireContentType_DefaultsToJSON(t *testing.T) {
+	router := setupContentTypeRouter()
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("long_url=https://example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+}
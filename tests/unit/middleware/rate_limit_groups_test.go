@@ -0,0 +1,147 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// testAPIKeys are the API clients known to the limiters under test, by name.
+var testAPIKeys = map[string]string{"client-a": "secret-a", "client-b": "secret-b"}
+
+// newGroupedRouter routes POST /shorten through the "shorten" group and GET /s through
+// the "redirect" group of rateLimiter; GET /other uses its default limit.
+func newGroupedRouter(rateLimiter middleware.Limiter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+
+	router := gin.New()
+	router.POST("/shorten", rateLimiter.LimitGroup("shorten"), ok)
+	router.GET("/s", rateLimiter.LimitGroup("redirect"), ok)
+	router.GET("/other", rateLimiter.Limit(), ok)
+
+	return router
+}
+
+// countAllowed sends n requests and returns how many were not throttled.
+func countAllowed(router *gin.Engine, method, path, ip, apiKey string, n int) int {
+	allowed := 0
+
+	for i := 0; i < n; i++ {
+		req := httptest.NewRequest(method, path, nil)
+		req.RemoteAddr = ip + ":12345"
+
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code == http.StatusOK {
+			allowed++
+		}
+	}
+
+	return allowed
+}
+
+func TestRateLimiter_GroupsHaveTheirOwnLimits(t *testing.T) {
+	rateLimiter := middleware.NewRateLimiter(5, 5)
+	rateLimiter.SetGroupLimits(map[string]int{"shorten": 2, "redirect": 10})
+
+	router := newGroupedRouter(rateLimiter)
+
+	assert.Equal(t, 2, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "", 6))
+	// Exhausting the shorten limit leaves the redirect budget untouched
+	assert.Equal(t, 10, countAllowed(router, http.MethodGet, "/s", "10.0.0.1", "", 15))
+	// Routes outside a configured group use the limiter's defaults
+	assert.Equal(t, 5, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "", 8))
+}
+
+func TestRateLimiter_APIKeysUseSeparateBuckets(t *testing.T) {
+	rateLimiter := middleware.NewRateLimiter(60, 2)
+	rateLimiter.SetKeyFunc(middleware.APIKeyOrIPKey("X-API-Key", testAPIKeys))
+
+	router := newGroupedRouter(rateLimiter)
+
+	// Anonymous requests use up the IP's bucket
+	assert.Equal(t, 2, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "", 4))
+
+	// API clients behind the same IP each still get a full bucket
+	assert.Equal(t, 2, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "secret-a", 4))
+	assert.Equal(t, 2, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "secret-b", 4))
+
+	// A key keeps its bucket when it moves to another IP
+	assert.Equal(t, 0, countAllowed(router, http.MethodGet, "/other", "10.0.0.2", "secret-a", 1))
+
+	// Buckets are named after the client, so reports never show the key itself
+	assert.Equal(t, []middleware.ThrottleCount{
+		{Visitor: "key:client-a", Denied: 3},
+		{Visitor: "10.0.0.1", Denied: 2},
+		{Visitor: "key:client-b", Denied: 2},
+	}, rateLimiter.TopThrottled(0))
+}
+
+func TestRateLimiter_UnknownAPIKeysShareTheIPBucket(t *testing.T) {
+	rateLimiter := middleware.NewRateLimiter(60, 2)
+	rateLimiter.SetKeyFunc(middleware.APIKeyOrIPKey("X-API-Key", testAPIKeys))
+
+	router := newGroupedRouter(rateLimiter)
+
+	// Rotating made-up keys does not earn a fresh budget
+	allowed := 0
+	for i := 0; i < 4; i++ {
+		allowed += countAllowed(router, http.MethodGet, "/other", "10.0.0.1", fmt.Sprintf("junk-%d", i), 1)
+	}
+
+	assert.Equal(t, 2, allowed)
+	assert.Equal(t, 0, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "", 1))
+}
+
+func TestRateLimiter_APIKeysGetTheirTierLimit(t *testing.T) {
+	rateLimiter := middleware.NewRateLimiter(5, 5)
+	rateLimiter.SetGroupLimits(map[string]int{"shorten": 2})
+	rateLimiter.SetKeyFunc(middleware.APIKeyOrIPKey("X-API-Key", testAPIKeys))
+	rateLimiter.SetAPIKeyMultiplier(3)
+
+	router := newGroupedRouter(rateLimiter)
+
+	assert.Equal(t, 2, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "", 10))
+	assert.Equal(t, 6, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "secret-a", 10))
+	assert.Equal(t, 15, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "secret-a", 20))
+}
+
+func TestRedisRateLimiter_GroupsAndAPIKeys(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	rateLimiter := middleware.NewRedisRateLimiter(client, "test:", 5, time.Minute)
+	rateLimiter.SetGroupLimits(map[string]int{"shorten": 2, "redirect": 10})
+	rateLimiter.SetKeyFunc(middleware.APIKeyOrIPKey("X-API-Key", testAPIKeys))
+	rateLimiter.SetAPIKeyMultiplier(3)
+
+	router := newGroupedRouter(rateLimiter)
+
+	assert.Equal(t, 2, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "", 6))
+	assert.Equal(t, 10, countAllowed(router, http.MethodGet, "/s", "10.0.0.1", "", 15))
+	assert.Equal(t, 5, countAllowed(router, http.MethodGet, "/other", "10.0.0.1", "", 8))
+
+	assert.Equal(t, 6, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "secret-a", 10))
+	assert.True(t, server.Exists("test:ratelimit:shorten:key:client-a"))
+
+	// An unknown key is counted with the IP's exhausted bucket
+	assert.Equal(t, 0, countAllowed(router, http.MethodPost, "/shorten", "10.0.0.1", "junk", 1))
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// headerInt returns the integer value of header in w, failing the test if it is missing.
+func headerInt(t *testing.T, w *httptest.ResponseRecorder, header string) int {
+	t.Helper()
+
+	value, err := strconv.Atoi(w.Header().Get(header))
+	require.NoError(t, err, "%s: %q", header, w.Header().Get(header))
+
+	return value
+}
+
+func TestRateLimiter_SetsRateLimitHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// Three requests per minute: one token every 20 seconds
+	rateLimiter := middleware.NewRateLimiter(3, 3)
+
+	router := gin.New()
+	router.GET("/", rateLimiter.Limit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 1; i <= 3; i++ {
+		w := sendFrom(router, "10.0.0.1")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 3, headerInt(t, w, "X-RateLimit-Limit"))
+		assert.Equal(t, 3-i, headerInt(t, w, "X-RateLimit-Remaining"))
+		assert.InDelta(t, 20*i, headerInt(t, w, "X-RateLimit-Reset"), 1)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	}
+
+	w := sendFrom(router, "10.0.0.1")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, 3, headerInt(t, w, "X-RateLimit-Limit"))
+	assert.Equal(t, 0, headerInt(t, w, "X-RateLimit-Remaining"))
+	assert.InDelta(t, 60, headerInt(t, w, "X-RateLimit-Reset"), 1)
+	assert.Equal(t, 20, headerInt(t, w, "Retry-After"))
+}
+
+func TestRedisRateLimiter_SetsRateLimitHeaders(t *testing.T) {
+	server, _, router := newRedisLimitedRouter(t, 2, 30*time.Second)
+
+	for i := 1; i <= 2; i++ {
+		w := sendFrom(router, "10.0.0.1")
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, headerInt(t, w, "X-RateLimit-Limit"))
+		assert.Equal(t, 2-i, headerInt(t, w, "X-RateLimit-Remaining"))
+		assert.Equal(t, 30, headerInt(t, w, "X-RateLimit-Reset"))
+	}
+
+	server.FastForward(10 * time.Second)
+
+	w := sendFrom(router, "10.0.0.1")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, 0, headerInt(t, w, "X-RateLimit-Remaining"))
+	assert.Equal(t, 20, headerInt(t, w, "X-RateLimit-Reset"))
+	assert.Equal(t, 20, headerInt(t, w, "Retry-After"))
+}
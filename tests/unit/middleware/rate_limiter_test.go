@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func TestRateLimiter_CountsThrottledRequestsPerVisitor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// One request per minute with a burst of one: every request after the first is denied
+	rateLimiter := middleware.NewRateLimiter(1, 1)
+
+	router := gin.New()
+	router.GET("/", rateLimiter.Limit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	send := func(ip string, times int) {
+		for i := 0; i < times; i++ {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = ip + ":12345"
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+		}
+	}
+
+	send("10.0.0.1", 5)
+	send("10.0.0.2", 3)
+	send("10.0.0.3", 1)
+
+	assert.Equal(t, []middleware.ThrottleCount{
+		{Visitor: "10.0.0.1", Denied: 4},
+		{Visitor: "10.0.0.2", Denied: 2},
+	}, rateLimiter.TopThrottled(0))
+	assert.Equal(t, int64(6), rateLimiter.TotalThrottled())
+
+	assert.Equal(t, []middleware.ThrottleCount{{Visitor: "10.0.0.1", Denied: 4}}, rateLimiter.TopThrottled(1))
+}
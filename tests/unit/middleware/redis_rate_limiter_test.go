@@ -0,0 +1,100 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+func newRedisLimitedRouter(t *testing.T, requests int, window time.Duration) (*miniredis.Miniredis, *redis.Client, *gin.Engine) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	rateLimiter := middleware.NewRedisRateLimiter(client, "test:", requests, window)
+
+	router := gin.New()
+	router.GET("/", rateLimiter.Limit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	return server, client, router
+}
+
+func sendFrom(router *gin.Engine, ip string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = ip + ":12345"
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestRedisRateLimiter_RejectsRequestsOverLimit(t *testing.T) {
+	server, _, router := newRedisLimitedRouter(t, 3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1").Code, "request %d", i+1)
+	}
+
+	w := sendFrom(router, "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+	// Other clients have their own count
+	assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.2").Code)
+
+	// The counter lives in Redis under the key prefix, with the window as its TTL
+	assert.True(t, server.Exists("test:ratelimit:10.0.0.1"))
+	assert.Equal(t, time.Minute, server.TTL("test:ratelimit:10.0.0.1"))
+}
+
+func TestRedisRateLimiter_SharesLimitAcrossInstances(t *testing.T) {
+	_, client, first := newRedisLimitedRouter(t, 2, time.Minute)
+
+	// A second instance using the same Redis
+	second := gin.New()
+	second.GET("/", middleware.NewRedisRateLimiter(client, "test:", 2, time.Minute).Limit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	assert.Equal(t, http.StatusOK, sendFrom(first, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, sendFrom(second, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendFrom(first, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendFrom(second, "10.0.0.1").Code)
+}
+
+func TestRedisRateLimiter_WindowResetsAfterExpiry(t *testing.T) {
+	server, _, router := newRedisLimitedRouter(t, 1, 10*time.Second)
+
+	assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendFrom(router, "10.0.0.1").Code)
+
+	server.FastForward(10 * time.Second)
+
+	assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendFrom(router, "10.0.0.1").Code)
+}
+
+func TestRedisRateLimiter_AllowsRequestsWhenRedisIsDown(t *testing.T) {
+	server, _, router := newRedisLimitedRouter(t, 1, time.Minute)
+
+	server.Close()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, sendFrom(router, "10.0.0.1").Code, "request %d", i+1)
+	}
+}
@@ -0,0 +1,106 @@
+package middleware_test
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/async"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// newRequestIDRouter returns a router whose handler records the request ID it sees in
+// the gin context and in the request context.
+func newRequestIDRouter(handlers ...gin.HandlerFunc) (*gin.Engine, *[2]string) {
+	gin.SetMode(gin.TestMode)
+
+	var seen [2]string
+
+	router := gin.New()
+	router.Use(handlers...)
+	router.GET("/", func(c *gin.Context) {
+		seen = [2]string{middleware.GetRequestID(c), async.RequestID(c.Request.Context())}
+		c.Status(http.StatusOK)
+	})
+
+	return router, &seen
+}
+
+func TestRequestID_GeneratesAndEchoesID(t *testing.T) {
+	router, seen := newRequestIDRouter(middleware.RequestID(), middleware.Logger())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	requestID := w.Header().Get(middleware.RequestIDHeader)
+	assert.Len(t, requestID, 32)
+	assert.Equal(t, [2]string{requestID, requestID}, *seen)
+
+	// Each request gets a fresh ID
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.NotEqual(t, requestID, w.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestRequestID_PreservesInboundID(t *testing.T) {
+	router, seen := newRequestIDRouter(middleware.RequestID(), middleware.Logger())
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.RequestIDHeader, "upstream-7f3a")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "upstream-7f3a", w.Header().Get(middleware.RequestIDHeader))
+	assert.Equal(t, [2]string{"upstream-7f3a", "upstream-7f3a"}, *seen)
+
+	// Oversized inbound IDs are replaced rather than logged
+	req.Header.Set(middleware.RequestIDHeader, strings.Repeat("x", 200))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Len(t, w.Header().Get(middleware.RequestIDHeader), 32)
+}
+
+func TestLogger_AssignsIDAndLogsKeyValues(t *testing.T) {
+	var buf bytes.Buffer
+
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(os.Stderr) })
+
+	// Without RequestID in the chain, Logger assigns the ID itself
+	router, seen := newRequestIDRouter(middleware.Logger())
+
+	req := httptest.NewRequest(http.MethodGet, "/?q=1", nil)
+	req.RemoteAddr = "203.0.113.9:4321"
+	req.Header.Set(middleware.RequestIDHeader, "req-42")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, "req-42", w.Header().Get(middleware.RequestIDHeader))
+	assert.Equal(t, [2]string{"req-42", "req-42"}, *seen)
+
+	line := buf.String()
+	for _, field := range []string{
+		"event=request",
+		"method=GET",
+		`path="/"`,
+		`query="q=1"`,
+		"status=200",
+		"latency_ms=",
+		"client_ip=203.0.113.9",
+		"request_id=req-42",
+	} {
+		assert.Contains(t, line, field)
+	}
+}
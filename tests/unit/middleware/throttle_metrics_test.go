@@ -0,0 +1,92 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/metrics"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// throttleCount returns the rate_limited_requests_total counter for group.
+func throttleCount(t *testing.T, reg *prometheus.Registry, group string) float64 {
+	t.Helper()
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+
+	for _, family := range families {
+		if family.GetName() != metrics.Namespace+"_rate_limited_requests_total" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "group" && label.GetValue() == group {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestRateLimiter_ExportsThrottlesToMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	reg := prometheus.NewRegistry()
+	rateLimitMetrics, err := metrics.NewRateLimitMetrics(reg)
+	require.NoError(t, err)
+
+	rateLimiter := middleware.NewRateLimiter(1, 1)
+	rateLimiter.SetThrottleObserver(rateLimitMetrics)
+
+	router := gin.New()
+	router.GET("/", rateLimiter.Limit(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 3; i++ {
+		sendFrom(router, "10.0.0.1")
+	}
+
+	// Routes without a group of their own are counted as "default"
+	assert.Equal(t, float64(2), throttleCount(t, reg, "default"))
+	assert.Equal(t, int64(2), rateLimiter.TotalThrottled())
+}
+
+func TestRedisRateLimiter_ExportsThrottlesToMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { client.Close() })
+
+	reg := prometheus.NewRegistry()
+	rateLimitMetrics, err := metrics.NewRateLimitMetrics(reg)
+	require.NoError(t, err)
+
+	rateLimiter := middleware.NewRedisRateLimiter(client, "test:", 2, time.Minute)
+	rateLimiter.SetThrottleObserver(rateLimitMetrics)
+
+	router := gin.New()
+	router.GET("/", rateLimiter.LimitGroup("redirect"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 5; i++ {
+		sendFrom(router, "10.0.0.1")
+	}
+
+	assert.Equal(t, float64(3), throttleCount(t, reg, "redirect"))
+}
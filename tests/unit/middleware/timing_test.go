@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+)
+
+// countTimedResponses sends requests to target through a router using sampler and
+// returns how many responses carried the processing time header.
+func countTimedResponses(sampler middleware.TimingSampler, target string, requests int) int {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(middleware.ProcessingTimeWith(sampler))
+	router.GET("/", func(c *gin.Context) {
+		c.Header("X-Handler", "ok")
+		c.Status(http.StatusNoContent)
+	})
+
+	timed := 0
+
+	for i := 0; i < requests; i++ {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if w.Header().Get(middleware.ProcessingTimeHeader) != "" {
+			timed++
+		}
+	}
+
+	return timed
+}
+
+func TestProcessingTime_SamplingModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		rate     int
+		target   string
+		expected int
+	}{
+		{"Always", middleware.TimingAlways, 0, "/", 100},
+		{"Never", middleware.TimingNever, 0, "/", 0},
+		{"Never ignores debug", middleware.TimingNever, 0, "/?debug", 0},
+		{"One in ten", middleware.TimingSampled, 10, "/", 10},
+		{"One in seven rounds up the first request", middleware.TimingSampled, 7, "/", 15},
+		{"Sampled honours debug", middleware.TimingSampled, 10, "/?debug=1", 100},
+		{"Debug without parameter", middleware.TimingDebug, 0, "/", 0},
+		{"Debug with parameter", middleware.TimingDebug, 0, "/?debug", 100},
+		{"Unknown mode falls back to always", "sometimes", 0, "/", 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := middleware.TimingSamplerFor(tt.mode, tt.rate)
+
+			assert.Equal(t, tt.expected, countTimedResponses(sampler, tt.target, 100))
+		})
+	}
+}
+
+func TestProcessingTime_EveryNIsDeterministic(t *testing.T) {
+	sampler := middleware.SampleEveryN(4)
+
+	var picks []bool
+	for i := 0; i < 8; i++ {
+		picks = append(picks, sampler(nil))
+	}
+
+	assert.Equal(t, []bool{true, false, false, false, true, false, false, false}, picks)
+}
@@ -0,0 +1,165 @@
+package postgres_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+// insertColumns is the number of values BatchSave binds per URL.
+const insertColumns = 11
+
+var batchInsertQuery = regexp.QuoteMeta(`INSERT INTO urls (`)
+
+// recordedArg matches any value and keeps it, so a test can inspect what was bound.
+type recordedArg struct {
+	values *[]driver.Value
+}
+
+func (a recordedArg) Match(v driver.Value) bool {
+	*a.values = append(*a.values, v)
+	return true
+}
+
+func makeURLs(t *testing.T, n int) []*entity.URL {
+	t.Helper()
+
+	urls := make([]*entity.URL, n)
+
+	for i := range urls {
+		shortKey, err := valueobject.NewShortKey(fmt.Sprintf("bulk%d", i))
+		require.NoError(t, err)
+
+		longURL, err := valueobject.NewLongURL(fmt.Sprintf("https://example.com/page/%d", i))
+		require.NoError(t, err)
+
+		urls[i] = entity.NewURL(shortKey, longURL)
+		urls[i].ID = int64(i + 1)
+	}
+
+	return urls
+}
+
+func returnedKeys(urls []*entity.URL) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"short_key"})
+	for _, url := range urls {
+		rows.AddRow(url.ShortKey.Value())
+	}
+
+	return rows
+}
+
+func TestBatchSave_InsertsAllURLsInOneStatement(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	urls := makeURLs(t, 500)
+
+	var bound []driver.Value
+
+	args := make([]driver.Value, len(urls)*insertColumns)
+	for i := range args {
+		args[i] = recordedArg{values: &bound}
+	}
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(batchInsertQuery).
+		WithArgs(args...).
+		WillReturnRows(returnedKeys(urls))
+	sqlMock.ExpectCommit()
+
+	require.NoError(t, postgres.NewURLRepository(db).BatchSave(context.Background(), urls))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+
+	// Every URL's row was bound: id, short key and destination in column order
+	require.Len(t, bound, len(urls)*insertColumns)
+
+	for i, url := range urls {
+		row := bound[i*insertColumns : (i+1)*insertColumns]
+		assert.Equal(t, url.ID, row[0])
+		assert.Equal(t, url.ShortKey.Value(), row[1])
+		assert.Equal(t, url.LongURL.Value(), row[2])
+	}
+}
+
+func TestBatchSave_ChunksLargeBatches(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	urls := makeURLs(t, 1500)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(batchInsertQuery).WillReturnRows(returnedKeys(urls[:1000]))
+	sqlMock.ExpectQuery(batchInsertQuery).WillReturnRows(returnedKeys(urls[1000:]))
+	sqlMock.ExpectCommit()
+
+	require.NoError(t, postgres.NewURLRepository(db).BatchSave(context.Background(), urls))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBatchSave_CollidingKeyRollsBack(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	urls := makeURLs(t, 500)
+
+	// ON CONFLICT skipped bulk42, so it is missing from the returned keys
+	stored := append(append([]*entity.URL{}, urls[:42]...), urls[43:]...)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`ON CONFLICT (short_key) DO NOTHING RETURNING short_key`)).
+		WillReturnRows(returnedKeys(stored))
+	sqlMock.ExpectRollback()
+
+	err = postgres.NewURLRepository(db).BatchSave(context.Background(), urls)
+
+	var dupErr *repository.DuplicateKeyError
+	require.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, "bulk42", dupErr.ShortKey)
+	assert.ErrorIs(t, err, repository.ErrDuplicateKey)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBatchSave_RepeatedKeyInBatchFailsBeforeInsert(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	urls := makeURLs(t, 3)
+	urls[2].ShortKey = urls[0].ShortKey
+
+	err = postgres.NewURLRepository(db).BatchSave(context.Background(), urls)
+
+	var dupErr *repository.DuplicateKeyError
+	require.ErrorAs(t, err, &dupErr)
+	assert.Equal(t, "bulk0", dupErr.ShortKey)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestBatchSave_StatementFailureRollsBack(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectQuery(batchInsertQuery).WillReturnError(assert.AnError)
+	sqlMock.ExpectRollback()
+
+	err = postgres.NewURLRepository(db).BatchSave(context.Background(), makeURLs(t, 10))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,72 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func TestRecordClicks_InsertsBatchInOneStatement(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	clickedAt := time.Date(2024, 3, 1, 6, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO url_clicks (short_key, clicked_at, referrer, user_agent, client_ip) VALUES `+
+		`($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, '')), ($6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''))`)).
+		WithArgs(
+			"abc123", clickedAt.UTC(), "https://news.example/", "Mozilla/5.0", "203.0.113.7",
+			"abc123", clickedAt.UTC(), "", "", "",
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = postgres.NewClickRepository(db).RecordClicks(context.Background(), []repository.Click{
+		{ShortKey: "abc123", ClickedAt: clickedAt, Referrer: "https://news.example/", UserAgent: "Mozilla/5.0", ClientIP: "203.0.113.7"},
+		{ShortKey: "abc123", ClickedAt: clickedAt},
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestRecordClicks_EmptyBatchSkipsDatabase(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.NoError(t, postgres.NewClickRepository(db).RecordClicks(context.Background(), nil))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestGetClicksByDay_GroupsByUTCDay(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	from := time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(`SELECT date_trunc\('day', clicked_at\) AS day, COUNT\(\*\).*GROUP BY day\s+ORDER BY day`).
+		WithArgs("abc123", from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"day", "count"}).
+			AddRow(from, 4).
+			AddRow(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 9))
+
+	days, err := postgres.NewClickRepository(db).GetClicksByDay(context.Background(), "abc123", from, to)
+
+	require.NoError(t, err)
+	assert.Equal(t, []repository.DailyClicks{
+		{Day: from, Clicks: 4},
+		{Day: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Clicks: 9},
+	}, days)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,112 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func makeShortKeys(t *testing.T, n int) []*valueobject.ShortKey {
+	t.Helper()
+
+	keys := make([]*valueobject.ShortKey, n)
+
+	for i := range keys {
+		key, err := valueobject.NewShortKey(fmt.Sprintf("key%d", i))
+		require.NoError(t, err)
+
+		keys[i] = key
+	}
+
+	return keys
+}
+
+func TestDeleteExpiredBatch_ChunksStatements(t *testing.T) {
+	testCases := []struct {
+		name           string
+		keys           int
+		chunkSize      int
+		expectedChunks []int
+	}{
+		{"Exact multiple", 1000, 500, []int{500, 500}},
+		{"Remainder chunk", 1201, 500, []int{500, 500, 201}},
+		{"Smaller than chunk", 42, 500, []int{42}},
+		{"Chunk of one", 3, 1, []int{1, 1, 1}},
+	}
+
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM urls WHERE short_key = ANY($1)`)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, sqlMock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			repo := postgres.NewURLRepository(db)
+			repo.SetDeleteChunkSize(tc.chunkSize)
+
+			// ceil(N/chunk) DELETE statements inside a single transaction
+			sqlMock.ExpectBegin()
+
+			for _, size := range tc.expectedChunks {
+				sqlMock.ExpectExec(deleteQuery).
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, int64(size)))
+			}
+
+			sqlMock.ExpectCommit()
+
+			err = repo.DeleteExpiredBatch(context.Background(), makeShortKeys(t, tc.keys))
+			assert.NoError(t, err)
+			assert.NoError(t, sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDeleteExpiredBatch_RollsBackOnChunkFailure(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewURLRepository(db)
+	repo.SetDeleteChunkSize(2)
+
+	deleteQuery := regexp.QuoteMeta(`DELETE FROM urls WHERE short_key = ANY($1)`)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(deleteQuery).WillReturnResult(sqlmock.NewResult(0, 2))
+	sqlMock.ExpectExec(deleteQuery).WillReturnError(assert.AnError)
+	sqlMock.ExpectRollback()
+
+	err = repo.DeleteExpiredBatch(context.Background(), makeShortKeys(t, 5))
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSetDeleteChunkSize_NonPositiveUsesDefault(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := postgres.NewURLRepository(db)
+	repo.SetDeleteChunkSize(0)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM urls`)).
+		WillReturnResult(sqlmock.NewResult(0, postgres.DefaultDeleteChunkSize))
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM urls`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err = repo.DeleteExpiredBatch(context.Background(), makeShortKeys(t, postgres.DefaultDeleteChunkSize+1))
+	assert.NoError(t, err)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,57 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func TestFindTopByVisits_OrdersByVisitCountWithLimit(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(`FROM urls\s+ORDER BY visit_count DESC, id DESC\s+LIMIT \$1`).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows(urlColumns).
+			AddRow(7, "hot", "https://example.com/hot", createdAt, nil, 900, createdAt, true, nil, "").
+			AddRow(9, "tie", "https://example.com/tie", createdAt, nil, 120, nil, true, nil, "").
+			AddRow(4, "warm", "https://example.com/warm", createdAt, nil, 120, nil, false, nil, ""))
+
+	urls, err := postgres.NewURLRepository(db).FindTopByVisits(context.Background(), 3, nil)
+
+	require.NoError(t, err)
+	require.Len(t, urls, 3)
+	assert.Equal(t, "hot", urls[0].ShortKey.Value())
+	assert.Equal(t, int64(900), urls[0].VisitCount)
+	assert.Equal(t, "warm", urls[2].ShortKey.Value())
+	assert.True(t, urls[2].Disabled)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestFindTopByVisits_FiltersByCreatedAt(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`WHERE created_at >= $2`)).
+		WithArgs(10, since).
+		WillReturnRows(sqlmock.NewRows(urlColumns))
+
+	urls, err := postgres.NewURLRepository(db).FindTopByVisits(context.Background(), 10, &since)
+
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
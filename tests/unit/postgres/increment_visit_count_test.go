@@ -0,0 +1,87 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+var incrementQuery = regexp.QuoteMeta("UPDATE urls") + `\s+SET visit_count = visit_count \+ 1`
+
+func TestIncrementVisitCount_RetriesTransientErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		code pq.ErrorCode
+	}{
+		{"Serialization failure", "40001"},
+		{"Deadlock detected", "40P01"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			defer db.Close()
+
+			mock.ExpectExec(incrementQuery).WithArgs("abc123").WillReturnError(&pq.Error{Code: tc.code})
+			mock.ExpectExec(incrementQuery).WithArgs("abc123").WillReturnResult(sqlmock.NewResult(0, 1))
+
+			repo := postgres.NewURLRepository(db)
+			repo.SetIncrementRetry(3, time.Millisecond)
+
+			shortKey, _ := valueobject.NewShortKey("abc123")
+			require.NoError(t, repo.IncrementVisitCount(context.Background(), shortKey))
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIncrementVisitCount_GivesUpAfterMaxAttempts(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	for i := 0; i < 2; i++ {
+		mock.ExpectExec(incrementQuery).WithArgs("abc123").WillReturnError(&pq.Error{Code: "40P01"})
+	}
+
+	repo := postgres.NewURLRepository(db)
+	repo.SetIncrementRetry(2, time.Millisecond)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	err = repo.IncrementVisitCount(context.Background(), shortKey)
+
+	var pqErr *pq.Error
+	require.True(t, errors.As(err, &pqErr))
+	assert.Equal(t, pq.ErrorCode("40P01"), pqErr.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIncrementVisitCount_DoesNotRetryOtherErrors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	// Unique violation is a real failure, not contention
+	mock.ExpectExec(incrementQuery).WithArgs("abc123").WillReturnError(&pq.Error{Code: "23505"})
+
+	repo := postgres.NewURLRepository(db)
+	repo.SetIncrementRetry(3, time.Millisecond)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	assert.Error(t, repo.IncrementVisitCount(context.Background(), shortKey))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func TestList_OrdersByWhitelistedColumn(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM urls`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`ORDER BY visit_count DESC, id DESC`)).
+		WithArgs(2, 10).
+		WillReturnRows(sqlmock.NewRows(urlColumns).
+			AddRow(3, "ccc", "https://example.com/c", createdAt, nil, 40, nil, true, nil, "").
+			AddRow(1, "aaa", "https://example.com/a", createdAt, nil, 9, nil, true, nil, ""))
+
+	urls, total, err := postgres.NewURLRepository(db).List(context.Background(), 2, 10, repository.ListOrderVisitCount)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), total)
+	require.Len(t, urls, 2)
+	assert.Equal(t, "ccc", urls[0].ShortKey.Value())
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestList_RejectsUnknownOrderWithoutQuerying(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, _, err = postgres.NewURLRepository(db).List(context.Background(), 10, 0, "long_url; DROP TABLE urls")
+
+	assert.ErrorIs(t, err, repository.ErrInvalidListOrder)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
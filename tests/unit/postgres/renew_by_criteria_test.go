@@ -0,0 +1,68 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func TestRenewByCriteria_CombinesAllCriteria(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(
+		`UPDATE urls SET expires_at = $1 WHERE expires_at IS NOT NULL AND expires_at > $2 `+
+			`AND host = $3 AND owner = $4 AND short_key LIKE $5 AND created_at >= $6 AND created_at < $7 RETURNING short_key`,
+	)).
+		WithArgs(expiresAt, sqlmock.AnyArg(), "shop.example", "alice", `spring\_%`, after, before).
+		WillReturnRows(sqlmock.NewRows([]string{"short_key"}).AddRow("spring_a").AddRow("spring_b"))
+
+	criteria := repository.RenewCriteria{
+		Host:          "shop.example",
+		Owner:         "alice",
+		KeyPrefix:     "spring_",
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+	}
+
+	shortKeys, err := postgres.NewURLRepository(db).RenewByCriteria(context.Background(), criteria, expiresAt)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"spring_a", "spring_b"}, shortKeys)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestRenewByCriteria_SingleCriterion(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta(`WHERE expires_at IS NOT NULL AND expires_at > $2 AND short_key LIKE $3 RETURNING short_key`)).
+		WithArgs(expiresAt, sqlmock.AnyArg(), "promo%").
+		WillReturnRows(sqlmock.NewRows([]string{"short_key"}))
+
+	shortKeys, err := postgres.NewURLRepository(db).RenewByCriteria(
+		context.Background(),
+		repository.RenewCriteria{KeyPrefix: "promo"},
+		expiresAt,
+	)
+
+	require.NoError(t, err)
+	assert.Empty(t, shortKeys)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,71 @@
+package postgres_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+	"github.com/Shofyan/url-shortener/internal/infrastructure/database/postgres"
+)
+
+func newReclaimedURL(t *testing.T) *entity.URL {
+	t.Helper()
+
+	shortKey, _ := valueobject.NewShortKey("promo")
+	longURL, err := valueobject.NewLongURL("https://example.com/new-campaign")
+	require.NoError(t, err)
+
+	url := entity.NewURL(shortKey, longURL)
+	url.ID = 42
+	url.Owner = "bob"
+
+	return url
+}
+
+func TestReplace_SwapsEveryColumnInOneTransaction(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	url := newReclaimedURL(t)
+	expiresAt := time.Now().Add(time.Hour)
+	url.ExpiresAt = &expiresAt
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM urls WHERE short_key = $1`)).
+		WithArgs("promo").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	// The new row carries its own ID, creation time and owner, with no visits or takedown
+	sqlMock.ExpectExec(regexp.QuoteMeta(`INSERT INTO urls`)).
+		WithArgs(int64(42), "promo", "https://example.com/new-campaign", url.CreatedAt, url.ExpiresAt,
+			int64(0), nil, true, nil, "example.com", "bob").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	require.NoError(t, postgres.NewURLRepository(db).Replace(context.Background(), url))
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestReplace_MissingKeyRollsBack(t *testing.T) {
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM urls WHERE short_key = $1`)).
+		WithArgs("promo").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	err = postgres.NewURLRepository(db).Replace(context.Background(), newReclaimedURL(t))
+
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,84 @@
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/infrastructure/config"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/handler"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/middleware"
+	"github.com/Shofyan/url-shortener/internal/interfaces/http/router"
+)
+
+const testAdminToken = "test-admin-token"
+
+// newTestRouter builds the production router. Requests rejected by RequireAdmin never
+// reach the handlers, so they are built without use cases.
+func newTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	// SetupRouter loads templates relative to the repository root
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir("../../.."))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{}
+	cfg.App.GinMode = gin.TestMode
+	cfg.App.AdminToken = testAdminToken
+
+	return router.SetupRouter(
+		cfg,
+		handler.NewURLHandler(nil, nil),
+		handler.NewAnalyticsHandler(nil),
+		handler.NewWebHandler(),
+		middleware.NewRateLimiter(100, 100),
+		nil,
+	)
+}
+
+func TestAdminRoutes_RequireAdminToken(t *testing.T) {
+	r := newTestRouter(t)
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/admin/cleanup/stats"},
+		{http.MethodPost, "/api/admin/cleanup/manual"},
+		{http.MethodGet, "/api/admin/cleanup/config"},
+		{http.MethodPut, "/api/admin/cleanup/config"},
+		{http.MethodGet, "/api/admin/info"},
+		{http.MethodGet, "/api/admin/selftest"},
+		{http.MethodGet, "/api/admin/urls"},
+		{http.MethodGet, "/api/admin/analytics/top"},
+		{http.MethodPut, "/api/admin/urls/abc123/enabled"},
+		{http.MethodPost, "/api/admin/urls/abc123/disable"},
+		{http.MethodPost, "/api/admin/cache/flush"},
+		{http.MethodPost, "/api/admin/renew"},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			for _, authorization := range []string{"", "Bearer wrong-token"} {
+				req := httptest.NewRequest(route.method, route.path, nil)
+				if authorization != "" {
+					req.Header.Set("Authorization", authorization)
+				}
+
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusUnauthorized, w.Code, "authorization %q", authorization)
+			}
+		})
+	}
+}
@@ -25,6 +25,11 @@ func (m *MockURLRepository) Save(ctx context.Context, url *entity.URL) error {
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) BatchSave(ctx context.Context, urls []*entity.URL) error {
+	args := m.Called(ctx, urls)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error) {
 	args := m.Called(ctx, shortKey)
 	if args.Get(0) == nil {
@@ -43,11 +48,25 @@ func (m *MockURLRepository) FindByLongURL(ctx context.Context, longURL *valueobj
 	return args.Get(0).(*entity.URL), args.Error(1)
 }
 
+func (m *MockURLRepository) FindByLongURLAndOwner(ctx context.Context, longURL *valueobject.LongURL, owner string) (*entity.URL, error) {
+	args := m.Called(ctx, longURL, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
 func (m *MockURLRepository) Update(ctx context.Context, url *entity.URL) error {
 	args := m.Called(ctx, url)
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) Replace(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) Delete(ctx context.Context, shortKey *valueobject.ShortKey) error {
 	args := m.Called(ctx, shortKey)
 	return args.Error(0)
@@ -82,6 +101,57 @@ func (m *MockURLRepository) GetExpiredCount(ctx context.Context, before time.Tim
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockURLRepository) Upsert(ctx context.Context, url *entity.URL) (repository.UpsertResult, error) {
+	args := m.Called(ctx, url)
+	return args.Get(0).(repository.UpsertResult), args.Error(1)
+}
+
+func (m *MockURLRepository) SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error {
+	args := m.Called(ctx, shortKey, enabled)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error {
+	args := m.Called(ctx, shortKey, reason)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) RenewByCriteria(ctx context.Context, criteria repository.RenewCriteria, expiresAt time.Time) ([]string, error) {
+	args := m.Called(ctx, criteria, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByHost(ctx context.Context, host string, cursor *repository.KeysetCursor, limit int) ([]*entity.URL, error) {
+	args := m.Called(ctx, host, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error) {
+	args := m.Called(ctx, limit, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error) {
+	args := m.Called(ctx, limit, offset, orderBy)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Get(1).(int64), args.Error(2)
+}
+
 // MockCacheRepository for cleanup service testing.
 type MockCacheRepository struct {
 	mock.Mock
@@ -126,6 +196,11 @@ func (m *MockCacheRepository) SetTombstone(ctx context.Context, key string, reas
 	return args.Error(0)
 }
 
+func (m *MockCacheRepository) FlushNamespace(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // TestBackgroundURLCleanupService_CleanupExpiredBatch tests the cleanup batch functionality.
 func TestBackgroundURLCleanupService_CleanupExpiredBatch(t *testing.T) {
 	tests := []struct {
@@ -340,3 +415,76 @@ func TestBackgroundURLCleanupService_DisabledConfig(t *testing.T) {
 	// No repository methods should be called
 	urlRepo.AssertNotCalled(t, "FindExpiredURLs", mock.Anything, mock.Anything, mock.Anything)
 }
+
+// TestBackgroundURLCleanupService_CheckBacklog tests backlog thresholds for readiness reporting.
+func TestBackgroundURLCleanupService_CheckBacklog(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int64
+		expectStatus string
+		expectWarn   bool
+	}{
+		{"Below warning threshold", 99, service.BacklogHealthy, false},
+		{"At warning threshold", 100, service.BacklogHealthy, false},
+		{"Above warning threshold", 101, service.BacklogDegraded, true},
+		{"At hard limit", 500, service.BacklogDegraded, true},
+		{"Above hard limit", 501, service.BacklogUnhealthy, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urlRepo := &MockURLRepository{}
+			cacheRepo := &MockCacheRepository{}
+
+			config := service.DefaultCleanupConfig()
+			config.BacklogWarnThreshold = 100
+			config.BacklogFailThreshold = 500
+
+			cleanupService := service.NewBackgroundURLCleanupService(urlRepo, cacheRepo, config)
+
+			urlRepo.On("GetExpiredCount", mock.Anything, mock.AnythingOfType("time.Time")).Return(tt.count, nil)
+
+			status, err := cleanupService.CheckBacklog(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectStatus, status.Status)
+			assert.Equal(t, tt.count, status.ExpiredCount)
+			assert.Equal(t, tt.expectWarn, status.Warning != "")
+			urlRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestBackgroundURLCleanupService_CheckBacklog_NoHardLimit tests that a zero fail threshold never fails readiness.
+func TestBackgroundURLCleanupService_CheckBacklog_NoHardLimit(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	config := service.DefaultCleanupConfig()
+	config.BacklogWarnThreshold = 100
+	config.BacklogFailThreshold = 0
+
+	cleanupService := service.NewBackgroundURLCleanupService(urlRepo, cacheRepo, config)
+
+	urlRepo.On("GetExpiredCount", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(1000000), nil)
+
+	status, err := cleanupService.CheckBacklog(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, service.BacklogDegraded, status.Status)
+}
+
+// TestBackgroundURLCleanupService_CheckBacklog_Error tests that count failures are surfaced.
+func TestBackgroundURLCleanupService_CheckBacklog_Error(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+
+	cleanupService := service.NewBackgroundURLCleanupService(urlRepo, cacheRepo, service.DefaultCleanupConfig())
+
+	urlRepo.On("GetExpiredCount", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(0), assert.AnError)
+
+	status, err := cleanupService.CheckBacklog(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, status)
+}
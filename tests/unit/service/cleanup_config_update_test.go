@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+func TestBackgroundURLCleanupService_UpdateConfigRestartsTicker(t *testing.T) {
+	urlRepo := &MockURLRepository{}
+	cacheRepo := &MockCacheRepository{}
+	urlRepo.On("FindExpiredURLs", mock.Anything, mock.Anything, 250).Return([]*entity.URL{}, nil)
+
+	config := service.DefaultCleanupConfig()
+	config.CleanupInterval = time.Hour
+
+	cleanupService := service.NewBackgroundURLCleanupService(urlRepo, cacheRepo, config)
+	require.NoError(t, cleanupService.StartCleanup(context.Background()))
+
+	defer func() { _ = cleanupService.StopCleanup() }()
+
+	interval := service.MinCleanupInterval
+	batchSize := 250
+
+	updated, err := cleanupService.UpdateConfig(service.CleanupConfigUpdate{
+		CleanupInterval: &interval,
+		BatchSize:       &batchSize,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, interval, updated.CleanupInterval)
+	assert.Equal(t, batchSize, updated.BatchSize)
+
+	// With the hour-long ticker no run would happen; the new interval triggers one quickly
+	assert.Eventually(t, func() bool {
+		return cleanupService.GetCleanupStats().SuccessfulRuns > 0
+	}, 3*time.Second, 20*time.Millisecond)
+
+	urlRepo.AssertCalled(t, "FindExpiredURLs", mock.Anything, mock.Anything, 250)
+}
+
+func TestBackgroundURLCleanupService_UpdateConfigAppliesBufferTime(t *testing.T) {
+	cleanupService := service.NewBackgroundURLCleanupService(&MockURLRepository{}, &MockCacheRepository{}, nil)
+
+	bufferTime := 30 * time.Minute
+
+	_, err := cleanupService.UpdateConfig(service.CleanupConfigUpdate{BufferTime: &bufferTime})
+	require.NoError(t, err)
+
+	current := cleanupService.GetConfig()
+	assert.Equal(t, bufferTime, current.BufferTime)
+	// Untouched fields keep their values
+	assert.Equal(t, service.DefaultCleanupConfig().BatchSize, current.BatchSize)
+}
+
+func TestBackgroundURLCleanupService_UpdateConfigRejectsInvalidValues(t *testing.T) {
+	zero := time.Duration(0)
+	negative := -time.Minute
+	tooSmall := 0
+	tooLarge := service.MaxCleanupBatch + 1
+
+	tests := []struct {
+		name   string
+		update service.CleanupConfigUpdate
+	}{
+		{"Zero interval", service.CleanupConfigUpdate{CleanupInterval: &zero}},
+		{"Zero batch size", service.CleanupConfigUpdate{BatchSize: &tooSmall}},
+		{"Batch size over limit", service.CleanupConfigUpdate{BatchSize: &tooLarge}},
+		{"Negative buffer time", service.CleanupConfigUpdate{BufferTime: &negative}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleanupService := service.NewBackgroundURLCleanupService(&MockURLRepository{}, &MockCacheRepository{}, nil)
+			before := cleanupService.GetConfig()
+
+			_, err := cleanupService.UpdateConfig(tt.update)
+			assert.ErrorIs(t, err, service.ErrInvalidCleanupConfig)
+
+			// A rejected update leaves the running configuration untouched
+			assert.Equal(t, before, cleanupService.GetConfig())
+		})
+	}
+}
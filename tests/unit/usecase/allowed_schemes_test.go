@@ -0,0 +1,61 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestShortenURL_RejectsDisallowedSchemes(t *testing.T) {
+	for _, longURL := range []string{
+		"javascript:alert(document.cookie)",
+		"JavaScript:alert(1)",
+		"data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==",
+		"ftp://files.example.com/archive.zip",
+		"mailto:someone@example.com",
+	} {
+		t.Run(longURL, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+			assert.ErrorIs(t, err, valueobject.ErrDisallowedScheme)
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShortenURL_AcceptsHTTPAndHTTPS(t *testing.T) {
+	for longURL, expected := range map[string]string{
+		"http://example.com/page":  "http://example.com/page",
+		"https://example.com/page": "https://example.com/page",
+		"HTTPS://example.com/page": "HTTPS://example.com/page",
+		"example.com/page":         "https://example.com/page",
+		"example.com:8080/page":    "https://example.com:8080/page",
+		"intranet:8080/page":       "https://intranet:8080/page",
+	} {
+		t.Run(longURL, func(t *testing.T) {
+			uc, _, _ := newShortenUseCaseWithConfig(nil)
+
+			resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+			require.NoError(t, err)
+			assert.Equal(t, expected, resp.LongURL)
+		})
+	}
+}
+
+func TestLongURL_ValidateSchemeAllowlist(t *testing.T) {
+	longURL, err := valueobject.NewLongURL("ftp://files.example.com/archive.zip")
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, longURL.ValidateScheme(valueobject.DefaultAllowedSchemes), valueobject.ErrDisallowedScheme)
+	assert.NoError(t, longURL.ValidateScheme([]string{"https", " FTP "}))
+	assert.ErrorIs(t, longURL.ValidateScheme(nil), valueobject.ErrDisallowedScheme)
+}
@@ -0,0 +1,90 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+// visitorRecord is one AddVisitor call seen by recordingEstimator.
+type visitorRecord struct {
+	shortKey string
+	visitor  string
+}
+
+// recordingEstimator reports every visitor it is given on a channel.
+type recordingEstimator struct {
+	added chan visitorRecord
+}
+
+func (e *recordingEstimator) AddVisitor(_ context.Context, shortKey, visitor string, _ time.Duration) error {
+	e.added <- visitorRecord{shortKey: shortKey, visitor: visitor}
+	return nil
+}
+
+func (e *recordingEstimator) EstimateVisitors(context.Context, string) (int64, error) {
+	return 0, nil
+}
+
+func recordVisitor(t *testing.T, hashIdentifiers bool, visitor string) visitorRecord {
+	t.Helper()
+
+	config := usecase.DefaultShortenURLConfig()
+	config.HashAnalyticsIdentifiers = hashIdentifiers
+
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+	uc := usecase.NewShortenURLUseCaseWithConfig(new(MockURLRepository), new(MockCacheRepository), genService, "http://localhost:8080", time.Hour, config)
+
+	estimator := &recordingEstimator{added: make(chan visitorRecord, 1)}
+	uc.SetVisitorEstimator(estimator, time.Hour)
+
+	uc.RecordVisitor(context.Background(), "docs", visitor)
+
+	select {
+	case record := <-estimator.added:
+		return record
+	case <-time.After(time.Second):
+		t.Fatal("visitor was not recorded")
+		return visitorRecord{}
+	}
+}
+
+func TestRecordVisitor_RawIdentifiersByDefault(t *testing.T) {
+	record := recordVisitor(t, false, "203.0.113.57")
+
+	assert.Equal(t, visitorRecord{shortKey: "docs", visitor: "203.0.113.57"}, record)
+}
+
+func TestRecordVisitor_HashesIdentifiersWhenEnabled(t *testing.T) {
+	record := recordVisitor(t, true, "203.0.113.57")
+
+	// First 16 hex characters of sha256("docs")
+	assert.Equal(t, "46b42b4229cd7a39", record.shortKey)
+	assert.Equal(t, usecase.HashShortKey("docs"), record.shortKey)
+	assert.Equal(t, "203.0.113.0", record.visitor)
+}
+
+func TestAnonymizeIP(t *testing.T) {
+	tests := map[string]string{
+		"198.51.100.255":               "198.51.100.0",
+		"::ffff:198.51.100.7":          "198.51.100.0",
+		"2001:db8:abcd:12:34:56:78:9a": "2001:db8:abcd::",
+		"not-an-ip":                    usecase.HashShortKey("not-an-ip"),
+	}
+
+	for ip, want := range tests {
+		assert.Equal(t, want, usecase.AnonymizeIP(ip), ip)
+	}
+}
+
+func TestHashShortKey_StableAndDistinct(t *testing.T) {
+	require.Len(t, usecase.HashShortKey("docs"), 16)
+	assert.Equal(t, usecase.HashShortKey("docs"), usecase.HashShortKey("docs"))
+	assert.NotEqual(t, usecase.HashShortKey("docs"), usecase.HashShortKey("Docs"))
+}
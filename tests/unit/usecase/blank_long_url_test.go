@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestShortenURL_RejectsBlankLongURL(t *testing.T) {
+	for name, rawURL := range map[string]string{
+		"Empty":            "",
+		"Spaces only":      "   ",
+		"Mixed whitespace": " \t\n ",
+	} {
+		t.Run(name, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: rawURL})
+
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, valueobject.ErrEmptyURL)
+			// Rejected before any lookup
+			mockURLRepo.AssertNotCalled(t, "FindByLongURL", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShortenURL_TrimsSurroundingWhitespace(t *testing.T) {
+	uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "  https://example.com/path \n"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/path", resp.LongURL)
+
+	longURL, _ := valueobject.NewLongURL("https://example.com/path")
+	mockURLRepo.AssertCalled(t, "FindByLongURL", mock.Anything, longURL)
+}
+
+func TestNormalizeURL_TrimsWhitespace(t *testing.T) {
+	assert.Equal(t, "https://example.com", valueobject.NormalizeURL("  example.com  "))
+	assert.Equal(t, "", valueobject.NormalizeURL("   "))
+}
@@ -0,0 +1,167 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+var errRedisDown = errors.New("dial tcp 127.0.0.1:6379: connect: connection refused")
+
+const breakerCooldown = 50 * time.Millisecond
+
+// newBreakerUseCase returns a use case whose cache breaker opens after five errors in a
+// row, backed by a database that always finds "down1" and a cache that always fails.
+func newBreakerUseCase(t *testing.T) (*usecase.ShortenURLUseCase, *MockCacheRepository, *MockURLRepository) {
+	t.Helper()
+
+	shortKey, err := valueobject.NewShortKey("down1")
+	require.NoError(t, err)
+
+	longURL, err := valueobject.NewLongURL("https://example.com/db")
+	require.NoError(t, err)
+
+	urlRepo := new(MockURLRepository)
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+
+	cacheRepo := new(MockCacheRepository)
+	failCache(cacheRepo)
+
+	config := usecase.DefaultShortenURLConfig()
+	config.EarlyRefreshProbability = 0
+	config.CacheBreakerThreshold = 5
+	config.CacheBreakerCooldown = breakerCooldown
+
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+	uc := usecase.NewShortenURLUseCaseWithConfig(urlRepo, cacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	return uc, cacheRepo, urlRepo
+}
+
+// failCache makes every cache read and fill on cacheRepo fail.
+func failCache(cacheRepo *MockCacheRepository) {
+	cacheRepo.ExpectedCalls = nil
+	cacheRepo.Calls = nil
+
+	cacheRepo.On("GetCacheEntry", mock.Anything, mock.Anything).Return(nil, errRedisDown)
+	cacheRepo.On("Get", mock.Anything, mock.Anything).Return("", errRedisDown)
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errRedisDown)
+}
+
+func resolveDown1(t *testing.T, uc *usecase.ShortenURLUseCase) {
+	t.Helper()
+
+	longURL, _, err := uc.ResolveWithoutVisit(context.Background(), "down1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/db", longURL)
+}
+
+func TestCacheBreaker_OpensAfterConsecutiveFailures(t *testing.T) {
+	uc, cacheRepo, urlRepo := newBreakerUseCase(t)
+
+	assert.Equal(t, usecase.CacheBreakerClosed, uc.CacheBreakerStats().State)
+
+	// Entry read, legacy read and cache fill fail: three errors
+	resolveDown1(t, uc)
+	assert.Equal(t, 3, uc.CacheBreakerStats().ConsecutiveFailures)
+	assert.Equal(t, usecase.CacheBreakerClosed, uc.CacheBreakerStats().State)
+
+	// The two reads of the next lookup reach the threshold; its cache fill is skipped
+	resolveDown1(t, uc)
+
+	stats := uc.CacheBreakerStats()
+	assert.Equal(t, usecase.CacheBreakerOpen, stats.State)
+	assert.Equal(t, int64(1), stats.Trips)
+	require.NotNil(t, stats.RetryAt)
+	assert.WithinDuration(t, time.Now().Add(breakerCooldown), *stats.RetryAt, breakerCooldown)
+	cacheRepo.AssertNumberOfCalls(t, "SetCacheEntry", 1)
+
+	// While open, lookups go straight to the database
+	for i := 0; i < 3; i++ {
+		resolveDown1(t, uc)
+	}
+
+	cacheRepo.AssertNumberOfCalls(t, "GetCacheEntry", 2)
+	cacheRepo.AssertNumberOfCalls(t, "Get", 2)
+	urlRepo.AssertNumberOfCalls(t, "FindByShortKey", 5)
+	// One skipped fill, then a read and a fill per lookup
+	assert.Equal(t, int64(7), uc.CacheBreakerStats().Skipped)
+}
+
+func TestCacheBreaker_HalfOpensAfterCooldownAndClosesOnSuccess(t *testing.T) {
+	uc, cacheRepo, _ := newBreakerUseCase(t)
+
+	resolveDown1(t, uc)
+	resolveDown1(t, uc)
+	require.Equal(t, usecase.CacheBreakerOpen, uc.CacheBreakerStats().State)
+
+	time.Sleep(breakerCooldown + 10*time.Millisecond)
+
+	stats := uc.CacheBreakerStats()
+	assert.Equal(t, usecase.CacheBreakerHalfOpen, stats.State)
+	assert.Nil(t, stats.RetryAt)
+
+	// The cache has recovered: the probe read hits and closes the breaker
+	cacheRepo.ExpectedCalls = nil
+	cacheRepo.On("GetCacheEntry", mock.Anything, "down1").Return(&repository.CacheEntry{LongURL: "https://example.com/cached"}, nil)
+
+	longURL, status, err := uc.ResolveWithoutVisit(context.Background(), "down1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/cached", longURL)
+	assert.Equal(t, usecase.CacheHit, status)
+
+	stats = uc.CacheBreakerStats()
+	assert.Equal(t, usecase.CacheBreakerClosed, stats.State)
+	assert.Zero(t, stats.ConsecutiveFailures)
+}
+
+func TestCacheBreaker_FailedProbeReopens(t *testing.T) {
+	uc, cacheRepo, _ := newBreakerUseCase(t)
+
+	resolveDown1(t, uc)
+	resolveDown1(t, uc)
+
+	time.Sleep(breakerCooldown + 10*time.Millisecond)
+	require.Equal(t, usecase.CacheBreakerHalfOpen, uc.CacheBreakerStats().State)
+
+	failCache(cacheRepo)
+
+	// One probe goes through and fails; the rest of the lookup skips the cache again
+	resolveDown1(t, uc)
+
+	stats := uc.CacheBreakerStats()
+	assert.Equal(t, usecase.CacheBreakerOpen, stats.State)
+	assert.Equal(t, int64(2), stats.Trips)
+	cacheRepo.AssertNumberOfCalls(t, "GetCacheEntry", 1)
+	cacheRepo.AssertNumberOfCalls(t, "Get", 0)
+	cacheRepo.AssertNumberOfCalls(t, "SetCacheEntry", 0)
+}
+
+func TestCacheBreaker_MissesDoNotCount(t *testing.T) {
+	uc, cacheRepo, _ := newBreakerUseCase(t)
+
+	cacheRepo.ExpectedCalls = nil
+	cacheRepo.On("GetCacheEntry", mock.Anything, mock.Anything).Return(nil, repository.ErrCacheMiss)
+	cacheRepo.On("Get", mock.Anything, mock.Anything).Return("", repository.ErrCacheMiss)
+	cacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	for i := 0; i < 10; i++ {
+		resolveDown1(t, uc)
+	}
+
+	stats := uc.CacheBreakerStats()
+	assert.Equal(t, usecase.CacheBreakerClosed, stats.State)
+	assert.Zero(t, stats.ConsecutiveFailures)
+	assert.Zero(t, stats.Trips)
+}
@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestGetLongURL_CoalescesConcurrentCacheMisses(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("hotkey")
+	longURL, _ := valueobject.NewLongURL("https://example.com/hot")
+	url := entity.NewURL(shortKey, longURL)
+	url.SetExpiration(time.Hour)
+
+	// Cold cache: every request misses, and the DB lookup is slow enough for requests to pile up
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "hotkey").Return(nil, assert.AnError)
+	mockCacheRepo.On("Get", mock.Anything, "hotkey").Return("", assert.AnError)
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).
+		After(100*time.Millisecond).Return(url, nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "hotkey", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(nil).Maybe()
+
+	const concurrentRequests = 50
+
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+	)
+
+	results := make([]string, concurrentRequests)
+	errs := make([]error, concurrentRequests)
+
+	for i := 0; i < concurrentRequests; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			<-start
+
+			results[i], errs[i] = uc.GetLongURL(context.Background(), "hotkey")
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < concurrentRequests; i++ {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, "https://example.com/hot", results[i])
+	}
+
+	// Only one request should have reached the database
+	mockURLRepo.AssertNumberOfCalls(t, "FindByShortKey", 1)
+}
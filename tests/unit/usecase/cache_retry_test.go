@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+func TestShortenURL_RetriesFailedCacheWrite(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.CacheRetryDelay = 10 * time.Millisecond
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	// Redis is briefly unavailable: both the structured and the fallback write fail once
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockCacheRepo.On("Set", mock.Anything, "promo", "https://example.com/promo", mock.Anything).Return(assert.AnError).Once()
+
+	cached := make(chan *repository.CacheEntry, 1)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { cached <- args.Get(2).(*repository.CacheEntry) }).
+		Return(nil).Once()
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com/promo", CustomKey: "promo"})
+	require.NoError(t, err)
+	assert.Equal(t, "promo", resp.ShortKey)
+	assert.Equal(t, 1, uc.PendingCacheRetries())
+
+	select {
+	case entry := <-cached:
+		assert.Equal(t, "https://example.com/promo", entry.LongURL)
+	case <-time.After(time.Second):
+		require.Fail(t, "failed cache write was not retried")
+	}
+
+	assert.Eventually(t, func() bool { return uc.PendingCacheRetries() == 0 }, time.Second, 5*time.Millisecond)
+	assert.Zero(t, uc.DroppedCacheRetries())
+	mockCacheRepo.AssertNumberOfCalls(t, "SetCacheEntry", 2)
+}
+
+func TestShortenURL_CacheRetryQueueIsBounded(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.CacheRetryQueueSize = 1
+	config.CacheRetryDelay = time.Hour
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+	mockCacheRepo.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError)
+
+	for _, key := range []string{"first", "second"} {
+		_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com/" + key, CustomKey: key})
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, uc.PendingCacheRetries())
+	assert.Equal(t, int64(1), uc.DroppedCacheRetries())
+}
+
+func TestShortenURL_DeleteDropsQueuedCacheRetry(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.CacheRetryDelay = 20 * time.Millisecond
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockURLRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+
+	// The first cache write fails; any retry would succeed
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockCacheRepo.On("Set", mock.Anything, "promo", mock.Anything, mock.Anything).Return(assert.AnError).Once()
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("SetTombstone", mock.Anything, "promo", repository.TombstoneDeleted, mock.Anything).Return(nil)
+
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com/promo", CustomKey: "promo"})
+	require.NoError(t, err)
+	require.Equal(t, 1, uc.PendingCacheRetries())
+
+	require.NoError(t, uc.DeleteURL(context.Background(), "promo"))
+	assert.Zero(t, uc.PendingCacheRetries())
+
+	// Once the retry pass has run, the deleted tombstone is still the last write
+	time.Sleep(5 * config.CacheRetryDelay)
+
+	mockCacheRepo.AssertNumberOfCalls(t, "SetCacheEntry", 1)
+	mockCacheRepo.AssertCalled(t, "SetTombstone", mock.Anything, "promo", repository.TombstoneDeleted, mock.Anything)
+}
@@ -0,0 +1,68 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestGetLongURL_CacheTTLFloorAndCeiling(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresIn time.Duration // zero means the URL never expires
+		expected  time.Duration
+	}{
+		{"Near expiry is raised to the floor", 10 * time.Second, 2 * time.Minute},
+		{"Between floor and ceiling uses remaining life", 30 * time.Minute, 30 * time.Minute},
+		{"Long remaining life is capped", 48 * time.Hour, 6 * time.Hour},
+		{"No expiry is capped", 0, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockURLRepo := new(MockURLRepository)
+			mockCacheRepo := new(MockCacheRepository)
+			genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+			config := usecase.DefaultShortenURLConfig()
+			config.MinCacheTTL = 2 * time.Minute
+			config.MaxCacheTTL = 6 * time.Hour
+
+			// The default TTL is above the ceiling, so no-expiry URLs would otherwise stay cached a day
+			uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", 24*time.Hour, config)
+
+			shortKey, _ := valueobject.NewShortKey("abc123")
+			longURL, _ := valueobject.NewLongURL("https://example.com")
+
+			url := &entity.URL{ID: 12345, ShortKey: shortKey, LongURL: longURL, CreatedAt: time.Now()}
+			if tt.expiresIn > 0 {
+				expiresAt := time.Now().Add(tt.expiresIn)
+				url.ExpiresAt = &expiresAt
+			}
+
+			var cacheTTL time.Duration
+
+			mockCacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(nil, assert.AnError)
+			mockCacheRepo.On("Get", mock.Anything, "abc123").Return("", assert.AnError)
+			mockURLRepo.On("FindByShortKey", mock.Anything, shortKey).Return(url, nil)
+			mockURLRepo.On("IncrementVisitCount", mock.Anything, shortKey).Return(nil)
+			mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).
+				Run(func(args mock.Arguments) { cacheTTL = args.Get(3).(time.Duration) }).
+				Return(nil)
+
+			_, err := uc.GetLongURL(context.Background(), "abc123")
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, cacheTTL, float64(5*time.Second))
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// shortenAndCaptureCacheTTL shortens req and returns the TTL passed to the cache and the saved URL.
+func shortenAndCaptureCacheTTL(t *testing.T, req *dto.ShortenURLRequest) (time.Duration, *entity.URL) {
+	t.Helper()
+
+	uc, mockURLRepo, mockCacheRepo := newShortenUseCaseWithConfig(nil)
+
+	_, err := uc.Shorten(context.Background(), req)
+	require.NoError(t, err)
+
+	saved := callArgs(t, &mockURLRepo.Mock, "Save").Get(1).(*entity.URL)
+	cacheTTL := callArgs(t, &mockCacheRepo.Mock, "SetCacheEntry").Get(3).(time.Duration)
+
+	return cacheTTL, saved
+}
+
+// callArgs returns the arguments of the only call to method on m.
+func callArgs(t *testing.T, m *mock.Mock, method string) mock.Arguments {
+	t.Helper()
+
+	var calls []mock.Call
+	for _, call := range m.Calls {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	require.Len(t, calls, 1, method)
+
+	return calls[0].Arguments
+}
+
+func TestShorten_AppliesPerURLCacheTTL(t *testing.T) {
+	cacheTTL, saved := shortenAndCaptureCacheTTL(t, &dto.ShortenURLRequest{
+		LongURL:         "https://example.com/hot",
+		TTLSeconds:      3600,
+		CacheTTLSeconds: 60,
+	})
+
+	assert.Equal(t, time.Minute, cacheTTL)
+	assert.Equal(t, time.Minute, saved.CacheTTL, "override should be persisted on the entity")
+}
+
+func TestShorten_UsesRemainingLifetimeWithoutCacheTTL(t *testing.T) {
+	cacheTTL, saved := shortenAndCaptureCacheTTL(t, &dto.ShortenURLRequest{
+		LongURL:    "https://example.com/plain",
+		TTLSeconds: 1800,
+	})
+
+	// Shorter than the default cache TTL of an hour
+	assert.InDelta(t, 30*time.Minute, cacheTTL, float64(5*time.Second))
+	assert.Zero(t, saved.CacheTTL)
+}
+
+func TestShorten_ClampsCacheTTLToRemainingLifetime(t *testing.T) {
+	cacheTTL, _ := shortenAndCaptureCacheTTL(t, &dto.ShortenURLRequest{
+		LongURL:         "https://example.com/short-lived",
+		TTLSeconds:      600,
+		CacheTTLSeconds: 7200,
+	})
+
+	assert.LessOrEqual(t, cacheTTL, 10*time.Minute)
+	assert.InDelta(t, 10*time.Minute, cacheTTL, float64(5*time.Second))
+}
+
+func TestGetLongURL_CachesWithPerURLCacheTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		cacheTTL time.Duration
+		expected time.Duration
+	}{
+		{"Override", 30 * time.Second, 30 * time.Second},
+		{"Default", 0, 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockURLRepo := new(MockURLRepository)
+			mockCacheRepo := new(MockCacheRepository)
+			genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+			uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", 2*time.Hour)
+
+			shortKey, _ := valueobject.NewShortKey("abc123")
+			longURL, _ := valueobject.NewLongURL("https://example.com")
+
+			// No expiration, so the override or the service default decides the TTL
+			url := &entity.URL{
+				ID:        12345,
+				ShortKey:  shortKey,
+				LongURL:   longURL,
+				CreatedAt: time.Now(),
+				CacheTTL:  tt.cacheTTL,
+			}
+
+			mockCacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(nil, assert.AnError)
+			mockCacheRepo.On("Get", mock.Anything, "abc123").Return("", assert.AnError)
+			mockURLRepo.On("FindByShortKey", mock.Anything, shortKey).Return(url, nil)
+			mockURLRepo.On("IncrementVisitCount", mock.Anything, shortKey).Return(nil)
+			mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.AnythingOfType("*repository.CacheEntry"), tt.expected).Return(nil)
+
+			redirectURL, err := uc.GetLongURL(context.Background(), "abc123")
+
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com", redirectURL)
+			mockCacheRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// batchRecorder is a ClickRepository that keeps every batch it is asked to write.
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches [][]repository.Click
+	err     error
+}
+
+func (r *batchRecorder) RecordClicks(_ context.Context, clicks []repository.Click) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.batches = append(r.batches, clicks)
+
+	return r.err
+}
+
+func (r *batchRecorder) GetClicksByDay(context.Context, string, time.Time, time.Time) ([]repository.DailyClicks, error) {
+	return nil, nil
+}
+
+func (r *batchRecorder) GetTopReferrers(context.Context, string, time.Time, time.Time, int) ([]repository.ReferrerCount, error) {
+	return nil, nil
+}
+
+func (r *batchRecorder) batchSizes() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sizes := make([]int, 0, len(r.batches))
+	for _, batch := range r.batches {
+		sizes = append(sizes, len(batch))
+	}
+
+	return sizes
+}
+
+func click(i int) repository.Click {
+	return repository.Click{ShortKey: "k" + strconv.Itoa(i), ClickedAt: time.Now()}
+}
+
+func TestClickRecorder_WritesFullBatchesAndDrainsOnStop(t *testing.T) {
+	repo := &batchRecorder{}
+	recorder := usecase.NewClickRecorder(repo, usecase.ClickRecorderConfig{QueueSize: 100, BatchSize: 3, FlushInterval: time.Hour})
+
+	for i := 0; i < 7; i++ {
+		require.True(t, recorder.Enqueue(click(i)))
+	}
+
+	recorder.Start()
+
+	// Two full batches are written without waiting for the interval
+	assert.Eventually(t, func() bool {
+		return len(repo.batchSizes()) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// Stop writes the partial batch left over
+	recorder.Stop()
+
+	assert.Equal(t, []int{3, 3, 1}, repo.batchSizes())
+	assert.Equal(t, "k0", repo.batches[0][0].ShortKey)
+	assert.Equal(t, "k6", repo.batches[2][0].ShortKey)
+}
+
+func TestClickRecorder_FlushesPartialBatchAfterInterval(t *testing.T) {
+	repo := &batchRecorder{}
+	recorder := usecase.NewClickRecorder(repo, usecase.ClickRecorderConfig{QueueSize: 100, BatchSize: 50, FlushInterval: 20 * time.Millisecond})
+
+	recorder.Start()
+	defer recorder.Stop()
+
+	recorder.Enqueue(click(1))
+	recorder.Enqueue(click(2))
+
+	assert.Eventually(t, func() bool {
+		sizes := repo.batchSizes()
+		return len(sizes) == 1 && sizes[0] == 2
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestClickRecorder_DropsWhenQueueIsFull(t *testing.T) {
+	repo := &batchRecorder{}
+	recorder := usecase.NewClickRecorder(repo, usecase.ClickRecorderConfig{QueueSize: 2, BatchSize: 10, FlushInterval: time.Hour})
+
+	// Nothing is draining the queue, so the third click cannot be held
+	assert.True(t, recorder.Enqueue(click(1)))
+	assert.True(t, recorder.Enqueue(click(2)))
+
+	done := make(chan bool)
+	go func() { done <- recorder.Enqueue(click(3)) }()
+
+	select {
+	case queued := <-done:
+		assert.False(t, queued)
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue blocked on a full queue")
+	}
+
+	assert.Equal(t, int64(1), recorder.Dropped())
+
+	recorder.Start()
+	recorder.Stop()
+
+	assert.Equal(t, []int{2}, repo.batchSizes())
+}
+
+func TestClickRecorder_CountsFailedWrites(t *testing.T) {
+	repo := &batchRecorder{err: errors.New("connection reset")}
+	recorder := usecase.NewClickRecorder(repo, usecase.ClickRecorderConfig{QueueSize: 10, BatchSize: 10, FlushInterval: time.Hour})
+
+	recorder.Enqueue(click(1))
+	recorder.Enqueue(click(2))
+
+	recorder.Start()
+	recorder.Stop()
+
+	assert.Equal(t, int64(2), recorder.Failed())
+	assert.Zero(t, recorder.Dropped())
+}
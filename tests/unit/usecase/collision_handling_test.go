@@ -0,0 +1,248 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// setupTakenCustomKey returns a use case where "promo" is taken and the keys in free are available.
+func setupTakenCustomKey(config *usecase.ShortenURLConfig, free ...string) (*usecase.ShortenURLUseCase, *MockURLRepository) {
+	mockURLRepo := new(MockURLRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, new(MockCacheRepository), genService, "http://localhost:8080", time.Hour, config)
+
+	available := make(map[string]bool)
+	for _, key := range free {
+		available[key] = true
+	}
+
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.MatchedBy(func(key *valueobject.ShortKey) bool {
+		return available[key.Value()]
+	})).Return(false, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.MatchedBy(func(key *valueobject.ShortKey) bool {
+		return !available[key.Value()]
+	})).Return(true, nil)
+
+	return uc, mockURLRepo
+}
+
+func shortenPromo(uc *usecase.ShortenURLUseCase) error {
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+		LongURL:   "https://example.com/campaign",
+		CustomKey: "promo",
+	})
+
+	return err
+}
+
+func TestShorten_CustomKeyConflictSuggestsAlternatives(t *testing.T) {
+	uc, _ := setupTakenCustomKey(usecase.DefaultShortenURLConfig(), "promo3", "promo4")
+
+	err := shortenPromo(uc)
+
+	var conflict *usecase.CustomKeyConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.ErrorIs(t, err, usecase.ErrCustomKeyExists)
+	assert.Equal(t, []string{"promo3", "promo4"}, conflict.Suggestions)
+}
+
+func TestShorten_SuggestionProbesAreCapped(t *testing.T) {
+	tests := []struct {
+		name   string
+		probes int
+	}{
+		{"Disabled", 0},
+		{"Three probes", 3},
+		{"Default", usecase.DefaultShortenURLConfig().MaxSuggestionProbes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := usecase.DefaultShortenURLConfig()
+			config.MaxSuggestionProbes = tt.probes
+
+			// Every candidate is taken, so probing only stops at the cap
+			uc, mockURLRepo := setupTakenCustomKey(config)
+
+			err := shortenPromo(uc)
+
+			var conflict *usecase.CustomKeyConflictError
+			require.ErrorAs(t, err, &conflict)
+			assert.Empty(t, conflict.Suggestions)
+			// One check for the custom key itself plus one per probe
+			mockURLRepo.AssertNumberOfCalls(t, "ExistsByShortKey", 1+tt.probes)
+		})
+	}
+}
+
+func TestShorten_SuggestionsStopOnceEnoughFound(t *testing.T) {
+	config := usecase.DefaultShortenURLConfig()
+	config.MaxSuggestionProbes = 10
+
+	uc, mockURLRepo := setupTakenCustomKey(config, "promo2", "promo3", "promo4", "promo5")
+
+	err := shortenPromo(uc)
+
+	var conflict *usecase.CustomKeyConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Len(t, conflict.Suggestions, 3)
+	mockURLRepo.AssertNumberOfCalls(t, "ExistsByShortKey", 4)
+}
+
+func TestShorten_SuggestionProbesBackOff(t *testing.T) {
+	config := usecase.DefaultShortenURLConfig()
+	config.MaxSuggestionProbes = 3
+	config.CollisionBackoff = 15 * time.Millisecond
+
+	uc, _ := setupTakenCustomKey(config)
+
+	start := time.Now()
+	err := shortenPromo(uc)
+
+	assert.ErrorIs(t, err, usecase.ErrCustomKeyExists)
+	assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+}
+
+func TestShorten_KeyspaceExhaustedReturnsClearError(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, new(MockCacheRepository), genService, "http://localhost:8080", time.Hour)
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(nil, service.ErrKeyspaceExhausted)
+
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com"})
+
+	assert.True(t, errors.Is(err, usecase.ErrKeyspaceExhausted), "got %v", err)
+	mockShortKeyGen.AssertNumberOfCalls(t, "GenerateFromID", 1)
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShorten_GeneratedKeyCollisionDrawsAnotherKey(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	taken, _ := valueobject.NewShortKey("taken1")
+	fresh, _ := valueobject.NewShortKey("fresh1")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(1), nil).Once()
+	mockIDGen.On("Generate").Return(int64(2), nil).Once()
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(taken, nil)
+	mockShortKeyGen.On("GenerateFromID", int64(2)).Return(fresh, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, taken).Return(true, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, fresh).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.ShortKey.Value() == "fresh1" && url.ID == 2
+	})).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "fresh1", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "fresh1", resp.ShortKey)
+	mockShortKeyGen.AssertNumberOfCalls(t, "GenerateFromID", 2)
+	mockURLRepo.AssertExpectations(t)
+}
+
+func TestShorten_GeneratedKeyCollisionsExhaustAttempts(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+
+	config := usecase.DefaultShortenURLConfig()
+	config.MaxKeyAttempts = 4
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, new(MockCacheRepository), genService, "http://localhost:8080", time.Hour, config)
+
+	taken, _ := valueobject.NewShortKey("taken1")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(taken, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, taken).Return(true, nil)
+
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com"})
+
+	assert.ErrorIs(t, err, usecase.ErrKeyspaceExhausted)
+	mockShortKeyGen.AssertNumberOfCalls(t, "GenerateFromID", 4)
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShorten_GeneratedKeyCheckFailureFailsClosed(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, new(MockCacheRepository), genService, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(shortKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, shortKey).Return(false, errors.New("connection refused"))
+
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com"})
+
+	assert.ErrorIs(t, err, usecase.ErrInternalError)
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+// existenceCheckingShortKeyGenerator is a MockShortKeyGenerator that, like the random
+// generator, checks its keys are unused before returning them.
+type existenceCheckingShortKeyGenerator struct {
+	*MockShortKeyGenerator
+}
+
+func (existenceCheckingShortKeyGenerator) ChecksExistence() bool {
+	return true
+}
+
+func TestShorten_GeneratedKeyNotCheckedAgainWhenGeneratorChecks(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, existenceCheckingShortKeyGenerator{mockShortKeyGen})
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("rnd123")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(shortKey, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "rnd123", mock.Anything, mock.Anything).Return(nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "rnd123", resp.ShortKey)
+	mockURLRepo.AssertNotCalled(t, "ExistsByShortKey", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// setupCustomKeyReuse returns a use case whose "promo" key is already taken by a URL
+// that expired expiredAgo ago (or is still active when expiredAgo is negative), and
+// was taken down for disabledReason when it is not empty.
+func setupCustomKeyReuse(
+	allowReuse bool,
+	expiredAgo time.Duration,
+	disabledReason string,
+) (*usecase.ShortenURLUseCase, *MockURLRepository, *MockCacheRepository) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.AllowExpiredKeyReuse = allowReuse
+	config.ExpiredKeyRetention = time.Hour
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	shortKey, _ := valueobject.NewShortKey("promo")
+	oldURL, _ := valueobject.NewLongURL("https://example.com/old-campaign")
+	existing := entity.NewURL(shortKey, oldURL)
+	expiresAt := time.Now().Add(-expiredAgo)
+	existing.ExpiresAt = &expiresAt
+
+	if disabledReason != "" {
+		existing.Disabled = true
+		existing.DisabledReason = disabledReason
+	}
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(true, nil)
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(existing, nil)
+	mockIDGen.On("Generate").Return(int64(42), nil)
+
+	return uc, mockURLRepo, mockCacheRepo
+}
+
+func TestShorten_ReclaimsExpiredCustomKey(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := setupCustomKeyReuse(true, 2*time.Hour, "")
+
+	mockURLRepo.On("Replace", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+		LongURL:   "https://example.com/new-campaign",
+		CustomKey: "promo",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "promo", resp.ShortKey)
+	assert.Equal(t, "https://example.com/new-campaign", resp.LongURL)
+
+	// The existing row is replaced rather than inserted again
+	mockURLRepo.AssertCalled(t, "Replace", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.LongURL.Value() == "https://example.com/new-campaign" && url.VisitCount == 0
+	}))
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShorten_RejectsCustomKeyReuse(t *testing.T) {
+	tests := []struct {
+		name           string
+		allowReuse     bool
+		expiredAgo     time.Duration
+		disabledReason string
+	}{
+		{"Active URL", true, -time.Hour, ""},
+		{"Expired but within retention", true, 30 * time.Minute, ""},
+		{"Reuse disabled", false, 2 * time.Hour, ""},
+		{"Expired after a takedown", true, 2 * time.Hour, "phishing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockURLRepo, _ := setupCustomKeyReuse(tt.allowReuse, tt.expiredAgo, tt.disabledReason)
+
+			resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+				LongURL:   "https://example.com/new-campaign",
+				CustomKey: "promo",
+			})
+
+			assert.ErrorIs(t, err, usecase.ErrCustomKeyExists)
+			assert.Nil(t, resp)
+			mockURLRepo.AssertNotCalled(t, "Replace", mock.Anything, mock.Anything)
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+func TestDeleteURL_TombstonesKeyForNextLookup(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	var reason string
+
+	mockURLRepo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("SetTombstone", mock.Anything, "docs", repository.TombstoneDeleted, time.Hour).
+		Run(func(args mock.Arguments) { reason = args.String(2) }).
+		Return(nil)
+
+	require.NoError(t, uc.DeleteURL(context.Background(), "docs"))
+
+	// The next lookup is answered by the tombstone without reaching the database
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "docs").Return(&repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      reason,
+	}, nil)
+
+	_, status, err := uc.Resolve(context.Background(), "docs")
+	assert.ErrorIs(t, err, usecase.ErrURLDeleted)
+	assert.Equal(t, usecase.CacheTombstone, status)
+
+	_, err = uc.GetLongURL(context.Background(), "docs")
+	assert.ErrorIs(t, err, usecase.ErrURLDeleted)
+
+	mockURLRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+	mockCacheRepo.AssertExpectations(t)
+}
+
+func TestDeleteURL_UnknownKey(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	mockURLRepo.On("Delete", mock.Anything, mock.Anything).Return(repository.ErrNotFound)
+
+	err := uc.DeleteURL(context.Background(), "missing")
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+
+	// Invalid keys cannot exist either
+	err = uc.DeleteURL(context.Background(), "not a key!")
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+
+	mockCacheRepo.AssertNotCalled(t, "SetTombstone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDeleteURL_DatabaseFailure(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	mockURLRepo.On("Delete", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	err := uc.DeleteURL(context.Background(), "docs")
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NotErrorIs(t, err, usecase.ErrURLNotFound)
+	mockCacheRepo.AssertNotCalled(t, "SetTombstone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
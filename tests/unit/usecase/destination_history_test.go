@@ -0,0 +1,100 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+// memoryHistory is an in-memory DestinationHistoryRepository.
+type memoryHistory struct {
+	mu      sync.Mutex
+	changes map[string][]repository.DestinationChange
+}
+
+func newMemoryHistory() *memoryHistory {
+	return &memoryHistory{changes: make(map[string][]repository.DestinationChange)}
+}
+
+func (h *memoryHistory) RecordDestinationChange(_ context.Context, shortKey string, change repository.DestinationChange) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.changes[shortKey] = append(h.changes[shortKey], change)
+
+	return nil
+}
+
+func (h *memoryHistory) GetDestinationHistory(_ context.Context, shortKey string) ([]repository.DestinationChange, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.changes[shortKey], nil
+}
+
+func TestDestinationHistory_AccumulatesAcrossUpdates(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+	uc.SetDestinationHistory(newMemoryHistory())
+
+	// The repository hands back the same entity, so each update sees the previous one
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(storedURL(t, "docs", "https://example.com/v1"), nil)
+	mockURLRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("Delete", mock.Anything, "docs").Return(nil)
+
+	ctx := context.Background()
+
+	for _, destination := range []string{"https://example.com/v2", "https://example.com/v3"} {
+		_, err := uc.UpdateDestination(ctx, "docs", destination)
+		require.NoError(t, err)
+	}
+
+	// Repointing to the current destination is not a change
+	_, err := uc.UpdateDestination(ctx, "docs", "https://example.com/v3")
+	require.NoError(t, err)
+
+	history, err := uc.GetDestinationHistory(ctx, "docs")
+	require.NoError(t, err)
+
+	assert.Equal(t, "docs", history.ShortKey)
+	assert.Equal(t, "https://example.com/v3", history.CurrentURL)
+	require.Len(t, history.Changes, 2)
+
+	assert.Equal(t, "https://example.com/v1", history.Changes[0].PreviousURL)
+	assert.Equal(t, "https://example.com/v2", history.Changes[0].NewURL)
+	assert.Equal(t, "https://example.com/v2", history.Changes[1].PreviousURL)
+	assert.Equal(t, "https://example.com/v3", history.Changes[1].NewURL)
+	assert.NotEmpty(t, history.Changes[0].ChangedAt)
+	assert.LessOrEqual(t, history.Changes[0].ChangedAt, history.Changes[1].ChangedAt)
+}
+
+func TestDestinationHistory_Errors(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	_, err := uc.GetDestinationHistory(context.Background(), "docs")
+	assert.ErrorIs(t, err, usecase.ErrHistoryUnavailable)
+
+	uc.SetDestinationHistory(newMemoryHistory())
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+	_, err = uc.GetDestinationHistory(context.Background(), "missing")
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+}
+
+func TestDestinationHistory_NewURLHasEmptyHistory(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+	uc.SetDestinationHistory(newMemoryHistory())
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(storedURL(t, "docs", "https://example.com/v1"), nil)
+
+	history, err := uc.GetDestinationHistory(context.Background(), "docs")
+	require.NoError(t, err)
+	assert.Empty(t, history.Changes)
+	assert.NotNil(t, history.Changes, "changes serialise as [] rather than null")
+}
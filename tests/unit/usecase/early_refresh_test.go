@@ -0,0 +1,158 @@
+package usecase_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// countEarlyRefreshes performs reads against a cache entry that expires in cachedFor
+// and returns how many of them re-set the entry once the background refreshes finish.
+func countEarlyRefreshes(t *testing.T, probability float64, cachedFor time.Duration, reads int) int {
+	t.Helper()
+
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.EarlyRefreshWindow = time.Minute
+	config.EarlyRefreshProbability = probability
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	shortKey, _ := valueobject.NewShortKey("hot")
+	longURL, _ := valueobject.NewLongURL("https://example.com/hot")
+	cachedUntil := time.Now().Add(cachedFor)
+
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "hot").Return(&repository.CacheEntry{
+		LongURL:     "https://example.com/hot",
+		CreatedAt:   time.Now().Add(-time.Hour),
+		CachedUntil: &cachedUntil,
+	}, nil)
+
+	var refreshes atomic.Int64
+
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "hot", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).
+		Run(func(mock.Arguments) { refreshes.Add(1) }).
+		Return(nil)
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	for i := 0; i < reads; i++ {
+		redirectURL, err := uc.GetLongURL(context.Background(), "hot")
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/hot", redirectURL)
+	}
+
+	// Refreshes run in the background; wait until no more arrive
+	for last := int64(-1); last != refreshes.Load(); {
+		last = refreshes.Load()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	return int(refreshes.Load())
+}
+
+func TestGetLongURL_EarlyRefreshWithinWindow(t *testing.T) {
+	const reads = 2000
+
+	refreshes := countEarlyRefreshes(t, 0.2, 10*time.Second, reads)
+
+	// Roughly 20% of reads re-set the entry; the bounds leave room for randomness
+	fraction := float64(refreshes) / reads
+	assert.Greater(t, fraction, 0.12)
+	assert.Less(t, fraction, 0.28)
+}
+
+func TestGetLongURL_EarlyRefreshRunsInBackground(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.EarlyRefreshWindow = time.Minute
+	config.EarlyRefreshProbability = 1
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	shortKey, _ := valueobject.NewShortKey("hot")
+	longURL, _ := valueobject.NewLongURL("https://example.com/hot")
+	cachedUntil := time.Now().Add(10 * time.Second)
+
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "hot").Return(&repository.CacheEntry{
+		LongURL:     "https://example.com/hot",
+		CreatedAt:   time.Now().Add(-time.Hour),
+		CachedUntil: &cachedUntil,
+	}, nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	// The database lookup blocks until released, so the read can only finish if it does not wait
+	release := make(chan struct{})
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { <-release }).
+		Return(entity.NewURL(shortKey, longURL), nil)
+
+	reset := make(chan *repository.CacheEntry, 1)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "hot", mock.AnythingOfType("*repository.CacheEntry"), time.Hour).
+		Run(func(args mock.Arguments) { reset <- args.Get(2).(*repository.CacheEntry) }).
+		Return(nil)
+
+	redirectURL, err := uc.GetLongURL(context.Background(), "hot")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hot", redirectURL)
+
+	close(release)
+
+	select {
+	case entry := <-reset:
+		assert.Equal(t, "https://example.com/hot", entry.LongURL)
+		require.NotNil(t, entry.CachedUntil)
+		assert.Greater(t, time.Until(*entry.CachedUntil), 59*time.Minute)
+	case <-time.After(time.Second):
+		require.Fail(t, "cache entry was not re-set in the background")
+	}
+}
+
+func TestGetLongURL_NoEarlyRefreshOutsideWindow(t *testing.T) {
+	assert.Zero(t, countEarlyRefreshes(t, 1, 10*time.Minute, 200))
+}
+
+func TestGetLongURL_EarlyRefreshDisabled(t *testing.T) {
+	assert.Zero(t, countEarlyRefreshes(t, 0, 10*time.Second, 200))
+}
+
+func TestGetLongURL_RefreshedEntryRecordsCacheExpiry(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+	longURL, _ := valueobject.NewLongURL("https://example.com")
+
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(nil, assert.AnError)
+	mockCacheRepo.On("Get", mock.Anything, "abc123").Return("", assert.AnError)
+	mockURLRepo.On("FindByShortKey", mock.Anything, shortKey).Return(entity.NewURL(shortKey, longURL), nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, shortKey).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.MatchedBy(func(entry *repository.CacheEntry) bool {
+		return entry.CachedUntil != nil && time.Until(*entry.CachedUntil) > 59*time.Minute
+	}), time.Hour).Return(nil)
+
+	_, err := uc.GetLongURL(context.Background(), "abc123")
+
+	require.NoError(t, err)
+	mockCacheRepo.AssertExpectations(t)
+}
@@ -0,0 +1,162 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestSetEnabled_DisableBlocksAndReenableServes(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("paused1")
+	longURL, _ := valueobject.NewLongURL("https://example.com/campaign")
+	url := entity.NewURL(shortKey, longURL)
+	url.SetExpiration(24 * time.Hour)
+
+	// The stored row reflects the last SetEnabled call
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(url, nil)
+	mockURLRepo.On("SetEnabled", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey"), mock.AnythingOfType("bool")).
+		Run(func(args mock.Arguments) {
+			url.Disabled = !args.Bool(2)
+		}).Return(nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(nil).Maybe()
+
+	// Cache always misses so every lookup exercises the database path
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "paused1").Return(nil, assert.AnError)
+	mockCacheRepo.On("Get", mock.Anything, "paused1").Return("", assert.AnError)
+	mockCacheRepo.On("SetTombstone", mock.Anything, "paused1", "disabled", time.Hour).Return(nil)
+	mockCacheRepo.On("Delete", mock.Anything, "paused1").Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "paused1", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+
+	// Served while enabled
+	got, err := uc.GetLongURL(context.Background(), "paused1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/campaign", got)
+
+	// Disable -> blocked
+	require.NoError(t, uc.SetEnabled(context.Background(), "paused1", false))
+	mockCacheRepo.AssertCalled(t, "SetTombstone", mock.Anything, "paused1", "disabled", time.Hour)
+
+	_, err = uc.GetLongURL(context.Background(), "paused1")
+	assert.ErrorIs(t, err, usecase.ErrURLDisabled)
+
+	// Re-enable -> tombstone cleared and served again
+	require.NoError(t, uc.SetEnabled(context.Background(), "paused1", true))
+	mockCacheRepo.AssertCalled(t, "Delete", mock.Anything, "paused1")
+
+	got, err = uc.GetLongURL(context.Background(), "paused1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/campaign", got)
+}
+
+func TestSetEnabled_UnknownKey(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(nil, usecase.ErrURLNotFound)
+
+	err := uc.SetEnabled(context.Background(), "missing", false)
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+	mockURLRepo.AssertNotCalled(t, "SetEnabled", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetLongURL_DisabledTombstoneSkipsDatabase(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	tombstone := &repository.CacheEntry{
+		IsTombstone: true,
+		Reason:      repository.TombstoneDisabled,
+		CreatedAt:   time.Now(),
+	}
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "paused1").Return(tombstone, nil)
+
+	_, err := uc.GetLongURL(context.Background(), "paused1")
+
+	assert.ErrorIs(t, err, usecase.ErrURLDisabled)
+	mockURLRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+	mockURLRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+}
+
+func TestDisableURL_RecordsReasonAndTombstones(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("abuse1")
+	longURL, _ := valueobject.NewLongURL("https://example.com/phish")
+	url := entity.NewURL(shortKey, longURL)
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(url, nil)
+	mockURLRepo.On("Disable", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey"), "phishing report #118").Return(nil)
+
+	var tombstone *repository.CacheEntry
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abuse1", mock.AnythingOfType("*repository.CacheEntry"), time.Hour).
+		Run(func(args mock.Arguments) {
+			tombstone = args.Get(2).(*repository.CacheEntry)
+		}).Return(nil)
+
+	require.NoError(t, uc.DisableURL(context.Background(), "abuse1", "phishing report #118"))
+
+	require.NotNil(t, tombstone)
+	assert.True(t, tombstone.IsTombstone)
+	assert.Equal(t, repository.TombstoneDisabled, tombstone.Reason)
+	assert.Equal(t, "phishing report #118", tombstone.Detail)
+
+	// The tombstone answers the next lookup with the reason, without reaching the database
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "abuse1").Return(tombstone, nil)
+
+	_, err := uc.GetLongURL(context.Background(), "abuse1")
+
+	var disabled *usecase.URLDisabledError
+	require.ErrorAs(t, err, &disabled)
+	assert.ErrorIs(t, err, usecase.ErrURLDisabled)
+	assert.Equal(t, "phishing report #118", disabled.Reason)
+	mockURLRepo.AssertNumberOfCalls(t, "FindByShortKey", 1)
+}
+
+func TestGetLongURL_DisabledInDatabaseCachesReason(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, nil, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("abuse1")
+	longURL, _ := valueobject.NewLongURL("https://example.com/phish")
+	url := entity.NewURL(shortKey, longURL)
+	url.Disabled = true
+	url.DisabledReason = "malware"
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(url, nil)
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "abuse1").Return(nil, repository.ErrCacheMiss)
+	mockCacheRepo.On("Get", mock.Anything, "abuse1").Return("", repository.ErrCacheMiss)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abuse1", mock.MatchedBy(func(entry *repository.CacheEntry) bool {
+		return entry.IsTombstone && entry.Reason == repository.TombstoneDisabled && entry.Detail == "malware"
+	}), time.Hour).Return(nil)
+
+	_, err := uc.GetLongURL(context.Background(), "abuse1")
+
+	var disabled *usecase.URLDisabledError
+	require.ErrorAs(t, err, &disabled)
+	assert.Equal(t, "malware", disabled.Reason)
+	mockCacheRepo.AssertExpectations(t)
+	mockURLRepo.AssertNotCalled(t, "IncrementVisitCount", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/apperror"
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+)
+
+func TestShortenURL_RejectsExpiryInThePast(t *testing.T) {
+	tests := []struct {
+		name string
+		req  dto.ShortenURLRequest
+	}{
+		{name: "Absolute expiry in the past", req: dto.ShortenURLRequest{ExpiresAt: time.Now().Add(-time.Hour).Format(time.RFC3339)}},
+		{name: "Absolute expiry long ago", req: dto.ShortenURLRequest{ExpiresAt: "2001-01-01T00:00:00Z"}},
+		{name: "Negative TTL", req: dto.ShortenURLRequest{TTLSeconds: -60}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			tt.req.LongURL = "https://example.com/docs"
+
+			_, err := uc.Shorten(context.Background(), &tt.req)
+			require.ErrorIs(t, err, usecase.ErrExpiryInPast)
+
+			var appErr *apperror.Error
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, http.StatusBadRequest, appErr.HTTPStatus)
+
+			// Nothing is looked up or stored for a URL that would be dead on arrival
+			mockURLRepo.AssertNotCalled(t, "FindByLongURL", mock.Anything, mock.Anything)
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShortenURL_AcceptsNearFutureExpiry(t *testing.T) {
+	uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+	expiresAt := time.Now().Add(2 * time.Minute).Truncate(time.Second).UTC()
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+		LongURL:   "https://example.com/docs",
+		ExpiresAt: expiresAt.Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expiresAt.Format(time.RFC3339), resp.ExpiresAt)
+	mockURLRepo.AssertCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShortenURL_TTLSecondsOnly(t *testing.T) {
+	uc, _, _ := newShortenUseCaseWithConfig(nil)
+
+	before := time.Now()
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com/docs", TTLSeconds: 3600})
+	require.NoError(t, err)
+
+	expiresAt, err := time.Parse(time.RFC3339, resp.ExpiresAt)
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(time.Hour), expiresAt, 2*time.Second)
+}
+
+func TestShortenURL_ExpiresAtTakesPrecedenceOverConsistentTTL(t *testing.T) {
+	uc, _, _ := newShortenUseCaseWithConfig(nil)
+
+	// The TTL was computed on a clock 20 seconds behind; the timestamp is used as given
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+		LongURL:    "https://example.com/docs",
+		TTLSeconds: 3620,
+		ExpiresAt:  expiresAt.Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, expiresAt.Format(time.RFC3339), resp.ExpiresAt)
+}
+
+func TestShortenURL_RejectsMalformedOrInconsistentExpiry(t *testing.T) {
+	future := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		req     dto.ShortenURLRequest
+		wantErr error
+	}{
+		{name: "Malformed timestamp", req: dto.ShortenURLRequest{ExpiresAt: "tomorrow"}, wantErr: usecase.ErrInvalidExpiry},
+		{name: "Date without time", req: dto.ShortenURLRequest{ExpiresAt: "2030-01-01"}, wantErr: usecase.ErrInvalidExpiry},
+		{name: "TTL disagrees with timestamp", req: dto.ShortenURLRequest{ExpiresAt: future, TTLSeconds: 86400}, wantErr: usecase.ErrInconsistentExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			tt.req.LongURL = "https://example.com/docs"
+
+			_, err := uc.Shorten(context.Background(), &tt.req)
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
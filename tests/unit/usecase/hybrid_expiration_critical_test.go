@@ -24,6 +24,7 @@ func TestHybridExpirationStrategy_NoSynchronousDeletes(t *testing.T) {
 
 	// Cache miss
 	cacheRepo.On("GetCacheEntry", mock.Anything, "expired").Return(nil, assert.AnError)
+	cacheRepo.On("Get", mock.Anything, "expired").Return("", assert.AnError)
 
 	// Database returns expired URL
 	shortKey, _ := valueobject.NewShortKey("expired")
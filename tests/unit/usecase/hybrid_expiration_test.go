@@ -53,6 +53,7 @@ func TestHybridExpirationStrategy(t *testing.T) {
 			setupMocks: func(urlRepo *MockURLRepository, cacheRepo *MockCacheRepository) {
 				// Cache miss
 				cacheRepo.On("GetCacheEntry", mock.Anything, "expired123").Return(nil, assert.AnError)
+				cacheRepo.On("Get", mock.Anything, "expired123").Return("", assert.AnError)
 
 				// Database returns expired URL
 				shortKey, _ := valueobject.NewShortKey("expired123")
@@ -126,6 +127,11 @@ func (m *MockURLRepository) Save(ctx context.Context, url *entity.URL) error {
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) BatchSave(ctx context.Context, urls []*entity.URL) error {
+	args := m.Called(ctx, urls)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error) {
 	args := m.Called(ctx, shortKey)
 	if args.Get(0) == nil {
@@ -144,11 +150,25 @@ func (m *MockURLRepository) FindByLongURL(ctx context.Context, longURL *valueobj
 	return args.Get(0).(*entity.URL), args.Error(1)
 }
 
+func (m *MockURLRepository) FindByLongURLAndOwner(ctx context.Context, longURL *valueobject.LongURL, owner string) (*entity.URL, error) {
+	args := m.Called(ctx, longURL, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
 func (m *MockURLRepository) Update(ctx context.Context, url *entity.URL) error {
 	args := m.Called(ctx, url)
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) Replace(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) Delete(ctx context.Context, shortKey *valueobject.ShortKey) error {
 	args := m.Called(ctx, shortKey)
 	return args.Error(0)
@@ -183,6 +203,57 @@ func (m *MockURLRepository) GetExpiredCount(ctx context.Context, before time.Tim
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockURLRepository) Upsert(ctx context.Context, url *entity.URL) (repository.UpsertResult, error) {
+	args := m.Called(ctx, url)
+	return args.Get(0).(repository.UpsertResult), args.Error(1)
+}
+
+func (m *MockURLRepository) SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error {
+	args := m.Called(ctx, shortKey, enabled)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error {
+	args := m.Called(ctx, shortKey, reason)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) RenewByCriteria(ctx context.Context, criteria repository.RenewCriteria, expiresAt time.Time) ([]string, error) {
+	args := m.Called(ctx, criteria, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByHost(ctx context.Context, host string, cursor *repository.KeysetCursor, limit int) ([]*entity.URL, error) {
+	args := m.Called(ctx, host, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error) {
+	args := m.Called(ctx, limit, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error) {
+	args := m.Called(ctx, limit, offset, orderBy)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Get(1).(int64), args.Error(2)
+}
+
 func (m *MockCacheRepository) Set(ctx context.Context, key string, value string, ttl time.Duration) error {
 	args := m.Called(ctx, key, value, ttl)
 	return args.Error(0)
@@ -221,3 +292,8 @@ func (m *MockCacheRepository) SetTombstone(ctx context.Context, key string, reas
 	args := m.Called(ctx, key, reason, ttl)
 	return args.Error(0)
 }
+
+func (m *MockCacheRepository) FlushNamespace(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
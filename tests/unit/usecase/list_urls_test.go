@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+)
+
+func TestListURLs_DefaultsAndPaginationMetadata(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	urls := []*entity.URL{storedURL(t, "first", "https://example.com/1"), storedURL(t, "second", "https://example.com/2")}
+	mockURLRepo.On("List", mock.Anything, usecase.DefaultURLListLimit, 10, repository.ListOrderCreatedAt).
+		Return(urls, int64(15), nil)
+
+	resp, err := uc.ListURLs(context.Background(), 0, 10, "")
+	require.NoError(t, err)
+
+	require.Len(t, resp.Items, 2)
+	assert.Equal(t, "first", resp.Items[0].ShortKey)
+	assert.Equal(t, usecase.DefaultURLListLimit, resp.Pagination.Limit)
+	assert.Equal(t, 10, resp.Pagination.Offset)
+	assert.Equal(t, int64(15), resp.Pagination.Total)
+	assert.True(t, resp.Pagination.HasMore)
+}
+
+func TestListURLs_ClampsLimit(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	mockURLRepo.On("List", mock.Anything, usecase.MaxURLListLimit, 0, repository.ListOrderVisitCount).
+		Return([]*entity.URL{}, int64(0), nil)
+
+	resp, err := uc.ListURLs(context.Background(), 10000, 0, "visit_count")
+	require.NoError(t, err)
+
+	assert.Equal(t, usecase.MaxURLListLimit, resp.Pagination.Limit)
+	assert.Empty(t, resp.Items)
+	assert.NotNil(t, resp.Items)
+	assert.False(t, resp.Pagination.HasMore)
+}
+
+func TestListURLs_LastPageHasNoMore(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	mockURLRepo.On("List", mock.Anything, 2, 4, repository.ListOrderExpiresAt).
+		Return([]*entity.URL{storedURL(t, "last", "https://example.com/5")}, int64(5), nil)
+
+	resp, err := uc.ListURLs(context.Background(), 2, 4, "expires_at")
+	require.NoError(t, err)
+
+	assert.False(t, resp.Pagination.HasMore)
+}
+
+func TestListURLs_RejectsNegativeOffset(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	_, err := uc.ListURLs(context.Background(), 10, -1, "")
+	assert.ErrorIs(t, err, usecase.ErrInvalidOffset)
+
+	mockURLRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestListURLs_RejectsUnknownSort(t *testing.T) {
+	uc, mockURLRepo, _ := newUpdateUseCase()
+
+	// Sort values never reach the query unless they are whitelisted
+	for _, sort := range []string{"long_url", "created_at; DROP TABLE urls", "CREATED_AT"} {
+		_, err := uc.ListURLs(context.Background(), 10, 0, sort)
+		assert.ErrorIs(t, err, usecase.ErrInvalidSort, sort)
+	}
+
+	mockURLRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,93 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestShortenURL_OwnerScopedDedup(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+
+	config := usecase.DefaultShortenURLConfig()
+	config.OwnerScopedDedup = true
+
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	const destination = "https://example.com/pricing"
+
+	longURL, _ := valueobject.NewLongURL(destination)
+	aliceKey, _ := valueobject.NewShortKey("alice1")
+	bobKey, _ := valueobject.NewShortKey("bob001")
+
+	// Neither owner has shortened the destination yet
+	mockURLRepo.On("FindByLongURLAndOwner", mock.Anything, longURL, "alice").Return(nil, usecase.ErrURLNotFound).Once()
+	mockURLRepo.On("FindByLongURLAndOwner", mock.Anything, longURL, "bob").Return(nil, usecase.ErrURLNotFound).Once()
+
+	mockIDGen.On("Generate").Return(int64(1), nil).Once()
+	mockIDGen.On("Generate").Return(int64(2), nil).Once()
+	mockShortKeyGen.On("GenerateFromID", int64(1)).Return(aliceKey, nil)
+	mockShortKeyGen.On("GenerateFromID", int64(2)).Return(bobKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.ShortKey.Value() == "alice1" && url.Owner == "alice"
+	})).Return(nil).Once()
+	mockURLRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.ShortKey.Value() == "bob001" && url.Owner == "bob"
+	})).Return(nil).Once()
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	aliceResp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: destination, Owner: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice1", aliceResp.ShortKey)
+
+	bobResp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: destination, Owner: "bob"})
+	require.NoError(t, err)
+	assert.Equal(t, "bob001", bobResp.ShortKey, "each owner gets their own key for the same destination")
+
+	// Alice repeats the request and gets her existing key back
+	aliceURL := entity.NewURL(aliceKey, longURL)
+	aliceURL.Owner = "alice"
+	mockURLRepo.On("FindByLongURLAndOwner", mock.Anything, longURL, "alice").Return(aliceURL, nil).Once()
+
+	repeatResp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: destination, Owner: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice1", repeatResp.ShortKey)
+
+	mockURLRepo.AssertNotCalled(t, "FindByLongURL", mock.Anything, mock.Anything)
+	mockURLRepo.AssertNumberOfCalls(t, "Save", 2)
+	mockIDGen.AssertNumberOfCalls(t, "Generate", 2)
+}
+
+func TestShortenURL_GlobalDedupIgnoresOwnerByDefault(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, new(MockCacheRepository), genService, "http://localhost:8080", time.Hour)
+
+	longURL, _ := valueobject.NewLongURL("https://example.com/pricing")
+	aliceKey, _ := valueobject.NewShortKey("alice1")
+	aliceURL := entity.NewURL(aliceKey, longURL)
+	aliceURL.Owner = "alice"
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, longURL).Return(aliceURL, nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "https://example.com/pricing", Owner: "bob"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "alice1", resp.ShortKey)
+	mockURLRepo.AssertNotCalled(t, "FindByLongURLAndOwner", mock.Anything, mock.Anything, mock.Anything)
+}
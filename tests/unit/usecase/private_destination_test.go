@@ -0,0 +1,117 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestShortenURL_RejectsSelfReferentialDestination(t *testing.T) {
+	for _, longURL := range []string{
+		"http://localhost:8080/abc123",
+		"https://LOCALHOST/abc123",
+		"localhost:8080/api/shorten",
+	} {
+		t.Run(longURL, func(t *testing.T) {
+			// The credentials use case is served from http://localhost:8080
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+			assert.ErrorIs(t, err, usecase.ErrSelfReferentialURL)
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShortenURL_RejectsPrivateDestinations(t *testing.T) {
+	for _, longURL := range []string{
+		"http://127.0.0.1/admin",
+		"http://10.0.0.5/internal",
+		"http://192.168.1.1/",
+		"http://172.16.0.10:9000/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]:3000/",
+		"http://0.0.0.0/",
+		"http://app.localhost/",
+	} {
+		t.Run(longURL, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(nil)
+
+			_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+			assert.ErrorIs(t, err, usecase.ErrPrivateDestination)
+			mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestShortenURL_AcceptsPublicDestination(t *testing.T) {
+	for _, longURL := range []string{
+		"https://example.com/page",
+		"http://93.184.216.34/",
+		"http://localhost.example.com/",
+	} {
+		t.Run(longURL, func(t *testing.T) {
+			uc, _, _ := newShortenUseCaseWithConfig(nil)
+
+			resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+			require.NoError(t, err)
+			assert.Equal(t, longURL, resp.LongURL)
+		})
+	}
+}
+
+func TestShortenURL_PrivateDestinationsAllowedWhenDisabled(t *testing.T) {
+	config := usecase.DefaultShortenURLConfig()
+	config.BlockPrivateHosts = false
+
+	uc, _, _ := newShortenUseCaseWithConfig(config)
+
+	for _, longURL := range []string{"http://10.0.0.5/internal", "http://127.0.0.1:3000/"} {
+		resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: longURL})
+
+		require.NoError(t, err, longURL)
+		assert.Equal(t, longURL, resp.LongURL)
+	}
+}
+
+func TestShortenURL_SameHostOnAnotherPortIsNotSelfReferential(t *testing.T) {
+	// Served from http://localhost:8080 with private destinations allowed
+	config := usecase.DefaultShortenURLConfig()
+	config.BlockPrivateHosts = false
+
+	uc, _, _ := newShortenUseCaseWithConfig(config)
+
+	_, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "http://localhost:3000/"})
+	require.NoError(t, err)
+
+	_, err = uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "http://localhost:8080/abc123"})
+	assert.ErrorIs(t, err, usecase.ErrSelfReferentialURL)
+}
+
+func TestLongURL_IsPrivateHost(t *testing.T) {
+	for rawURL, expected := range map[string]bool{
+		"http://127.0.0.1/":         true,
+		"http://10.0.0.5/":          true,
+		"http://[fe80::1]/":         true,
+		"http://[fd00::1]/":         true,
+		"http://localhost./":        true,
+		"http://8.8.8.8/":           false,
+		"https://example.com/":      false,
+		"http://internal.corp.net/": false,
+	} {
+		longURL, err := valueobject.NewLongURL(rawURL)
+		require.NoError(t, err, rawURL)
+
+		assert.Equal(t, expected, longURL.IsPrivateHost(), rawURL)
+	}
+}
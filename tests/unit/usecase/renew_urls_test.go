@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+func newRenewUseCase() (*usecase.ShortenURLUseCase, *MockURLRepository, *MockCacheRepository) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	return uc, mockURLRepo, mockCacheRepo
+}
+
+func TestRenewURLs_InvalidatesRenewedCacheEntries(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := newRenewUseCase()
+
+	criteria := repository.RenewCriteria{Host: "shop.example", KeyPrefix: "spring"}
+
+	mockURLRepo.On("RenewByCriteria", mock.Anything, criteria, mock.AnythingOfType("time.Time")).
+		Return([]string{"spring1", "spring2"}, nil)
+	mockCacheRepo.On("Delete", mock.Anything, "spring1").Return(nil)
+	mockCacheRepo.On("Delete", mock.Anything, "spring2").Return(assert.AnError)
+
+	resp, err := uc.RenewURLs(context.Background(), &dto.RenewURLsRequest{
+		Host:       "Shop.Example:443",
+		KeyPrefix:  "spring",
+		TTLSeconds: 7 * 24 * 3600,
+		Confirm:    true,
+	})
+
+	require.NoError(t, err)
+	// A failed cache delete is logged, not fatal: the database update already happened
+	assert.Equal(t, 2, resp.Renewed)
+
+	expiresAt, err := time.Parse(time.RFC3339, resp.ExpiresAt)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), expiresAt, time.Minute)
+	mockCacheRepo.AssertNumberOfCalls(t, "Delete", 2)
+}
+
+func TestRenewURLs_CreatedBetweenAndExplicitExpiry(t *testing.T) {
+	uc, mockURLRepo, _ := newRenewUseCase()
+
+	after := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	expiresAt := time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+
+	mockURLRepo.On("RenewByCriteria", mock.Anything, mock.MatchedBy(func(c repository.RenewCriteria) bool {
+		return c.CreatedAfter.Equal(after) && c.CreatedBefore.Equal(before) && c.Host == "" && c.KeyPrefix == ""
+	}), mock.MatchedBy(expiresAt.Equal)).Return(nil, nil)
+
+	resp, err := uc.RenewURLs(context.Background(), &dto.RenewURLsRequest{
+		CreatedAfter:  after.Format(time.RFC3339),
+		CreatedBefore: before.Format(time.RFC3339),
+		ExpiresAt:     expiresAt.Format(time.RFC3339),
+		Confirm:       true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Renewed)
+	mockURLRepo.AssertExpectations(t)
+}
+
+func TestRenewURLs_ByOwner(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := newRenewUseCase()
+
+	mockURLRepo.On("RenewByCriteria", mock.Anything, repository.RenewCriteria{Owner: "alice"}, mock.AnythingOfType("time.Time")).
+		Return([]string{"alice1"}, nil)
+	mockCacheRepo.On("Delete", mock.Anything, "alice1").Return(nil)
+
+	resp, err := uc.RenewURLs(context.Background(), &dto.RenewURLsRequest{
+		Owner:      " alice ",
+		TTLSeconds: 3600,
+		Confirm:    true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.Renewed)
+	mockURLRepo.AssertExpectations(t)
+}
+
+func TestRenewURLs_RejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     dto.RenewURLsRequest
+		wantErr error
+	}{
+		{
+			name:    "Not confirmed",
+			req:     dto.RenewURLsRequest{Host: "shop.example", TTLSeconds: 60},
+			wantErr: usecase.ErrRenewNotConfirmed,
+		},
+		{
+			name:    "No criteria",
+			req:     dto.RenewURLsRequest{TTLSeconds: 60, Confirm: true},
+			wantErr: usecase.ErrInvalidRenewal,
+		},
+		{
+			name:    "No new expiry",
+			req:     dto.RenewURLsRequest{Host: "shop.example", Confirm: true},
+			wantErr: usecase.ErrInvalidRenewal,
+		},
+		{
+			name:    "Both ttl and expires_at",
+			req:     dto.RenewURLsRequest{Host: "shop.example", TTLSeconds: 60, ExpiresAt: "2099-01-01T00:00:00Z", Confirm: true},
+			wantErr: usecase.ErrInvalidRenewal,
+		},
+		{
+			name:    "Expiry in the past",
+			req:     dto.RenewURLsRequest{Host: "shop.example", ExpiresAt: "2001-01-01T00:00:00Z", Confirm: true},
+			wantErr: usecase.ErrInvalidRenewal,
+		},
+		{
+			name:    "Malformed created_after",
+			req:     dto.RenewURLsRequest{CreatedAfter: "last week", TTLSeconds: 60, Confirm: true},
+			wantErr: usecase.ErrInvalidRenewal,
+		},
+		{
+			name:    "Malformed host",
+			req:     dto.RenewURLsRequest{Host: "shop.example/path", TTLSeconds: 60, Confirm: true},
+			wantErr: usecase.ErrInvalidHost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockURLRepo, _ := newRenewUseCase()
+
+			resp, err := uc.RenewURLs(context.Background(), &tt.req)
+
+			assert.Nil(t, resp)
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockURLRepo.AssertNotCalled(t, "RenewByCriteria", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
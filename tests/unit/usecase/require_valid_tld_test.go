@@ -0,0 +1,88 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// tldConfig turns TLD validation on, allowing the bare hosts in allowed.
+func tldConfig(allowed []string) *usecase.ShortenURLConfig {
+	config := usecase.DefaultShortenURLConfig()
+	config.RequireValidTLD = true
+	config.AllowedBareHosts = allowed
+	// Bare and IP-literal hosts below are local; only the TLD rule is under test
+	config.BlockPrivateHosts = false
+
+	return config
+}
+
+func TestShortenURL_RequireValidTLD(t *testing.T) {
+	tests := []struct {
+		name    string
+		longURL string
+		allowed []string
+		wantErr error
+	}{
+		{name: "Bare hostname", longURL: "http://foo/path", wantErr: valueobject.ErrInvalidHost},
+		{name: "Localhost not allowlisted", longURL: "http://localhost:3000", wantErr: valueobject.ErrInvalidHost},
+		{name: "Unknown TLD", longURL: "https://service.notarealtld", wantErr: valueobject.ErrInvalidHost},
+		{name: "Registered domain", longURL: "https://example.com/page"},
+		{name: "IPv4 literal", longURL: "http://192.168.1.1/admin"},
+		{name: "IPv6 literal", longURL: "http://[::1]:8080/"},
+		{name: "Localhost allowlisted", longURL: "http://localhost:3000", allowed: []string{"localhost"}},
+		{name: "Allowlisted suffix", longURL: "http://wiki.corp.internal", allowed: []string{".internal"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, mockURLRepo, _ := newShortenUseCaseWithConfig(tldConfig(tt.allowed))
+
+			resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: tt.longURL})
+
+			if tt.wantErr != nil {
+				assert.Nil(t, resp)
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "abc123", resp.ShortKey)
+		})
+	}
+}
+
+func TestShortenURL_BareHostAcceptedByDefault(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(12345), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(12345)).Return(shortKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+
+	resp, err := uc.Shorten(context.Background(), &dto.ShortenURLRequest{LongURL: "http://foo/path"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", resp.ShortKey)
+}
@@ -0,0 +1,81 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+// newReservedKeyUseCase returns a use case where every custom key is free to claim.
+func newReservedKeyUseCase(config *usecase.ShortenURLConfig) (*usecase.ShortenURLUseCase, *MockURLRepository) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockIDGen.On("Generate").Return(int64(12345), nil)
+
+	genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	return uc, mockURLRepo
+}
+
+func shortenWithKey(uc *usecase.ShortenURLUseCase, key string) (*dto.ShortenURLResponse, error) {
+	return uc.Shorten(context.Background(), &dto.ShortenURLRequest{
+		LongURL:   "https://example.com/page",
+		CustomKey: key,
+	})
+}
+
+func TestShortenURL_ReservedCustomKeysRejected(t *testing.T) {
+	uc, mockURLRepo := newReservedKeyUseCase(usecase.DefaultShortenURLConfig())
+
+	for _, key := range []string{"api", "health", "admin", "API", "Health"} {
+		_, err := shortenWithKey(uc, key)
+		assert.ErrorIs(t, err, valueobject.ErrReservedShortKey, key)
+	}
+
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestShortenURL_UnreservedCustomKeysPass(t *testing.T) {
+	uc, _ := newReservedKeyUseCase(usecase.DefaultShortenURLConfig())
+
+	for _, key := range []string{"docs", "apis", "my-admin", "healthy"} {
+		resp, err := shortenWithKey(uc, key)
+		require.NoError(t, err, key)
+		assert.Equal(t, key, resp.ShortKey)
+	}
+}
+
+func TestShortenURL_ReservedKeysConfigurable(t *testing.T) {
+	config := usecase.DefaultShortenURLConfig()
+	config.ReservedShortKeys = []string{" login "}
+
+	uc, _ := newReservedKeyUseCase(config)
+
+	_, err := shortenWithKey(uc, "Login")
+	assert.ErrorIs(t, err, valueobject.ErrReservedShortKey)
+
+	// Replacing the list drops the defaults
+	_, err = shortenWithKey(uc, "admin")
+	assert.NoError(t, err)
+
+	// Defaults are copied, so editing one config's list leaves the defaults untouched
+	edited := usecase.DefaultShortenURLConfig()
+	edited.ReservedShortKeys[0] = "login"
+	assert.Equal(t, []string{"api", "health", "admin"}, usecase.DefaultShortenURLConfig().ReservedShortKeys)
+}
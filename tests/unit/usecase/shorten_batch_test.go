@@ -0,0 +1,99 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/dto"
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestShortenBatch_MixedItems(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, service.NewGeneratorService(mockIDGen, mockShortKeyGen), "http://localhost:8080", time.Hour)
+
+	generatedKey, _ := valueobject.NewShortKey("gen001")
+
+	mockIDGen.On("Generate").Return(int64(42), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(42)).Return(generatedKey, nil)
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("BatchSave", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	responses, errs := uc.ShortenBatch(context.Background(), []*dto.ShortenURLRequest{
+		{LongURL: "https://example.com/docs", CustomKey: "docs"},
+		{LongURL: "not a url"},
+		{LongURL: "https://example.com/pricing"},
+		{LongURL: "https://example.com/other-docs", CustomKey: "docs"},
+		{LongURL: "https://example.com/pricing"},
+	})
+
+	require.Len(t, responses, 5)
+	require.Len(t, errs, 5)
+
+	// Valid items succeed
+	require.NoError(t, errs[0])
+	assert.Equal(t, "docs", responses[0].ShortKey)
+	require.NoError(t, errs[2])
+	assert.Equal(t, "gen001", responses[2].ShortKey)
+
+	// The invalid URL fails on its own
+	assert.Nil(t, responses[1])
+	assert.True(t, errors.Is(errs[1], valueobject.ErrInvalidURL) || errors.Is(errs[1], valueobject.ErrEmptyURL), errs[1])
+
+	// The second use of a custom key within the batch conflicts
+	assert.Nil(t, responses[3])
+	assert.ErrorIs(t, errs[3], usecase.ErrCustomKeyExists)
+
+	// A repeated destination shares the key created for its first occurrence
+	require.NoError(t, errs[4])
+	assert.Equal(t, "gen001", responses[4].ShortKey)
+
+	// New URLs are inserted together
+	mockURLRepo.AssertNumberOfCalls(t, "BatchSave", 1)
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	assert.Len(t, mockURLRepo.Calls[len(mockURLRepo.Calls)-1].Arguments.Get(1), 2)
+	mockIDGen.AssertNumberOfCalls(t, "Generate", 2)
+}
+
+func TestShortenBatch_SaveFailureIsPerItem(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockIDGen.On("Generate").Return(int64(1), nil)
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator)), "http://localhost:8080", time.Hour)
+
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("BatchSave", mock.Anything, mock.Anything).Return(&repository.DuplicateKeyError{ShortKey: "broken"})
+	mockURLRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool { return url.ShortKey.Value() == "broken" })).Return(assert.AnError)
+	mockURLRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	responses, errs := uc.ShortenBatch(context.Background(), []*dto.ShortenURLRequest{
+		{LongURL: "https://example.com/a", CustomKey: "broken"},
+		{LongURL: "https://example.com/b", CustomKey: "fine"},
+	})
+
+	assert.ErrorIs(t, errs[0], assert.AnError)
+	assert.Nil(t, responses[0])
+	require.NoError(t, errs[1])
+	assert.Equal(t, "fine", responses[1].ShortKey)
+
+	mockCacheRepo.AssertNotCalled(t, "SetCacheEntry", mock.Anything, "broken", mock.Anything, mock.Anything)
+}
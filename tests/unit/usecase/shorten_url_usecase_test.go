@@ -26,6 +26,11 @@ func (m *MockURLRepository) Save(ctx context.Context, url *entity.URL) error {
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) BatchSave(ctx context.Context, urls []*entity.URL) error {
+	args := m.Called(ctx, urls)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) FindByShortKey(ctx context.Context, shortKey *valueobject.ShortKey) (*entity.URL, error) {
 	args := m.Called(ctx, shortKey)
 	if args.Get(0) == nil {
@@ -44,11 +49,25 @@ func (m *MockURLRepository) FindByLongURL(ctx context.Context, longURL *valueobj
 	return args.Get(0).(*entity.URL), args.Error(1)
 }
 
+func (m *MockURLRepository) FindByLongURLAndOwner(ctx context.Context, longURL *valueobject.LongURL, owner string) (*entity.URL, error) {
+	args := m.Called(ctx, longURL, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entity.URL), args.Error(1)
+}
+
 func (m *MockURLRepository) Update(ctx context.Context, url *entity.URL) error {
 	args := m.Called(ctx, url)
 	return args.Error(0)
 }
 
+func (m *MockURLRepository) Replace(ctx context.Context, url *entity.URL) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
 func (m *MockURLRepository) Delete(ctx context.Context, shortKey *valueobject.ShortKey) error {
 	args := m.Called(ctx, shortKey)
 	return args.Error(0)
@@ -83,6 +102,57 @@ func (m *MockURLRepository) GetExpiredCount(ctx context.Context, before time.Tim
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockURLRepository) Upsert(ctx context.Context, url *entity.URL) (repository.UpsertResult, error) {
+	args := m.Called(ctx, url)
+	return args.Get(0).(repository.UpsertResult), args.Error(1)
+}
+
+func (m *MockURLRepository) SetEnabled(ctx context.Context, shortKey *valueobject.ShortKey, enabled bool) error {
+	args := m.Called(ctx, shortKey, enabled)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) Disable(ctx context.Context, shortKey *valueobject.ShortKey, reason string) error {
+	args := m.Called(ctx, shortKey, reason)
+	return args.Error(0)
+}
+
+func (m *MockURLRepository) RenewByCriteria(ctx context.Context, criteria repository.RenewCriteria, expiresAt time.Time) ([]string, error) {
+	args := m.Called(ctx, criteria, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockURLRepository) FindByHost(ctx context.Context, host string, cursor *repository.KeysetCursor, limit int) ([]*entity.URL, error) {
+	args := m.Called(ctx, host, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) FindTopByVisits(ctx context.Context, limit int, since *time.Time) ([]*entity.URL, error) {
+	args := m.Called(ctx, limit, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Error(1)
+}
+
+func (m *MockURLRepository) List(ctx context.Context, limit, offset int, orderBy string) ([]*entity.URL, int64, error) {
+	args := m.Called(ctx, limit, offset, orderBy)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).([]*entity.URL), args.Get(1).(int64), args.Error(2)
+}
+
 // MockCacheRepository is a mock implementation of CacheRepository.
 type MockCacheRepository struct {
 	mock.Mock
@@ -127,6 +197,11 @@ func (m *MockCacheRepository) SetTombstone(ctx context.Context, key string, reas
 	return args.Error(0)
 }
 
+func (m *MockCacheRepository) FlushNamespace(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
 // MockGeneratorService is a mock implementation of GeneratorService.
 type MockIDGenerator struct {
 	mock.Mock
@@ -155,6 +230,29 @@ func (m *MockShortKeyGenerator) DecodeToID(shortKey *valueobject.ShortKey) (int6
 	return args.Get(0).(int64), args.Error(1)
 }
 
+// newShortenUseCaseWithConfig builds a use case with config, nil for the defaults, whose
+// repository accepts any new URL, which is given the short key abc123 and cached.
+func newShortenUseCaseWithConfig(config *usecase.ShortenURLConfig) (*usecase.ShortenURLUseCase, *MockURLRepository, *MockCacheRepository) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	mockIDGen := new(MockIDGenerator)
+	mockShortKeyGen := new(MockShortKeyGenerator)
+
+	shortKey, _ := valueobject.NewShortKey("abc123")
+
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockIDGen.On("Generate").Return(int64(12345), nil)
+	mockShortKeyGen.On("GenerateFromID", int64(12345)).Return(shortKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+	mockURLRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
+	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+
+	genService := service.NewGeneratorService(mockIDGen, mockShortKeyGen)
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	return uc, mockURLRepo, mockCacheRepo
+}
+
 func TestShortenURL_Success(t *testing.T) {
 	// Setup
 	mockURLRepo := new(MockURLRepository)
@@ -185,6 +283,7 @@ func TestShortenURL_Success(t *testing.T) {
 	mockURLRepo.On("FindByLongURL", mock.Anything, longURL).Return(nil, usecase.ErrURLNotFound)
 	mockIDGen.On("Generate").Return(expectedID, nil)
 	mockShortKeyGen.On("GenerateFromID", expectedID).Return(shortKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
 	mockURLRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.URL")).Return(nil)
 	mockCacheRepo.On("SetCacheEntry", mock.Anything, shortKey.Value(), mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
 
@@ -205,6 +304,45 @@ func TestShortenURL_Success(t *testing.T) {
 	mockShortKeyGen.AssertExpectations(t)
 }
 
+func TestShortenURL_NilGeneratorService(t *testing.T) {
+	// Setup
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(
+		mockURLRepo,
+		mockCacheRepo,
+		nil,
+		"http://localhost:8080",
+		time.Hour,
+	)
+
+	// Mock expectations
+	mockURLRepo.On("FindByLongURL", mock.Anything, mock.Anything).Return(nil, usecase.ErrURLNotFound)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
+
+	// Execute - both the generated and the custom key paths need the generator
+	for _, req := range []*dto.ShortenURLRequest{
+		{LongURL: "https://example.com"},
+		{LongURL: "https://example.com", CustomKey: "mykey"},
+	} {
+		var (
+			resp *dto.ShortenURLResponse
+			err  error
+		)
+
+		assert.NotPanics(t, func() {
+			resp, err = uc.Shorten(context.Background(), req)
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, usecase.ErrInternalError)
+		assert.Nil(t, resp)
+	}
+
+	mockURLRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
 func TestShortenURL_DefaultTTL(t *testing.T) {
 	// Setup
 	mockURLRepo := new(MockURLRepository)
@@ -235,6 +373,7 @@ func TestShortenURL_DefaultTTL(t *testing.T) {
 	mockURLRepo.On("FindByLongURL", mock.Anything, longURL).Return(nil, usecase.ErrURLNotFound)
 	mockIDGen.On("Generate").Return(expectedID, nil)
 	mockShortKeyGen.On("GenerateFromID", expectedID).Return(shortKey, nil)
+	mockURLRepo.On("ExistsByShortKey", mock.Anything, mock.Anything).Return(false, nil)
 	mockURLRepo.On("Save", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
 		// Verify that expiration is set to 24 hours from now
 		if url.ExpiresAt == nil {
@@ -293,6 +432,7 @@ func TestGetLongURL_Success(t *testing.T) {
 
 	// Mock expectations - cache miss, then database hit
 	mockCacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(nil, assert.AnError)
+	mockCacheRepo.On("Get", mock.Anything, "abc123").Return("", assert.AnError)
 	mockURLRepo.On("FindByShortKey", mock.Anything, shortKey).Return(url, nil)
 	mockURLRepo.On("IncrementVisitCount", mock.Anything, shortKey).Return(nil)
 	mockCacheRepo.On("SetCacheEntry", mock.Anything, "abc123", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
@@ -309,6 +449,36 @@ func TestGetLongURL_Success(t *testing.T) {
 	mockCacheRepo.AssertExpectations(t)
 }
 
+func TestGetLongURL_LegacyStringCacheHit(t *testing.T) {
+	// Setup
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+
+	uc := usecase.NewShortenURLUseCase(
+		mockURLRepo,
+		mockCacheRepo,
+		nil,
+		"http://localhost:8080",
+		time.Hour,
+	)
+
+	// Mock expectations - no structured entry, only the plain string written by the fallback path
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "legacy1").Return(nil, assert.AnError)
+	mockCacheRepo.On("Get", mock.Anything, "legacy1").Return("https://example.com/legacy", nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.AnythingOfType("*valueobject.ShortKey")).Return(nil)
+
+	// Execute
+	redirectURL, err := uc.GetLongURL(context.Background(), "legacy1")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/legacy", redirectURL)
+
+	// Verify the database was never consulted
+	mockURLRepo.AssertNotCalled(t, "FindByShortKey", mock.Anything, mock.Anything)
+	mockCacheRepo.AssertExpectations(t)
+}
+
 func TestGetStats_Success(t *testing.T) {
 	// Setup
 	mockURLRepo := new(MockURLRepository)
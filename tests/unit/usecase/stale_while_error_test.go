@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+var errDatabaseDown = errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+
+func newStaleWhileErrorUseCase(urlRepo *MockURLRepository, cacheRepo *MockCacheRepository, enabled bool) *usecase.ShortenURLUseCase {
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.EarlyRefreshProbability = 0
+	config.StaleWhileError = enabled
+	config.StaleWindow = 10 * time.Minute
+
+	return usecase.NewShortenURLUseCaseWithConfig(urlRepo, cacheRepo, genService, "http://localhost:8080", time.Hour, config)
+}
+
+// staleEntry returns a cache entry whose cache TTL ran out age ago.
+func staleEntry(age time.Duration) *repository.CacheEntry {
+	cachedUntil := time.Now().Add(-age)
+
+	return &repository.CacheEntry{
+		LongURL:     "https://example.com/stale",
+		CreatedAt:   cachedUntil.Add(-time.Hour),
+		CachedUntil: &cachedUntil,
+	}
+}
+
+func TestStaleWhileError_CleanNotFoundStillNotFound(t *testing.T) {
+	urlRepo := new(MockURLRepository)
+	cacheRepo := new(MockCacheRepository)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "stale").Return(staleEntry(time.Minute), nil)
+	cacheRepo.On("Get", mock.Anything, "stale").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+	cacheRepo.On("SetTombstone", mock.Anything, "stale", repository.TombstoneNotFound, time.Hour).Return(nil)
+
+	uc := newStaleWhileErrorUseCase(urlRepo, cacheRepo, true)
+
+	_, err := uc.GetLongURL(context.Background(), "stale")
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+	cacheRepo.AssertCalled(t, "SetTombstone", mock.Anything, "stale", repository.TombstoneNotFound, time.Hour)
+}
+
+func TestStaleWhileError_DatabaseErrorServesStaleEntry(t *testing.T) {
+	urlRepo := new(MockURLRepository)
+	cacheRepo := new(MockCacheRepository)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "stale").Return(staleEntry(2*time.Minute), nil)
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, errDatabaseDown)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(errDatabaseDown).Maybe()
+
+	uc := newStaleWhileErrorUseCase(urlRepo, cacheRepo, true)
+
+	longURL, err := uc.GetLongURL(context.Background(), "stale")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/stale", longURL)
+	cacheRepo.AssertNotCalled(t, "SetTombstone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStaleWhileError_DatabaseErrorBeyondWindowFails(t *testing.T) {
+	urlRepo := new(MockURLRepository)
+	cacheRepo := new(MockCacheRepository)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "stale").Return(staleEntry(20*time.Minute), nil)
+	cacheRepo.On("Get", mock.Anything, "stale").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, errDatabaseDown)
+
+	uc := newStaleWhileErrorUseCase(urlRepo, cacheRepo, true)
+
+	_, err := uc.GetLongURL(context.Background(), "stale")
+	assert.ErrorIs(t, err, usecase.ErrInternalError)
+	cacheRepo.AssertNotCalled(t, "SetTombstone", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestStaleWhileError_DisabledTreatsDatabaseErrorAsNotFound(t *testing.T) {
+	urlRepo := new(MockURLRepository)
+	cacheRepo := new(MockCacheRepository)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "stale").Return(staleEntry(2*time.Minute), nil)
+	cacheRepo.On("Get", mock.Anything, "stale").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, errDatabaseDown)
+	cacheRepo.On("SetTombstone", mock.Anything, "stale", repository.TombstoneNotFound, time.Hour).Return(nil)
+
+	uc := newStaleWhileErrorUseCase(urlRepo, cacheRepo, false)
+
+	_, err := uc.GetLongURL(context.Background(), "stale")
+	assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+}
+
+func TestStaleWhileError_RetainsEntriesForStaleWindow(t *testing.T) {
+	shortKey, _ := valueobject.NewShortKey("fresh")
+	longURL, _ := valueobject.NewLongURL("https://example.com/fresh")
+
+	urlRepo := new(MockURLRepository)
+	cacheRepo := new(MockCacheRepository)
+	cacheRepo.On("GetCacheEntry", mock.Anything, "fresh").Return(nil, errors.New("cache miss"))
+	cacheRepo.On("Get", mock.Anything, "fresh").Return("", errors.New("cache miss"))
+	urlRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(entity.NewURL(shortKey, longURL), nil)
+	urlRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	// The store keeps the entry for the cache TTL plus the stale window,
+	// while the entry itself records when its cache TTL ends
+	cacheRepo.On("SetCacheEntry", mock.Anything, "fresh", mock.MatchedBy(func(entry *repository.CacheEntry) bool {
+		return entry.CachedUntil != nil && time.Until(*entry.CachedUntil) <= time.Hour
+	}), time.Hour+10*time.Minute).Return(nil)
+
+	uc := newStaleWhileErrorUseCase(urlRepo, cacheRepo, true)
+
+	_, err := uc.GetLongURL(context.Background(), "fresh")
+	require.NoError(t, err)
+	cacheRepo.AssertExpectations(t)
+}
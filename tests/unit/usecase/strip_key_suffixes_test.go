@@ -0,0 +1,58 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+)
+
+func TestResolve_StripsConfiguredKeySuffixes(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	config := usecase.DefaultShortenURLConfig()
+	config.StripKeySuffixes = []string{".html", "/", ".", ")"}
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour, config)
+
+	mockCacheRepo.On("GetCacheEntry", mock.Anything, "abc123").Return(&repository.CacheEntry{
+		LongURL:   "https://example.com/docs",
+		CreatedAt: time.Now(),
+	}, nil)
+	mockURLRepo.On("IncrementVisitCount", mock.Anything, mock.Anything).Return(nil)
+
+	for _, requested := range []string{"abc123/", "abc123.", "abc123).", "abc123.html", "abc123"} {
+		t.Run(requested, func(t *testing.T) {
+			longURL, _, err := uc.Resolve(context.Background(), requested)
+
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com/docs", longURL)
+		})
+	}
+
+	mockCacheRepo.AssertNotCalled(t, "GetCacheEntry", mock.Anything, "abc123.")
+}
+
+func TestTrimKeySuffixes(t *testing.T) {
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	disabled := usecase.NewShortenURLUseCase(new(MockURLRepository), new(MockCacheRepository), genService, "http://localhost:8080", time.Hour)
+	assert.Equal(t, "abc123.", disabled.TrimKeySuffixes("abc123."), "nothing is stripped by default")
+
+	config := usecase.DefaultShortenURLConfig()
+	config.StripKeySuffixes = []string{".", ")"}
+
+	uc := usecase.NewShortenURLUseCaseWithConfig(new(MockURLRepository), new(MockCacheRepository), genService, "http://localhost:8080", time.Hour, config)
+	assert.Equal(t, "abc123", uc.TrimKeySuffixes("abc123).."))
+	assert.Equal(t, ".", uc.TrimKeySuffixes("."), "a key is never trimmed to nothing")
+	assert.Equal(t, "abc.123", uc.TrimKeySuffixes("abc.123"), "only trailing text is stripped")
+}
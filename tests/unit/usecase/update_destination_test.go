@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func newUpdateUseCase() (*usecase.ShortenURLUseCase, *MockURLRepository, *MockCacheRepository) {
+	mockURLRepo := new(MockURLRepository)
+	mockCacheRepo := new(MockCacheRepository)
+	genService := service.NewGeneratorService(new(MockIDGenerator), new(MockShortKeyGenerator))
+
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+	return uc, mockURLRepo, mockCacheRepo
+}
+
+func storedURL(t *testing.T, key, destination string) *entity.URL {
+	t.Helper()
+
+	shortKey, err := valueobject.NewShortKey(key)
+	require.NoError(t, err)
+
+	longURL, err := valueobject.NewLongURL(destination)
+	require.NoError(t, err)
+
+	url := entity.NewURL(shortKey, longURL)
+	url.VisitCount = 7
+	url.SetExpiration(24 * time.Hour)
+
+	return url
+}
+
+func TestUpdateDestination_RepointsAndDeletesCacheEntry(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+
+	existing := storedURL(t, "docs", "https://example.com/old")
+	expiresAt := *existing.ExpiresAt
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(existing, nil)
+	mockURLRepo.On("Update", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+		return url.LongURL.Value() == "https://example.com/new"
+	})).Return(nil)
+	mockCacheRepo.On("Delete", mock.Anything, "docs").Return(nil)
+
+	resp, err := uc.UpdateDestination(context.Background(), "docs", "https://example.com/new")
+	require.NoError(t, err)
+
+	assert.Equal(t, "docs", resp.ShortKey)
+	assert.Equal(t, "https://example.com/new", resp.LongURL)
+	assert.Equal(t, expiresAt.Format(time.RFC3339), resp.ExpiresAt)
+	assert.Equal(t, int64(7), existing.VisitCount, "the visit count is kept")
+
+	// The entry is removed, never rewritten with the new destination
+	mockCacheRepo.AssertCalled(t, "Delete", mock.Anything, "docs")
+	mockCacheRepo.AssertNotCalled(t, "SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockURLRepo.AssertExpectations(t)
+}
+
+func TestUpdateDestination_CacheDeleteFailureStillSucceeds(t *testing.T) {
+	uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+
+	mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(storedURL(t, "docs", "https://example.com/old"), nil)
+	mockURLRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	mockCacheRepo.On("Delete", mock.Anything, "docs").Return(assert.AnError)
+
+	_, err := uc.UpdateDestination(context.Background(), "docs", "https://example.com/new")
+	require.NoError(t, err)
+	mockCacheRepo.AssertCalled(t, "Delete", mock.Anything, "docs")
+}
+
+func TestUpdateDestination_Errors(t *testing.T) {
+	t.Run("invalid destination", func(t *testing.T) {
+		uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+
+		_, err := uc.UpdateDestination(context.Background(), "docs", "")
+		assert.ErrorIs(t, err, valueobject.ErrEmptyURL)
+
+		mockURLRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockCacheRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+		mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+
+		_, err := uc.UpdateDestination(context.Background(), "missing", "https://example.com/new")
+		assert.ErrorIs(t, err, usecase.ErrURLNotFound)
+		mockCacheRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired URL", func(t *testing.T) {
+		uc, mockURLRepo, _ := newUpdateUseCase()
+
+		expired := storedURL(t, "old", "https://example.com/old")
+		expired.SetExpiration(-time.Hour)
+		mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(expired, nil)
+
+		_, err := uc.UpdateDestination(context.Background(), "old", "https://example.com/new")
+		assert.ErrorIs(t, err, usecase.ErrURLExpired)
+		mockURLRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update failure keeps cache", func(t *testing.T) {
+		uc, mockURLRepo, mockCacheRepo := newUpdateUseCase()
+		mockURLRepo.On("FindByShortKey", mock.Anything, mock.Anything).Return(storedURL(t, "docs", "https://example.com/old"), nil)
+		mockURLRepo.On("Update", mock.Anything, mock.Anything).Return(assert.AnError)
+
+		_, err := uc.UpdateDestination(context.Background(), "docs", "https://example.com/new")
+		assert.ErrorIs(t, err, assert.AnError)
+		mockCacheRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
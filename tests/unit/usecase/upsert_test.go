@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Shofyan/url-shortener/internal/application/usecase"
+	"github.com/Shofyan/url-shortener/internal/domain/entity"
+	"github.com/Shofyan/url-shortener/internal/domain/repository"
+	"github.com/Shofyan/url-shortener/internal/domain/service"
+	"github.com/Shofyan/url-shortener/internal/domain/valueobject"
+)
+
+func TestUpsert_Outcomes(t *testing.T) {
+	tests := []struct {
+		name              string
+		result            repository.UpsertResult
+		expectCached      bool
+		expectInvalidated bool
+	}{
+		{"Creates missing mapping", repository.UpsertCreated, true, false},
+		{"Updates changed destination", repository.UpsertUpdated, false, true},
+		{"No-op for identical destination", repository.UpsertUnchanged, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockURLRepo := new(MockURLRepository)
+			mockCacheRepo := new(MockCacheRepository)
+			mockIDGen := new(MockIDGenerator)
+			genService := service.NewGeneratorService(mockIDGen, new(MockShortKeyGenerator))
+
+			uc := usecase.NewShortenURLUseCase(mockURLRepo, mockCacheRepo, genService, "http://localhost:8080", time.Hour)
+
+			shortKey, _ := valueobject.NewShortKey("promo")
+			longURL, _ := valueobject.NewLongURL("https://example.com/landing")
+
+			mockIDGen.On("Generate").Return(int64(777), nil)
+			mockURLRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(url *entity.URL) bool {
+				return url.ShortKey.Value() == "promo" && url.LongURL.Value() == "https://example.com/landing"
+			})).Return(tt.result, nil)
+
+			switch {
+			case tt.expectCached:
+				mockCacheRepo.On("SetCacheEntry", mock.Anything, "promo", mock.AnythingOfType("*repository.CacheEntry"), mock.AnythingOfType("time.Duration")).Return(nil)
+			case tt.expectInvalidated:
+				// The stored row may be disabled, so its cache entry is dropped rather than overwritten
+				mockCacheRepo.On("Delete", mock.Anything, "promo").Return(nil)
+			default:
+				mockURLRepo.On("FindByShortKey", mock.Anything, shortKey).Return(entity.NewURL(shortKey, longURL), nil)
+			}
+
+			resp, result, err := uc.Upsert(context.Background(), "promo", "https://example.com/landing", 3600)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.result, result)
+			assert.Equal(t, "promo", resp.ShortKey)
+			assert.Equal(t, "https://example.com/landing", resp.LongURL)
+
+			if !tt.expectCached {
+				mockCacheRepo.AssertNotCalled(t, "SetCacheEntry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			mockURLRepo.AssertExpectations(t)
+			mockCacheRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpsert_InvalidShortKey(t *testing.T) {
+	uc := usecase.NewShortenURLUseCase(new(MockURLRepository), new(MockCacheRepository), nil, "http://localhost:8080", time.Hour)
+
+	_, _, err := uc.Upsert(context.Background(), "bad key!", "https://example.com", 0)
+
+	assert.ErrorIs(t, err, valueobject.ErrInvalidShortKey)
+}
+
+func TestUpsert_RejectsReservedShortKey(t *testing.T) {
+	mockURLRepo := new(MockURLRepository)
+	uc := usecase.NewShortenURLUseCase(mockURLRepo, new(MockCacheRepository), nil, "http://localhost:8080", time.Hour)
+
+	for _, key := range []string{"api", "health", "admin"} {
+		_, _, err := uc.Upsert(context.Background(), key, "https://example.com", 0)
+
+		assert.ErrorIs(t, err, valueobject.ErrReservedShortKey, key)
+	}
+
+	mockURLRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
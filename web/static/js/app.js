@@ -546,11 +546,18 @@ document.addEventListener('DOMContentLoaded', () => {
 });
 
 // Clean Stats functionality
+
+// Admin endpoints require the admin token as a bearer token
+function adminHeaders() {
+    const token = document.getElementById('admin_token').value.trim();
+    return token ? { 'Authorization': `Bearer ${token}` } : {};
+}
+
 function loadCleanupStats() {
     const statsDiv = document.getElementById('cleanup-stats');
     statsDiv.innerHTML = '<div class="loading">Loading cleanup statistics...</div>';
 
-    fetch('/api/admin/cleanup/stats')
+    fetch('/api/admin/cleanup/stats', { headers: adminHeaders() })
         .then(response => response.json())
         .then(data => {
             if (data.error) {
@@ -658,6 +665,7 @@ function triggerManualCleanup() {
         method: 'POST',
         headers: {
             'Content-Type': 'application/json',
+            ...adminHeaders(),
         },
         body: JSON.stringify({
             batch_size: parseInt(batchSize)